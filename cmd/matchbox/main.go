@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/coreos/pkg/flagutil"
 	web "github.com/poseidon/matchbox/matchbox/http"
@@ -15,7 +18,9 @@ import (
 	"github.com/poseidon/matchbox/matchbox/storage"
 	"github.com/poseidon/matchbox/matchbox/tlsutil"
 	"github.com/poseidon/matchbox/matchbox/version"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 var (
@@ -25,26 +30,51 @@ var (
 
 func main() {
 	flags := struct {
-		address      string
-		rpcAddress   string
-		dataPath     string
-		assetsPath   string
-		logLevel     string
-		grpcCAFile   string
-		grpcCertFile string
-		grpcKeyFile  string
-		tlsCertFile  string
-		tlsKeyFile   string
-		tlsEnabled   bool
-		keyRingPath  string
-		version      bool
-		help         bool
+		address        string
+		rpcAddress     string
+		metricsAddress string
+		dataPath       string
+		assetsPath     string
+		store          string
+		etcdEndpoints  string
+		etcdPrefix     string
+		etcdCAFile     string
+		etcdCertFile   string
+		etcdKeyFile    string
+		etcdMaxTxnOps  int
+		revisions      int
+		instances      int
+		auditLog       string
+		logLevel       string
+		grpcCAFile     string
+		grpcCertFile   string
+		grpcKeyFile    string
+		rpcPolicy      string
+		tlsCertFile    string
+		tlsKeyFile     string
+		tlsEnabled     bool
+		keyRingPath    string
+		version        bool
+		help           bool
 	}{}
 	flag.StringVar(&flags.address, "address", "127.0.0.1:8080", "HTTP listen address")
 	flag.StringVar(&flags.rpcAddress, "rpc-address", "", "RPC listen address")
+	flag.StringVar(&flags.metricsAddress, "metrics-address", "", "Prometheus metrics listen address (defaults to /metrics on the HTTP server)")
 	flag.StringVar(&flags.dataPath, "data-path", "/var/lib/matchbox", "Path to data directory")
 	flag.StringVar(&flags.assetsPath, "assets-path", "/var/lib/matchbox/assets", "Path to static assets")
 
+	// Storage
+	flag.StringVar(&flags.store, "store", "file", "Storage backend (file, etcd)")
+	flag.StringVar(&flags.etcdEndpoints, "etcd-endpoints", "http://127.0.0.1:2379", "Comma separated etcd endpoints (etcd store)")
+	flag.StringVar(&flags.etcdPrefix, "etcd-prefix", "/matchbox", "etcd key prefix (etcd store)")
+	flag.StringVar(&flags.etcdCAFile, "etcd-ca-file", "", "Path to the CA bundle to verify etcd server certificates")
+	flag.StringVar(&flags.etcdCertFile, "etcd-cert-file", "", "Path to the etcd client TLS certificate file")
+	flag.StringVar(&flags.etcdKeyFile, "etcd-key-file", "", "Path to the etcd client TLS key file")
+	flag.IntVar(&flags.etcdMaxTxnOps, "etcd-max-txn-ops", 128, "Maximum operations per etcd transaction, as configured for the etcd cluster")
+	flag.IntVar(&flags.revisions, "revisions", storage.DefaultRevisions, "Number of revisions kept of each group, profile, and template")
+	flag.IntVar(&flags.instances, "instances", storage.DefaultInstances, "Number of machine instances kept in memory")
+	flag.StringVar(&flags.auditLog, "audit-log", "", "Path to append the JSON lines audit log of gRPC writes")
+
 	// Log levels https://github.com/sirupsen/logrus/blob/master/logrus.go#L36
 	flag.StringVar(&flags.logLevel, "log-level", "info", "Set the logging level")
 
@@ -54,6 +84,7 @@ func main() {
 
 	// gRPC TLS Client Authentication
 	flag.StringVar(&flags.grpcCAFile, "ca-file", "/etc/matchbox/ca.crt", "Path to the CA verify and authenticate client certificates")
+	flag.StringVar(&flags.rpcPolicy, "rpc-policy", "", "Path to a policy file granting roles to gRPC client certificates")
 
 	// Signing
 	flag.StringVar(&flags.keyRingPath, "key-ring-path", "", "Path to a private keyring file")
@@ -86,8 +117,33 @@ func main() {
 	}
 
 	// validate arguments
-	if finfo, err := os.Stat(flags.dataPath); err != nil || !finfo.IsDir() {
-		log.Fatal("A valid -data-path is required")
+	switch flags.store {
+	case "file":
+		if finfo, err := os.Stat(flags.dataPath); err != nil || !finfo.IsDir() {
+			log.Fatal("A valid -data-path is required")
+		}
+	case "etcd":
+		if flags.etcdEndpoints == "" {
+			log.Fatal("Provide -etcd-endpoints to use the etcd store")
+		}
+		if flags.etcdCertFile != "" || flags.etcdKeyFile != "" || flags.etcdCAFile != "" {
+			for _, path := range []string{flags.etcdCAFile, flags.etcdCertFile, flags.etcdKeyFile} {
+				if _, err := os.Stat(path); err != nil {
+					log.Fatalf("Provide a valid -etcd-ca-file, -etcd-cert-file, and -etcd-key-file: %v", err)
+				}
+			}
+		}
+		if flags.etcdMaxTxnOps < 1 {
+			log.Fatal("Provide a positive -etcd-max-txn-ops")
+		}
+	default:
+		log.Fatalf("Provide a valid -store (file, etcd): %s", flags.store)
+	}
+	if flags.revisions < 1 {
+		log.Fatalf("Provide a positive number of -revisions: %d", flags.revisions)
+	}
+	if flags.instances < 1 {
+		log.Fatalf("Provide a positive number of -instances: %d", flags.instances)
 	}
 	if flags.assetsPath != "" {
 		if finfo, err := os.Stat(flags.assetsPath); err != nil || !finfo.IsDir() {
@@ -133,14 +189,77 @@ func main() {
 	}
 
 	// storage
-	store := storage.NewFileStore(&storage.Config{
-		Root:   flags.dataPath,
-		Logger: log,
-	})
+	var store storage.Store
+	switch flags.store {
+	case "etcd":
+		etcdConfig := clientv3.Config{
+			Endpoints:   strings.Split(flags.etcdEndpoints, ","),
+			DialTimeout: 5 * time.Second,
+		}
+		if flags.etcdCertFile != "" {
+			tlsinfo := tlsutil.TLSInfo{
+				CertFile: flags.etcdCertFile,
+				KeyFile:  flags.etcdKeyFile,
+				CAFile:   flags.etcdCAFile,
+			}
+			etcdConfig.TLS, err = tlsinfo.ClientConfig()
+			if err != nil {
+				log.Fatalf("Invalid etcd TLS credentials: %v", err)
+			}
+		}
+		client, err := clientv3.New(etcdConfig)
+		if err != nil {
+			log.Fatalf("failed to create etcd client: %v", err)
+		}
+		defer client.Close()
+		log.Infof("Using etcd store at %s with prefix %s", flags.etcdEndpoints, flags.etcdPrefix)
+		store = storage.NewEtcdStore(&storage.EtcdConfig{
+			Client:    client,
+			Prefix:    flags.etcdPrefix,
+			MaxTxnOps: flags.etcdMaxTxnOps,
+			Revisions: flags.revisions,
+			Logger:    log,
+		})
+	default:
+		store = storage.NewFileStore(&storage.Config{
+			Root:      flags.dataPath,
+			Revisions: flags.revisions,
+			Logger:    log,
+		})
+	}
+	// keep revisions with the resources, when the store supports it
+	history, ok := store.(storage.HistoryStore)
+	if !ok {
+		history = storage.NewMemHistoryStore(flags.revisions)
+	}
+	store = storage.NewInstrumentedStore(store)
+
+	// cache Groups and Profiles in memory while the store can be watched
+	cache := storage.NewCache(&storage.CacheConfig{Store: store})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := cache.Watch(ctx); err == nil {
+		store = cache
+	} else {
+		log.Warningf("Serving Groups and Profiles without caching: %v", err)
+	}
+
+	// audit gRPC writes in memory, unless a log file is given
+	audit := storage.NewMemAuditStore()
+	if flags.auditLog != "" {
+		audit, err = storage.NewFileAuditStore(flags.auditLog)
+		if err != nil {
+			log.Fatalf("failed to open audit log: %v", err)
+		}
+		log.Infof("Using audit log %s", flags.auditLog)
+	}
 
 	// core logic
 	server := server.NewServer(&server.Config{
-		Store: store,
+		Store:     store,
+		Instances: storage.NewMemInstanceStore(flags.instances),
+		History:   history,
+		Audit:     audit,
 	})
 
 	// gRPC Server (feature disabled by default)
@@ -162,17 +281,38 @@ func main() {
 		if err != nil {
 			log.Fatalf("Invalid TLS credentials: %v", err)
 		}
-		grpcServer := rpc.NewServer(server, tlscfg)
+		var policy *rpc.Policy
+		if flags.rpcPolicy != "" {
+			policy, err = rpc.LoadPolicy(flags.rpcPolicy)
+			if err != nil {
+				log.Fatalf("Invalid -rpc-policy: %v", err)
+			}
+			log.Infof("Using gRPC authorization policy: %s", flags.rpcPolicy)
+		}
+		grpcServer := rpc.NewServer(server, tlscfg, policy)
 		go grpcServer.Serve(lis)
 		defer grpcServer.Stop()
 	}
 
+	// Prometheus metrics (on the HTTP server by default)
+	if flags.metricsAddress != "" {
+		log.Infof("Starting matchbox metrics server on %s", flags.metricsAddress)
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		go func() {
+			if err := http.ListenAndServe(flags.metricsAddress, mux); err != nil {
+				log.Fatalf("failed to start listening: %v", err)
+			}
+		}()
+	}
+
 	config := &web.Config{
 		Core:          server,
 		Logger:        log,
 		AssetsPath:    flags.assetsPath,
 		Signer:        signer,
 		ArmoredSigner: armoredSigner,
+		Metrics:       flags.metricsAddress == "",
 	}
 	httpServer := web.NewServer(config)
 
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// applyCmd applies a data directory of resources together.
+var applyCmd = &cobra.Command{
+	Use:   "apply --filename DATA_PATH",
+	Short: "Apply a directory of groups, profiles, and templates",
+	Long: `Apply the groups, profiles, and templates in a matchbox data directory
+(with groups, profiles, ignition, generic, cloud, and partials subdirectories)
+together. Resources are validated together and either all are written or
+none are.`,
+	Run: runApplyCmd,
+}
+
+func init() {
+	RootCmd.AddCommand(applyCmd)
+	applyCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "data directory to apply")
+	applyCmd.MarkFlagRequired("filename")
+	applyCmd.MarkFlagDirname("filename")
+	applyCmd.Flags().BoolVar(&flagDryRun, "dry-run", false, "only validate the resources")
+}
+
+func runApplyCmd(cmd *cobra.Command, args []string) {
+	if len(flagFilename) == 0 {
+		cmd.Help()
+		return
+	}
+	if err := validateArgs(cmd, args); err != nil {
+		return
+	}
+
+	batch, err := loadBatch(flagFilename)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	client := mustClientFromCmd(cmd)
+	req := &pb.BatchApplyRequest{
+		Batch:  batch,
+		DryRun: flagDryRun,
+	}
+	_, err = client.Batch.Apply(context.TODO(), req)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+}
+
+// loadBatch returns a Batch which writes all Groups, Profiles, and templates
+// in a data directory.
+func loadBatch(root string) (*storagepb.Batch, error) {
+	if finfo, err := os.Stat(root); err != nil {
+		return nil, err
+	} else if !finfo.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+	store := storage.NewFileStore(&storage.Config{Root: root})
+	if validator, ok := store.(storage.Validator); ok {
+		invalid, err := validator.Invalid()
+		if err != nil {
+			return nil, err
+		}
+		if len(invalid) > 0 {
+			r := invalid[0]
+			return nil, fmt.Errorf("can't parse %s %s: %s", r.Kind, r.Id, r.Error)
+		}
+	}
+	return storage.Snapshot(store)
+}
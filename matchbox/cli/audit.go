@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// auditCmd lists audit log entries.
+var (
+	auditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "List audited calls which changed resources",
+		Long: `List the gRPC calls which wrote or deleted groups, profiles, and templates,
+oldest first, with the subject of the client certificate which made each call.
+With --diff, show the diff of each changed resource.`,
+		Run: runAuditCmd,
+	}
+
+	flagSubject string
+	flagKind    string
+	flagName    string
+	flagLimit   int32
+	flagDiff    bool
+)
+
+func init() {
+	RootCmd.AddCommand(auditCmd)
+	auditCmd.Flags().StringVar(&flagSubject, "subject", "", "only list calls by this client certificate subject")
+	auditCmd.Flags().StringVar(&flagKind, "kind", "", "only list calls which changed this kind of resource (e.g. Ignition)")
+	auditCmd.Flags().StringVar(&flagName, "name", "", "only list calls which changed resources with this name")
+	auditCmd.Flags().Int32Var(&flagLimit, "limit", 20, "list at most this many recent calls (0 for all)")
+	auditCmd.Flags().BoolVar(&flagDiff, "diff", false, "show the diff of each changed resource")
+}
+
+func runAuditCmd(cmd *cobra.Command, args []string) {
+	if err := validateArgs(cmd, args); err != nil {
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	req := &pb.AuditListRequest{
+		Subject: flagSubject,
+		Kind:    flagKind,
+		Name:    flagName,
+		Limit:   flagLimit,
+	}
+	resp, err := client.Audit.List(context.TODO(), req)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	if flagDiff {
+		for _, entry := range resp.Entries {
+			fmt.Printf("# %s %s %s %s\n", formatUnix(entry.Timestamp), formatAuthor(entry.Subject), entry.Method, entry.Code)
+			for _, change := range entry.Changes {
+				fmt.Print(change.Diff)
+			}
+		}
+		return
+	}
+
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "TIMESTAMP\tSUBJECT\tMETHOD\tCODE\tRESOURCES\n")
+	for _, entry := range resp.Entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", formatUnix(entry.Timestamp), formatAuthor(entry.Subject), entry.Method, entry.Code, formatChanges(entry.Changes))
+	}
+}
+
+// formatChanges formats the resources changed by an audited call as a comma
+// separated list.
+func formatChanges(changes []*storagepb.AuditChange) string {
+	formatted := make([]string, 0, len(changes))
+	for _, change := range changes {
+		formatted = append(formatted, change.Kind+" "+change.Name)
+	}
+	if len(formatted) == 0 {
+		return "-"
+	}
+	return strings.Join(formatted, ", ")
+}
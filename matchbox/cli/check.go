@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/poseidon/matchbox/matchbox/storage"
+)
+
+// checkCmd reports dangling references in a data directory.
+var checkCmd = &cobra.Command{
+	Use:   "check DATA_PATH",
+	Short: "Check a data directory for dangling references",
+	Long: `Check a matchbox data directory for Groups which reference missing Profiles
+and Profiles which reference missing parent Profiles or templates`,
+	Run: runCheckCmd,
+}
+
+func init() {
+	RootCmd.AddCommand(checkCmd)
+}
+
+func runCheckCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+
+	store := storage.NewFileStore(&storage.Config{Root: args[0]})
+	dangling, err := storage.Check(store)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	if len(dangling) == 0 {
+		return
+	}
+
+	tw := newTabWriter(os.Stdout)
+	// legend
+	fmt.Fprintf(tw, "KIND\tID\tFIELD\tMISSING\n")
+	for _, ref := range dangling {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", ref.Kind, ref.Id, ref.Field, ref.Target)
+	}
+	tw.Flush()
+	exitWithError(ExitError, fmt.Errorf("found %d dangling references", len(dangling)))
+}
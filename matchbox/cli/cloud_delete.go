@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// cloudDeleteCmd deletes a Cloud-Config template.
+var (
+	cloudDeleteCmd = &cobra.Command{
+		Use:   "delete TEMPLATE_NAME",
+		Short: "Delete a Cloud-Config template",
+		Long:  `Delete a Cloud-Config template`,
+		Run:   runCloudDeleteCmd,
+	}
+	flagForce bool
+)
+
+func init() {
+	cloudCmd.AddCommand(cloudDeleteCmd)
+	cloudDeleteCmd.Flags().BoolVar(&flagForce, "force", false, "delete even if Profiles reference the template")
+	cloudDeleteCmd.Flags().StringVar(&flagResourceVersion, "resource-version", "", "expected resource version of the existing template")
+}
+
+func runCloudDeleteCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	_, err := client.Cloud.CloudDelete(context.TODO(), &pb.CloudDeleteRequest{Name: args[0], Force: flagForce, ResourceVersion: flagResourceVersion})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+}
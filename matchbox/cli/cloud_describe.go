@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// cloudDescribeCmd shows a Cloud-Config template.
+var cloudDescribeCmd = &cobra.Command{
+	Use:   "describe TEMPLATE_NAME",
+	Short: "Describe a Cloud-Config template",
+	Long:  `Describe a Cloud-Config template`,
+	Run:   runCloudDescribeCmd,
+}
+
+func init() {
+	cloudCmd.AddCommand(cloudDescribeCmd)
+}
+
+func runCloudDescribeCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Cloud.CloudGet(context.TODO(), &pb.CloudGetRequest{Name: args[0]})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	os.Stdout.Write(resp.Config)
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// cloudListCmd lists Cloud-Config templates.
+var cloudListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List Cloud-Config templates",
+	Long:  `List Cloud-Config templates`,
+	Run:   runCloudListCmd,
+}
+
+func init() {
+	cloudCmd.AddCommand(cloudListCmd)
+}
+
+func runCloudListCmd(cmd *cobra.Command, args []string) {
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "NAME\tSIZE\tMODIFIED\n")
+
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Cloud.CloudList(context.TODO(), &pb.CloudListRequest{})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	for _, template := range resp.Templates {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", template.Name, template.Size, formatModified(template.Modified))
+	}
+}
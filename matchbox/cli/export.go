@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"io"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// exportCmd exports an archive of all resources.
+var (
+	exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export an archive of all groups, profiles, and templates",
+		Long: `Export an archive (tar) of all groups, profiles, and templates, which can
+be imported into any matchbox with bootcmd import. The archive is written to
+stdout unless --output is given.`,
+		Run: runExportCmd,
+	}
+
+	flagOutput string
+)
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&flagOutput, "output", "o", "", "file to write the archive to")
+}
+
+func runExportCmd(cmd *cobra.Command, args []string) {
+	if err := validateArgs(cmd, args); err != nil {
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	stream, err := client.Archive.Export(context.TODO(), &pb.ArchiveExportRequest{})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	out := os.Stdout
+	if flagOutput != "" {
+		out, err = os.Create(flagOutput)
+		if err != nil {
+			exitWithError(ExitError, err)
+		}
+		defer out.Close()
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			exitWithError(ExitError, err)
+		}
+		if _, err := out.Write(resp.Data); err != nil {
+			exitWithError(ExitError, err)
+		}
+	}
+}
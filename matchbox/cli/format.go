@@ -2,7 +2,11 @@ package cli
 
 import (
 	"io"
+	"strings"
 	"text/tabwriter"
+	"time"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
 // newTabWriter returns an initialized tab Writer writes tabbed text as
@@ -12,3 +16,35 @@ func newTabWriter(writer io.Writer) *tabwriter.Writer {
 	tw.Init(writer, 0, 8, 1, '\t', 0)
 	return tw
 }
+
+// formatUnix formats Unix seconds as an RFC 3339 UTC timestamp.
+func formatUnix(sec int64) string {
+	return time.Unix(sec, 0).UTC().Format(time.RFC3339)
+}
+
+// formatModified formats a modification time in Unix seconds, or "-" if the
+// time is unknown (zero).
+func formatModified(sec int64) string {
+	if sec == 0 {
+		return "-"
+	}
+	return formatUnix(sec)
+}
+
+// formatExpressions formats selector expressions as a comma separated list.
+func formatExpressions(exprs []*storagepb.SelectorRequirement) string {
+	formatted := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		formatted = append(formatted, expr.Expression())
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+// formatAuthor formats the author of a revision, or "-" if the author is
+// unknown.
+func formatAuthor(author string) string {
+	if author == "" {
+		return "-"
+	}
+	return author
+}
@@ -24,6 +24,8 @@ func init() {
 	genericCmd.AddCommand(genericPutCmd)
 	genericPutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create an Generic template")
 	genericPutCmd.MarkFlagRequired("filename")
+	genericPutCmd.Flags().StringVar(&flagResourceVersion, "resource-version", "", "expected resource version of the existing template")
+	genericPutCmd.Flags().BoolVar(&flagOverwrite, "overwrite", false, "replace an existing template")
 }
 
 func runGenericPutCmd(cmd *cobra.Command, args []string) {
@@ -40,7 +42,7 @@ func runGenericPutCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exitWithError(ExitError, err)
 	}
-	req := &pb.GenericPutRequest{Name: filepath.Base(flagFilename), Config: config}
+	req := &pb.GenericPutRequest{Name: filepath.Base(flagFilename), Config: config, ResourceVersion: putVersion()}
 	_, err = client.Generic.GenericPut(context.TODO(), req)
 	if err != nil {
 		exitWithError(ExitError, err)
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// genericListCmd lists Generic templates.
+var genericListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List Generic templates",
+	Long:  `List Generic templates`,
+	Run:   runGenericListCmd,
+}
+
+func init() {
+	genericCmd.AddCommand(genericListCmd)
+}
+
+func runGenericListCmd(cmd *cobra.Command, args []string) {
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "NAME\tSIZE\tMODIFIED\n")
+
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Generic.GenericList(context.TODO(), &pb.GenericListRequest{})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	for _, template := range resp.Templates {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", template.Name, template.Size, formatModified(template.Modified))
+	}
+}
@@ -24,6 +24,8 @@ func init() {
 	groupCmd.AddCommand(groupPutCmd)
 	groupPutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create a Group")
 	groupPutCmd.MarkFlagRequired("filename")
+	groupPutCmd.Flags().StringVar(&flagResourceVersion, "resource-version", "", "expected resource version of the existing Group")
+	groupPutCmd.Flags().BoolVar(&flagOverwrite, "overwrite", false, "replace an existing Group")
 	groupPutCmd.MarkFlagFilename("filename", "json")
 }
 
@@ -41,6 +43,7 @@ func runGroupPutCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exitWithError(ExitError, err)
 	}
+	group.ResourceVersion = putVersion()
 	req := &pb.GroupPutRequest{Group: group}
 	_, err = client.Groups.GroupPut(context.TODO(), req)
 	if err != nil {
@@ -32,7 +32,7 @@ func runGroupDescribeCmd(cmd *cobra.Command, args []string) {
 	defer tw.Flush()
 
 	// legend
-	fmt.Fprintf(tw, "ID\tNAME\tSELECTORS\tPROFILE\tMETADATA\n")
+	fmt.Fprintf(tw, "ID\tNAME\tSELECTORS\tEXPRESSIONS\tPROFILE\tPRIORITY\tMETADATA\tVERSION\n")
 
 	client := mustClientFromCmd(cmd)
 	request := &pb.GroupGetRequest{
@@ -43,5 +43,5 @@ func runGroupDescribeCmd(cmd *cobra.Command, args []string) {
 		return
 	}
 	g := resp.Group
-	fmt.Fprintf(tw, "%s\t%s\t%s\t%#v\t%s\n", g.Id, g.Name, g.Selector, g.Profile, g.Metadata)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%#v\t%d\t%s\t%s\n", g.Id, g.Name, g.Selector, formatExpressions(g.MatchExpressions), g.Profile, g.Priority, g.Metadata, g.ResourceVersion)
 }
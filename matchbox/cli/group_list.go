@@ -26,7 +26,7 @@ func runGroupListCmd(cmd *cobra.Command, args []string) {
 	tw := newTabWriter(os.Stdout)
 	defer tw.Flush()
 	// legend
-	fmt.Fprintf(tw, "ID\tGROUP NAME\tSELECTORS\tPROFILE\n")
+	fmt.Fprintf(tw, "ID\tGROUP NAME\tSELECTORS\tPROFILE\tPRIORITY\n")
 
 	client := mustClientFromCmd(cmd)
 	resp, err := client.Groups.GroupList(context.TODO(), &pb.GroupListRequest{})
@@ -34,6 +34,6 @@ func runGroupListCmd(cmd *cobra.Command, args []string) {
 		return
 	}
 	for _, group := range resp.Groups {
-		fmt.Fprintf(tw, "%s\t%s\t%#v\t%s\n", group.Id, group.Name, group.Selector, group.Profile)
+		fmt.Fprintf(tw, "%s\t%s\t%#v\t%s\t%d\n", group.Id, group.Name, group.Selector, group.Profile, group.Priority)
 	}
 }
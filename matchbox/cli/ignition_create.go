@@ -15,15 +15,22 @@ var (
 	ignitionPutCmd = &cobra.Command{
 		Use:   "create --file FILENAME",
 		Short: "Create an Ignition template",
-		Long:  `Create an Ignition template`,
-		Run:   runIgnitionPutCmd,
+		Long: `Create an Ignition template. Templates are validated and, if sample
+labels are given, trial rendered with the variables of the matching group.`,
+		Run: runIgnitionPutCmd,
 	}
+
+	flagDryRun bool
 )
 
 func init() {
 	ignitionCmd.AddCommand(ignitionPutCmd)
 	ignitionPutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create an Ignition template")
 	ignitionPutCmd.MarkFlagRequired("filename")
+	ignitionPutCmd.Flags().StringVar(&flagResourceVersion, "resource-version", "", "expected resource version of the existing template")
+	ignitionPutCmd.Flags().BoolVar(&flagOverwrite, "overwrite", false, "replace an existing template")
+	ignitionPutCmd.Flags().StringToStringVarP(&flagLabels, "label", "l", nil, "sample machine label to trial render the template with, may be repeated")
+	ignitionPutCmd.Flags().BoolVar(&flagDryRun, "dry-run", false, "only validate the Ignition template")
 }
 
 func runIgnitionPutCmd(cmd *cobra.Command, args []string) {
@@ -40,7 +47,13 @@ func runIgnitionPutCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exitWithError(ExitError, err)
 	}
-	req := &pb.IgnitionPutRequest{Name: filepath.Base(flagFilename), Config: config}
+	req := &pb.IgnitionPutRequest{
+		Name:            filepath.Base(flagFilename),
+		Config:          config,
+		Labels:          flagLabels,
+		DryRun:          flagDryRun,
+		ResourceVersion: putVersion(),
+	}
 	_, err = client.Ignition.IgnitionPut(context.TODO(), req)
 	if err != nil {
 		exitWithError(ExitError, err)
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+)
+
+// ignitionHistoryCmd lists the kept revisions of an Ignition template.
+var ignitionHistoryCmd = &cobra.Command{
+	Use:   "history TEMPLATE_NAME",
+	Short: "List revisions of an Ignition template",
+	Long:  `List the kept revisions of an Ignition template, oldest first`,
+	Run:   runIgnitionHistoryCmd,
+}
+
+func init() {
+	ignitionCmd.AddCommand(ignitionHistoryCmd)
+}
+
+func runIgnitionHistoryCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	req := &pb.HistoryListRequest{Kind: storage.KindIgnition, Name: args[0]}
+	resp, err := client.History.List(context.TODO(), req)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "REVISION\tTIMESTAMP\tAUTHOR\tDELETED\tSIZE\n")
+	for _, revision := range resp.Revisions {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%t\t%d\n", revision.Revision, formatUnix(revision.Timestamp), formatAuthor(revision.Author), revision.Deleted, len(revision.Contents))
+	}
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// ignitionListCmd lists Ignition templates.
+var ignitionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List Ignition templates",
+	Long:  `List Ignition templates`,
+	Run:   runIgnitionListCmd,
+}
+
+func init() {
+	ignitionCmd.AddCommand(ignitionListCmd)
+}
+
+func runIgnitionListCmd(cmd *cobra.Command, args []string) {
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "NAME\tSIZE\tMODIFIED\n")
+
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Ignition.IgnitionList(context.TODO(), &pb.IgnitionListRequest{})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	for _, template := range resp.Templates {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", template.Name, template.Size, formatModified(template.Modified))
+	}
+}
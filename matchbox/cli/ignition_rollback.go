@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+)
+
+// ignitionRollbackCmd restores an Ignition template to a kept revision.
+var (
+	ignitionRollbackCmd = &cobra.Command{
+		Use:   "rollback TEMPLATE_NAME --revision N",
+		Short: "Roll back an Ignition template to a revision",
+		Long: `Restore an Ignition template to a revision listed by the history command.
+The restored template is validated and recorded as a new revision. Rolling back
+to a deleted revision deletes the template.`,
+		Run: runIgnitionRollbackCmd,
+	}
+
+	flagRevision int64
+)
+
+func init() {
+	ignitionCmd.AddCommand(ignitionRollbackCmd)
+	ignitionRollbackCmd.Flags().Int64Var(&flagRevision, "revision", 0, "revision number to restore")
+	ignitionRollbackCmd.MarkFlagRequired("revision")
+	ignitionRollbackCmd.Flags().BoolVar(&flagForce, "force", false, "delete even if Profiles reference the template")
+}
+
+func runIgnitionRollbackCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 || flagRevision == 0 {
+		cmd.Help()
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	req := &pb.HistoryRollbackRequest{
+		Kind:     storage.KindIgnition,
+		Name:     args[0],
+		Revision: flagRevision,
+		Force:    flagForce,
+	}
+	_, err := client.History.Rollback(context.TODO(), req)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"io"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// importChunkSize is the size of streamed archive chunks.
+const importChunkSize = 64 * 1024
+
+// importCmd imports an archive of resources.
+var importCmd = &cobra.Command{
+	Use:   "import ARCHIVE",
+	Short: "Import an archive of groups, profiles, and templates",
+	Long: `Import an archive written by bootcmd export. Every resource in the archive
+is written together. Existing resources which aren't in the archive are kept.
+Reads the archive from stdin if ARCHIVE is "-".`,
+	Run: runImportCmd,
+}
+
+func init() {
+	RootCmd.AddCommand(importCmd)
+}
+
+func runImportCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+
+	in := os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			exitWithError(ExitError, err)
+		}
+		defer f.Close()
+		in = f
+	}
+	client := mustClientFromCmd(cmd)
+	stream, err := client.Archive.Import(context.TODO())
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	buf := make([]byte, importChunkSize)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.ArchiveImportRequest{Data: buf[:n]}); err == io.EOF {
+				// the server ended the stream, CloseAndRecv returns why
+				break
+			} else if err != nil {
+				exitWithError(ExitError, err)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			exitWithError(ExitError, err)
+		}
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		exitWithError(ExitError, err)
+	}
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// instanceDescribeCmd describes an observed machine Instance.
+var instanceDescribeCmd = &cobra.Command{
+	Use:   "describe INSTANCE_ID",
+	Short: "Describe an observed machine instance",
+	Long:  `Describe an observed machine instance`,
+	Run:   runInstanceDescribeCmd,
+}
+
+func init() {
+	instanceCmd.AddCommand(instanceDescribeCmd)
+}
+
+func runInstanceDescribeCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "ID\tLABELS\tGROUP\tPROFILE\tENDPOINT\tFIRST SEEN\tLAST SEEN\n")
+
+	client := mustClientFromCmd(cmd)
+	request := &pb.InstanceGetRequest{
+		Id: args[0],
+	}
+	resp, err := client.Instances.InstanceGet(context.TODO(), request)
+	if err != nil {
+		return
+	}
+	i := resp.Instance
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i.Id, i.Labels, i.Group, i.Profile, i.Endpoint, formatUnix(i.FirstSeen), formatUnix(i.LastSeen))
+}
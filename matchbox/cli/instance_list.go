@@ -2,20 +2,38 @@ package cli
 
 import (
 	"fmt"
+	"os"
 
+	"context"
 	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
 )
 
-// instanceListCmd represents the instance list command
+// instanceListCmd lists observed machine Instances.
 var instanceListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List observed machine instances",
 	Long:  `List observed machine instances`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("not implemented")
-	},
+	Run:   runInstanceListCmd,
 }
 
 func init() {
 	instanceCmd.AddCommand(instanceListCmd)
 }
+
+func runInstanceListCmd(cmd *cobra.Command, args []string) {
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "ID\tGROUP\tPROFILE\tENDPOINT\tLAST SEEN\n")
+
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Instances.InstanceList(context.TODO(), &pb.InstanceListRequest{})
+	if err != nil {
+		return
+	}
+	for _, instance := range resp.Instances {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", instance.Id, instance.Group, instance.Profile, instance.Endpoint, formatUnix(instance.LastSeen))
+	}
+}
@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// partialCmd represents the partial command
+var partialCmd = &cobra.Command{
+	Use:   "partial",
+	Short: "Manage template partials",
+	Long:  `Manage template partials which templates may include by name`,
+}
+
+func init() {
+	RootCmd.AddCommand(partialCmd)
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// partialPutCmd creates and updates template partials.
+var (
+	partialPutCmd = &cobra.Command{
+		Use:   "create --file FILENAME",
+		Short: "Create a template partial",
+		Long:  `Create a template partial, named by the file name unless --name is given`,
+		Run:   runPartialPutCmd,
+	}
+
+	flagPartialName string
+)
+
+func init() {
+	partialCmd.AddCommand(partialPutCmd)
+	partialPutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create a template partial")
+	partialPutCmd.Flags().StringVar(&flagPartialName, "name", "", "template partial name (defaults to the file name)")
+	partialPutCmd.MarkFlagRequired("filename")
+	partialPutCmd.Flags().StringVar(&flagResourceVersion, "resource-version", "", "expected resource version of the existing partial")
+	partialPutCmd.Flags().BoolVar(&flagOverwrite, "overwrite", false, "replace an existing partial")
+}
+
+func runPartialPutCmd(cmd *cobra.Command, args []string) {
+	if len(flagFilename) == 0 {
+		cmd.Help()
+		return
+	}
+	if err := validateArgs(cmd, args); err != nil {
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	config, err := ioutil.ReadFile(flagFilename)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	name := flagPartialName
+	if name == "" {
+		name = filepath.Base(flagFilename)
+	}
+	req := &pb.PartialPutRequest{Name: name, Config: config, ResourceVersion: putVersion()}
+	_, err = client.Partials.PartialPut(context.TODO(), req)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+}
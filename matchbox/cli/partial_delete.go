@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// partialDeleteCmd deletes a template partial.
+var partialDeleteCmd = &cobra.Command{
+	Use:   "delete PARTIAL_NAME",
+	Short: "Delete a template partial",
+	Long:  `Delete a template partial`,
+	Run:   runPartialDeleteCmd,
+}
+
+func init() {
+	partialCmd.AddCommand(partialDeleteCmd)
+	partialDeleteCmd.Flags().StringVar(&flagResourceVersion, "resource-version", "", "expected resource version of the existing partial")
+}
+
+func runPartialDeleteCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	_, err := client.Partials.PartialDelete(context.TODO(), &pb.PartialDeleteRequest{Name: args[0], ResourceVersion: flagResourceVersion})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// partialDescribeCmd shows a template partial.
+var partialDescribeCmd = &cobra.Command{
+	Use:   "describe PARTIAL_NAME",
+	Short: "Describe a template partial",
+	Long:  `Describe a template partial`,
+	Run:   runPartialDescribeCmd,
+}
+
+func init() {
+	partialCmd.AddCommand(partialDescribeCmd)
+}
+
+func runPartialDescribeCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Partials.PartialGet(context.TODO(), &pb.PartialGetRequest{Name: args[0]})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	os.Stdout.Write(resp.Config)
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// partialListCmd lists template partials.
+var partialListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List template partials",
+	Long:  `List template partials`,
+	Run:   runPartialListCmd,
+}
+
+func init() {
+	partialCmd.AddCommand(partialListCmd)
+}
+
+func runPartialListCmd(cmd *cobra.Command, args []string) {
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "NAME\n")
+
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Partials.PartialList(context.TODO(), &pb.PartialListRequest{})
+	if err != nil {
+		return
+	}
+	for _, name := range resp.Names {
+		fmt.Fprintf(tw, "%s\n", name)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
 	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
@@ -18,13 +19,17 @@ var (
 		Long:  `Create a machine profile`,
 		Run:   runProfilePutCmd,
 	}
-	flagFilename string
+	flagFilename        string
+	flagResourceVersion string
+	flagOverwrite       bool
 )
 
 func init() {
 	profileCmd.AddCommand(profilePutCmd)
 	profilePutCmd.Flags().StringVarP(&flagFilename, "filename", "f", "", "filename to use to create a Profile")
 	profilePutCmd.MarkFlagRequired("filename")
+	profilePutCmd.Flags().StringVar(&flagResourceVersion, "resource-version", "", "expected resource version of the existing Profile")
+	profilePutCmd.Flags().BoolVar(&flagOverwrite, "overwrite", false, "replace an existing Profile")
 	profilePutCmd.MarkFlagFilename("filename", "json")
 }
 
@@ -42,6 +47,7 @@ func runProfilePutCmd(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exitWithError(ExitError, err)
 	}
+	profile.ResourceVersion = putVersion()
 	req := &pb.ProfilePutRequest{Profile: profile}
 	_, err = client.Profiles.ProfilePut(context.TODO(), req)
 	if err != nil {
@@ -56,6 +62,16 @@ func validateArgs(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// putVersion returns the resource version create commands expect: the
+// --resource-version flag if set, otherwise none so that existing resources
+// aren't overwritten, unless --overwrite is set.
+func putVersion() string {
+	if flagResourceVersion != "" || flagOverwrite {
+		return flagResourceVersion
+	}
+	return storage.NoVersion
+}
+
 func loadProfile(filename string) (*storagepb.Profile, error) {
 	data, err := ioutil.ReadFile(filename)
 	if err != nil {
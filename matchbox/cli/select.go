@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// selectCmd represents the select command
+var selectCmd = &cobra.Command{
+	Use:   "select",
+	Short: "Inspect machine group selection",
+	Long:  `Inspect machine group selection`,
+}
+
+func init() {
+	RootCmd.AddCommand(selectCmd)
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// selectExplainCmd explains Group selection for machine labels.
+var (
+	selectExplainCmd = &cobra.Command{
+		Use:   "explain --label KEY=VALUE",
+		Short: "Explain machine group selection",
+		Long:  `Explain which machine group and profile would be selected for the given labels`,
+		Run:   runSelectExplainCmd,
+	}
+
+	flagLabels map[string]string
+)
+
+func init() {
+	selectCmd.AddCommand(selectExplainCmd)
+	selectExplainCmd.Flags().StringToStringVarP(&flagLabels, "label", "l", nil, "machine label (e.g. mac=52:54:00:a1:9c:ae), may be repeated")
+}
+
+func runSelectExplainCmd(cmd *cobra.Command, args []string) {
+	if err := validateArgs(cmd, args); err != nil {
+		return
+	}
+
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Select.Explain(context.TODO(), &pb.SelectExplainRequest{Labels: flagLabels})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+
+	tw := newTabWriter(os.Stdout)
+	defer tw.Flush()
+	// legend
+	fmt.Fprintf(tw, "RANK\tGROUP\tPRIORITY\tSELECTORS\tEXPRESSIONS\tMATCHED\tFAILED REQUIREMENT\tSELECTED\n")
+	for _, ge := range resp.Groups {
+		g := ge.Group
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%t\t%s\t%t\n", ge.Rank, g.Id, g.Priority, g.Selector, formatExpressions(g.MatchExpressions), ge.Matched, ge.FailedRequirement, ge.Selected)
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintf(tw, "GROUP\tPROFILE\tIGNITION\tGENERIC\tCLOUD\n")
+	if resp.Group == nil {
+		fmt.Fprintf(tw, "-\t-\t-\t-\t-\n")
+		return
+	}
+	if p := resp.Profile; p != nil {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", resp.Group.Id, p.Id, p.IgnitionId, p.GenericId, p.CloudId)
+	} else {
+		fmt.Fprintf(tw, "%s\t%s (missing)\t-\t-\t-\n", resp.Group.Id, resp.Group.Profile)
+	}
+}
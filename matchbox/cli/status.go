@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/spf13/cobra"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// statusCmd reports stored resources which can't be parsed.
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report stored resources which can't be parsed",
+	Long: `Report stored Groups, Profiles, and other resources which matchbox can't
+parse. Exits non-zero if any exist.`,
+	Run: runStatusCmd,
+}
+
+func init() {
+	RootCmd.AddCommand(statusCmd)
+}
+
+func runStatusCmd(cmd *cobra.Command, args []string) {
+	client := mustClientFromCmd(cmd)
+	resp, err := client.Status.StatusGet(context.TODO(), &pb.StatusGetRequest{})
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	if resp.Healthy {
+		return
+	}
+
+	tw := newTabWriter(os.Stdout)
+	// legend
+	fmt.Fprintf(tw, "KIND\tID\tERROR\n")
+	for _, r := range resp.Invalid {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Kind, r.Id, r.Error)
+	}
+	tw.Flush()
+	exitWithError(ExitError, fmt.Errorf("found %d invalid resources", len(resp.Invalid)))
+}
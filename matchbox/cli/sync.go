@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"context"
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+
+	"github.com/poseidon/matchbox/matchbox/client"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// syncCmd syncs a data directory to matchbox.
+var (
+	syncCmd = &cobra.Command{
+		Use:   "sync DATA_PATH",
+		Short: "Sync a directory of groups, profiles, and templates to matchbox",
+		Long: `Compare the groups, profiles, and templates in a matchbox data directory
+with those in matchbox, show a plan of resources to create, update, or delete,
+and apply the plan together. Resources in matchbox which aren't in the data
+directory are only deleted with --prune.`,
+		Run: runSyncCmd,
+	}
+
+	flagPrune bool
+)
+
+func init() {
+	RootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().BoolVar(&flagPrune, "prune", false, "delete resources which aren't in the data directory")
+	syncCmd.Flags().BoolVar(&flagDryRun, "dry-run", false, "only show and validate the plan")
+}
+
+// Sync plan actions
+const (
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+)
+
+// syncChange is a planned change to a resource.
+type syncChange struct {
+	action string
+	kind   string
+	name   string
+}
+
+func runSyncCmd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Help()
+		return
+	}
+
+	local, err := loadBatch(args[0])
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	client := mustClientFromCmd(cmd)
+	remote, err := fetchResources(client)
+	if err != nil {
+		exitWithError(ExitError, err)
+	}
+	batch, changes := planSync(local, remote, flagPrune)
+	if len(changes) == 0 {
+		fmt.Println("No changes")
+		return
+	}
+
+	tw := newTabWriter(os.Stdout)
+	// legend
+	fmt.Fprintf(tw, "ACTION\tKIND\tNAME\n")
+	for _, change := range changes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", change.action, change.kind, change.name)
+	}
+	tw.Flush()
+
+	req := &pb.BatchApplyRequest{
+		Batch:  batch,
+		DryRun: flagDryRun,
+	}
+	if _, err := client.Batch.Apply(context.TODO(), req); err != nil {
+		exitWithError(ExitError, err)
+	}
+}
+
+// planSync returns a Batch which syncs the remote resources to match the
+// local resources, and the changes it makes. Creates expect no resource
+// version and updates and deletes expect the remote resource versions, so the
+// Batch fails if the remote resources change after they're compared.
+func planSync(local, remote *storagepb.Batch, prune bool) (*storagepb.Batch, []*syncChange) {
+	batch := new(storagepb.Batch)
+	var changes []*syncChange
+	change := func(action, kind, name, version string) {
+		changes = append(changes, &syncChange{action: action, kind: kind, name: name})
+		if action == actionDelete {
+			batch.Deletes = append(batch.Deletes, &storagepb.Deletion{Kind: kind, Name: name, ResourceVersion: version})
+		}
+	}
+
+	remoteGroups := make(map[string]*storagepb.Group, len(remote.Groups))
+	for _, group := range remote.Groups {
+		remoteGroups[group.Id] = group
+	}
+	localGroups := make(map[string]bool, len(local.Groups))
+	for _, group := range local.Groups {
+		localGroups[group.Id] = true
+		existing, ok := remoteGroups[group.Id]
+		if !ok {
+			change(actionCreate, storage.KindGroup, group.Id, "")
+			group = group.Copy()
+			group.ResourceVersion = storage.NoVersion
+			batch.Groups = append(batch.Groups, group)
+		} else if !equalGroups(group, existing) {
+			change(actionUpdate, storage.KindGroup, group.Id, "")
+			group = group.Copy()
+			group.ResourceVersion = existing.ResourceVersion
+			batch.Groups = append(batch.Groups, group)
+		}
+	}
+
+	remoteProfiles := make(map[string]*storagepb.Profile, len(remote.Profiles))
+	for _, profile := range remote.Profiles {
+		remoteProfiles[profile.Id] = profile
+	}
+	localProfiles := make(map[string]bool, len(local.Profiles))
+	for _, profile := range local.Profiles {
+		localProfiles[profile.Id] = true
+		existing, ok := remoteProfiles[profile.Id]
+		if !ok {
+			change(actionCreate, storage.KindProfile, profile.Id, "")
+			profile = profile.Copy()
+			profile.ResourceVersion = storage.NoVersion
+			batch.Profiles = append(batch.Profiles, profile)
+		} else if !equalProfiles(profile, existing) {
+			change(actionUpdate, storage.KindProfile, profile.Id, "")
+			profile = profile.Copy()
+			profile.ResourceVersion = existing.ResourceVersion
+			batch.Profiles = append(batch.Profiles, profile)
+		}
+	}
+
+	templates := []struct {
+		kind   string
+		local  []*storagepb.Template
+		remote []*storagepb.Template
+		result *[]*storagepb.Template
+	}{
+		{storage.KindIgnition, local.Ignition, remote.Ignition, &batch.Ignition},
+		{storage.KindGeneric, local.Generic, remote.Generic, &batch.Generic},
+		{storage.KindCloud, local.Cloud, remote.Cloud, &batch.Cloud},
+		{storage.KindPartial, local.Partials, remote.Partials, &batch.Partials},
+	}
+	localTemplates := map[string]map[string]bool{}
+	for _, t := range templates {
+		existing := make(map[string]*storagepb.Template, len(t.remote))
+		for _, template := range t.remote {
+			existing[template.Name] = template
+		}
+		localTemplates[t.kind] = make(map[string]bool, len(t.local))
+		for _, template := range t.local {
+			localTemplates[t.kind][template.Name] = true
+			current, ok := existing[template.Name]
+			if !ok {
+				change(actionCreate, t.kind, template.Name, "")
+				*t.result = append(*t.result, &storagepb.Template{
+					Name:            template.Name,
+					Contents:        template.Contents,
+					ResourceVersion: storage.NoVersion,
+				})
+			} else if !bytes.Equal(template.Contents, current.Contents) {
+				change(actionUpdate, t.kind, template.Name, "")
+				*t.result = append(*t.result, &storagepb.Template{
+					Name:            template.Name,
+					Contents:        template.Contents,
+					ResourceVersion: current.ResourceVersion,
+				})
+			}
+		}
+	}
+
+	if !prune {
+		return batch, changes
+	}
+	for _, group := range remote.Groups {
+		if !localGroups[group.Id] {
+			change(actionDelete, storage.KindGroup, group.Id, group.ResourceVersion)
+		}
+	}
+	for _, profile := range remote.Profiles {
+		if !localProfiles[profile.Id] {
+			change(actionDelete, storage.KindProfile, profile.Id, profile.ResourceVersion)
+		}
+	}
+	for _, t := range templates {
+		for _, template := range t.remote {
+			if !localTemplates[t.kind][template.Name] {
+				change(actionDelete, t.kind, template.Name, template.ResourceVersion)
+			}
+		}
+	}
+	return batch, changes
+}
+
+// equalGroups returns true if the Groups are equal, ignoring resource
+// versions.
+func equalGroups(a, b *storagepb.Group) bool {
+	a, b = a.Copy(), b.Copy()
+	a.ResourceVersion, b.ResourceVersion = "", ""
+	return proto.Equal(a, b)
+}
+
+// equalProfiles returns true if the Profiles are equal, ignoring resource
+// versions.
+func equalProfiles(a, b *storagepb.Profile) bool {
+	a, b = a.Copy(), b.Copy()
+	a.ResourceVersion, b.ResourceVersion = "", ""
+	return proto.Equal(a, b)
+}
+
+// fetchResources returns all Groups, Profiles, and templates in matchbox,
+// with their resource versions.
+func fetchResources(client *client.Client) (*storagepb.Batch, error) {
+	ctx := context.TODO()
+	resources := new(storagepb.Batch)
+	groups, err := client.Groups.GroupList(ctx, &pb.GroupListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	resources.Groups = groups.Groups
+	profiles, err := client.Profiles.ProfileList(ctx, &pb.ProfileListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	resources.Profiles = profiles.Profiles
+
+	ignition, err := client.Ignition.IgnitionList(ctx, &pb.IgnitionListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range ignition.Templates {
+		resp, err := client.Ignition.IgnitionGet(ctx, &pb.IgnitionGetRequest{Name: info.Name})
+		if err != nil {
+			return nil, err
+		}
+		resources.Ignition = append(resources.Ignition, &storagepb.Template{Name: info.Name, Contents: resp.Config, ResourceVersion: resp.ResourceVersion})
+	}
+	generic, err := client.Generic.GenericList(ctx, &pb.GenericListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range generic.Templates {
+		resp, err := client.Generic.GenericGet(ctx, &pb.GenericGetRequest{Name: info.Name})
+		if err != nil {
+			return nil, err
+		}
+		resources.Generic = append(resources.Generic, &storagepb.Template{Name: info.Name, Contents: resp.Config, ResourceVersion: resp.ResourceVersion})
+	}
+	cloud, err := client.Cloud.CloudList(ctx, &pb.CloudListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range cloud.Templates {
+		resp, err := client.Cloud.CloudGet(ctx, &pb.CloudGetRequest{Name: info.Name})
+		if err != nil {
+			return nil, err
+		}
+		resources.Cloud = append(resources.Cloud, &storagepb.Template{Name: info.Name, Contents: resp.Config, ResourceVersion: resp.ResourceVersion})
+	}
+	partials, err := client.Partials.PartialList(ctx, &pb.PartialListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range partials.Names {
+		resp, err := client.Partials.PartialGet(ctx, &pb.PartialGetRequest{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		resources.Partials = append(resources.Partials, &storagepb.Template{Name: name, Contents: resp.Config, ResourceVersion: resp.ResourceVersion})
+	}
+	return resources, nil
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+func TestPlanSync(t *testing.T) {
+	group := &storagepb.Group{Id: "node1", Profile: "etcd", Selector: map[string]string{"mac": "52:54:00:a1:9c:ae"}}
+	changedGroup := &storagepb.Group{Id: "node1", Profile: "etcd-proxy", Selector: map[string]string{"mac": "52:54:00:a1:9c:ae"}}
+	remoteGroup := &storagepb.Group{Id: "node1", Profile: "etcd", Selector: map[string]string{"mac": "52:54:00:a1:9c:ae"}, ResourceVersion: "3a91c4bd02f7e615"}
+	profile := &storagepb.Profile{Id: "etcd", IgnitionId: "etcd.yaml"}
+	changedProfile := &storagepb.Profile{Id: "etcd", IgnitionId: "etcd-v2.yaml"}
+	remoteProfile := &storagepb.Profile{Id: "etcd", IgnitionId: "etcd.yaml", ResourceVersion: "b7e20f19c4a83d56"}
+
+	cases := []struct {
+		name    string
+		local   *storagepb.Batch
+		remote  *storagepb.Batch
+		prune   bool
+		batch   *storagepb.Batch
+		changes []*syncChange
+	}{
+		{
+			name:   "create Group",
+			local:  &storagepb.Batch{Groups: []*storagepb.Group{group}},
+			remote: &storagepb.Batch{},
+			batch: &storagepb.Batch{Groups: []*storagepb.Group{
+				{Id: "node1", Profile: "etcd", Selector: map[string]string{"mac": "52:54:00:a1:9c:ae"}, ResourceVersion: storage.NoVersion},
+			}},
+			changes: []*syncChange{{actionCreate, storage.KindGroup, "node1"}},
+		},
+		{
+			name:   "update Group",
+			local:  &storagepb.Batch{Groups: []*storagepb.Group{changedGroup}},
+			remote: &storagepb.Batch{Groups: []*storagepb.Group{remoteGroup}},
+			batch: &storagepb.Batch{Groups: []*storagepb.Group{
+				{Id: "node1", Profile: "etcd-proxy", Selector: map[string]string{"mac": "52:54:00:a1:9c:ae"}, ResourceVersion: "3a91c4bd02f7e615"},
+			}},
+			changes: []*syncChange{{actionUpdate, storage.KindGroup, "node1"}},
+		},
+		{
+			name:   "unchanged Group",
+			local:  &storagepb.Batch{Groups: []*storagepb.Group{group}},
+			remote: &storagepb.Batch{Groups: []*storagepb.Group{remoteGroup}},
+			batch:  &storagepb.Batch{},
+		},
+		{
+			name:   "remote Group without prune",
+			local:  &storagepb.Batch{},
+			remote: &storagepb.Batch{Groups: []*storagepb.Group{remoteGroup}},
+			batch:  &storagepb.Batch{},
+		},
+		{
+			name:   "remote Group with prune",
+			local:  &storagepb.Batch{},
+			remote: &storagepb.Batch{Groups: []*storagepb.Group{remoteGroup}},
+			prune:  true,
+			batch: &storagepb.Batch{Deletes: []*storagepb.Deletion{
+				{Kind: storage.KindGroup, Name: "node1", ResourceVersion: "3a91c4bd02f7e615"},
+			}},
+			changes: []*syncChange{{actionDelete, storage.KindGroup, "node1"}},
+		},
+		{
+			name:   "create Profile",
+			local:  &storagepb.Batch{Profiles: []*storagepb.Profile{profile}},
+			remote: &storagepb.Batch{},
+			batch: &storagepb.Batch{Profiles: []*storagepb.Profile{
+				{Id: "etcd", IgnitionId: "etcd.yaml", ResourceVersion: storage.NoVersion},
+			}},
+			changes: []*syncChange{{actionCreate, storage.KindProfile, "etcd"}},
+		},
+		{
+			name:   "update Profile",
+			local:  &storagepb.Batch{Profiles: []*storagepb.Profile{changedProfile}},
+			remote: &storagepb.Batch{Profiles: []*storagepb.Profile{remoteProfile}},
+			batch: &storagepb.Batch{Profiles: []*storagepb.Profile{
+				{Id: "etcd", IgnitionId: "etcd-v2.yaml", ResourceVersion: "b7e20f19c4a83d56"},
+			}},
+			changes: []*syncChange{{actionUpdate, storage.KindProfile, "etcd"}},
+		},
+		{
+			name:   "unchanged Profile",
+			local:  &storagepb.Batch{Profiles: []*storagepb.Profile{profile}},
+			remote: &storagepb.Batch{Profiles: []*storagepb.Profile{remoteProfile}},
+			batch:  &storagepb.Batch{},
+		},
+		{
+			name:   "remote Profile without prune",
+			local:  &storagepb.Batch{},
+			remote: &storagepb.Batch{Profiles: []*storagepb.Profile{remoteProfile}},
+			batch:  &storagepb.Batch{},
+		},
+		{
+			name:   "remote Profile with prune",
+			local:  &storagepb.Batch{},
+			remote: &storagepb.Batch{Profiles: []*storagepb.Profile{remoteProfile}},
+			prune:  true,
+			batch: &storagepb.Batch{Deletes: []*storagepb.Deletion{
+				{Kind: storage.KindProfile, Name: "etcd", ResourceVersion: "b7e20f19c4a83d56"},
+			}},
+			changes: []*syncChange{{actionDelete, storage.KindProfile, "etcd"}},
+		},
+	}
+	// assert that:
+	// - local-only resources are created, expecting NoVersion
+	// - changed resources are updated, expecting the remote version
+	// - identical resources are unchanged
+	// - remote-only resources are only deleted with prune, expecting the
+	// remote version
+	// - local resources are not modified
+	for _, c := range cases {
+		batch, changes := planSync(c.local, c.remote, c.prune)
+		assert.Equal(t, c.batch, batch, c.name)
+		assert.Equal(t, c.changes, changes, c.name)
+	}
+	assert.Empty(t, group.ResourceVersion)
+	assert.Empty(t, changedProfile.ResourceVersion)
+}
+
+func TestPlanSync_Templates(t *testing.T) {
+	kinds := []struct {
+		kind      string
+		templates func(batch *storagepb.Batch) *[]*storagepb.Template
+	}{
+		{storage.KindIgnition, func(batch *storagepb.Batch) *[]*storagepb.Template { return &batch.Ignition }},
+		{storage.KindGeneric, func(batch *storagepb.Batch) *[]*storagepb.Template { return &batch.Generic }},
+		{storage.KindCloud, func(batch *storagepb.Batch) *[]*storagepb.Template { return &batch.Cloud }},
+		{storage.KindPartial, func(batch *storagepb.Batch) *[]*storagepb.Template { return &batch.Partials }},
+	}
+	local := &storagepb.Template{Name: "etcd.tmpl", Contents: []byte("a")}
+	changed := &storagepb.Template{Name: "etcd.tmpl", Contents: []byte("b")}
+	remote := &storagepb.Template{Name: "etcd.tmpl", Contents: []byte("a"), ResourceVersion: "ca978112ca1bbdca"}
+
+	cases := []struct {
+		name     string
+		local    []*storagepb.Template
+		remote   []*storagepb.Template
+		prune    bool
+		expected []*storagepb.Template
+		deletes  bool
+		action   string
+	}{
+		{
+			name:     "create",
+			local:    []*storagepb.Template{local},
+			expected: []*storagepb.Template{{Name: "etcd.tmpl", Contents: []byte("a"), ResourceVersion: storage.NoVersion}},
+			action:   actionCreate,
+		},
+		{
+			name:     "update",
+			local:    []*storagepb.Template{changed},
+			remote:   []*storagepb.Template{remote},
+			expected: []*storagepb.Template{{Name: "etcd.tmpl", Contents: []byte("b"), ResourceVersion: "ca978112ca1bbdca"}},
+			action:   actionUpdate,
+		},
+		{
+			name:   "unchanged",
+			local:  []*storagepb.Template{local},
+			remote: []*storagepb.Template{remote},
+		},
+		{
+			name:   "remote without prune",
+			remote: []*storagepb.Template{remote},
+		},
+		{
+			name:    "remote with prune",
+			remote:  []*storagepb.Template{remote},
+			prune:   true,
+			deletes: true,
+			action:  actionDelete,
+		},
+	}
+	// assert that, for each kind of template:
+	// - local-only templates are created, expecting NoVersion
+	// - changed templates are updated, expecting the remote version
+	// - identical templates are unchanged
+	// - remote-only templates are only deleted with prune, expecting the
+	// remote version
+	for _, k := range kinds {
+		for _, c := range cases {
+			localBatch, remoteBatch := new(storagepb.Batch), new(storagepb.Batch)
+			*k.templates(localBatch) = c.local
+			*k.templates(remoteBatch) = c.remote
+			expected := new(storagepb.Batch)
+			*k.templates(expected) = c.expected
+			var changes []*syncChange
+			if c.action != "" {
+				changes = []*syncChange{{c.action, k.kind, "etcd.tmpl"}}
+			}
+			if c.deletes {
+				expected.Deletes = []*storagepb.Deletion{{Kind: k.kind, Name: "etcd.tmpl", ResourceVersion: "ca978112ca1bbdca"}}
+			}
+
+			batch, planned := planSync(localBatch, remoteBatch, c.prune)
+			assert.Equal(t, expected, batch, k.kind+" "+c.name)
+			assert.Equal(t, changes, planned, k.kind+" "+c.name)
+		}
+	}
+}
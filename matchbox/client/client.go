@@ -30,12 +30,20 @@ type Config struct {
 
 // Client provides a matchbox client RPC session.
 type Client struct {
-	Groups   rpcpb.GroupsClient
-	Profiles rpcpb.ProfilesClient
-	Ignition rpcpb.IgnitionClient
-	Generic  rpcpb.GenericClient
-	Select   rpcpb.SelectClient
-	conn     *grpc.ClientConn
+	Groups    rpcpb.GroupsClient
+	Profiles  rpcpb.ProfilesClient
+	Ignition  rpcpb.IgnitionClient
+	Generic   rpcpb.GenericClient
+	Cloud     rpcpb.CloudClient
+	Partials  rpcpb.PartialsClient
+	Select    rpcpb.SelectClient
+	Instances rpcpb.InstancesClient
+	Status    rpcpb.StatusClient
+	Batch     rpcpb.BatchClient
+	Archive   rpcpb.ArchiveClient
+	History   rpcpb.HistoryClient
+	Audit     rpcpb.AuditClient
+	conn      *grpc.ClientConn
 }
 
 // New creates a new Client from the given Config.
@@ -62,12 +70,20 @@ func newClient(config *Config) (*Client, error) {
 		return nil, err
 	}
 	client := &Client{
-		conn:     conn,
-		Groups:   rpcpb.NewGroupsClient(conn),
-		Profiles: rpcpb.NewProfilesClient(conn),
-		Ignition: rpcpb.NewIgnitionClient(conn),
-		Generic:  rpcpb.NewGenericClient(conn),
-		Select:   rpcpb.NewSelectClient(conn),
+		conn:      conn,
+		Groups:    rpcpb.NewGroupsClient(conn),
+		Profiles:  rpcpb.NewProfilesClient(conn),
+		Ignition:  rpcpb.NewIgnitionClient(conn),
+		Generic:   rpcpb.NewGenericClient(conn),
+		Cloud:     rpcpb.NewCloudClient(conn),
+		Partials:  rpcpb.NewPartialsClient(conn),
+		Select:    rpcpb.NewSelectClient(conn),
+		Instances: rpcpb.NewInstancesClient(conn),
+		Status:    rpcpb.NewStatusClient(conn),
+		Batch:     rpcpb.NewBatchClient(conn),
+		Archive:   rpcpb.NewArchiveClient(conn),
+		History:   rpcpb.NewHistoryClient(conn),
+		Audit:     rpcpb.NewAuditClient(conn),
 	}
 	return client, nil
 }
@@ -2,6 +2,8 @@ package http
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -30,7 +32,7 @@ func (s *Server) cloudHandler(core server.Server) http.Handler {
 			s.logger.WithFields(logrus.Fields{
 				"labels": labelsFromRequest(nil, req),
 			}).Infof("No matching group")
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusNotFound, &errorResponse{Error: errNoMatchingGroup.Error()})
 			return
 		}
 
@@ -41,11 +43,16 @@ func (s *Server) cloudHandler(core server.Server) http.Handler {
 				"group":      group.Id,
 				"group_name": group.Name,
 			}).Infof("No profile named: %s", group.Profile)
-			http.NotFound(w, req)
+			recordMatch(ctx, outcomeNoProfile, group.Id, group.Profile)
+			s.renderError(w, http.StatusNotFound, &errorResponse{
+				Error:   fmt.Sprintf("no Profile named %s", group.Profile),
+				Group:   group.Id,
+				Profile: group.Profile,
+			})
 			return
 		}
 
-		contents, err := core.CloudGet(ctx, profile.CloudId)
+		contents, err := core.CloudGet(ctx, &pb.CloudGetRequest{Name: profile.CloudId})
 		if err != nil {
 			s.logger.WithFields(logrus.Fields{
 				"labels":     labelsFromRequest(nil, req),
@@ -53,7 +60,8 @@ func (s *Server) cloudHandler(core server.Server) http.Handler {
 				"group_name": group.Name,
 				"profile":    group.Profile,
 			}).Infof("No cloud-config template named: %s", profile.CloudId)
-			http.NotFound(w, req)
+			err = fmt.Errorf("no cloud-config template named %s", profile.CloudId)
+			s.renderError(w, http.StatusNotFound, templateError(err, group, profile, profile.CloudId))
 			return
 		}
 
@@ -71,29 +79,37 @@ func (s *Server) cloudHandler(core server.Server) http.Handler {
 		data, err := collectVariables(req, group)
 		if err != nil {
 			s.logger.Errorf("error collecting variables: %v", err)
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusInternalServerError, templateError(err, group, profile, profile.CloudId))
+			return
+		}
+
+		partials, err := core.Partials(ctx)
+		if err != nil {
+			s.logger.Errorf("error getting template partials: %v", err)
+			s.renderError(w, http.StatusInternalServerError, templateError(err, group, profile, profile.CloudId))
 			return
 		}
 
 		// render the template of a cloud config with data
 		var buf bytes.Buffer
-		err = s.renderTemplate(&buf, data, contents)
+		err = s.renderTemplate(&buf, data, partials, contents)
 		if err != nil {
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusUnprocessableEntity, templateError(err, group, profile, profile.CloudId))
 			return
 		}
 
 		config := buf.String()
 		if !cloudinit.IsCloudConfig(config) && !cloudinit.IsScript(config) {
 			s.logger.Error("error parsing user-data")
-			http.NotFound(w, req)
+			err = errors.New("error parsing user-data: not a cloud-config or script")
+			s.renderError(w, http.StatusUnprocessableEntity, templateError(err, group, profile, profile.CloudId))
 			return
 		}
 
 		if cloudinit.IsCloudConfig(config) {
 			if _, err = cloudinit.NewCloudConfig(config); err != nil {
 				s.logger.Errorf("error parsing cloud config: %v", err)
-				http.NotFound(w, req)
+				s.renderError(w, http.StatusUnprocessableEntity, templateError(err, group, profile, profile.CloudId))
 				return
 			}
 		}
@@ -93,5 +93,5 @@ coreos:
 	// assert that:
 	// - Cloud-config template rendering errors because "missing_key" is not
 	// present in the template variables
-	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 }
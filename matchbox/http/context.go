@@ -14,6 +14,7 @@ type key int
 const (
 	profileKey key = iota
 	groupKey
+	matchKey
 )
 
 var (
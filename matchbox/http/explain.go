@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// explanation explains how Groups are evaluated against a machine's labels.
+type explanation struct {
+	Labels map[string]string `json:"labels"`
+	// selected Group id
+	Group string `json:"group,omitempty"`
+	// Profile and template names which would be served
+	Profile  string              `json:"profile,omitempty"`
+	Ignition string              `json:"ignition,omitempty"`
+	Generic  string              `json:"generic,omitempty"`
+	Cloud    string              `json:"cloud,omitempty"`
+	Groups   []*groupExplanation `json:"groups"`
+}
+
+// groupExplanation explains whether a Group matches a machine's labels. Group
+// metadata is omitted since the endpoint is unauthenticated.
+type groupExplanation struct {
+	Rank              int32                            `json:"rank"`
+	Id                string                           `json:"id"`
+	Name              string                           `json:"name,omitempty"`
+	Profile           string                           `json:"profile"`
+	Priority          int32                            `json:"priority,omitempty"`
+	Selector          map[string]string                `json:"selector,omitempty"`
+	MatchExpressions  []*storagepb.SelectorRequirement `json:"match_expressions,omitempty"`
+	Matched           bool                             `json:"matched"`
+	Selected          bool                             `json:"selected"`
+	FailedRequirement string                           `json:"failed_requirement,omitempty"`
+}
+
+// explainHandler returns a handler that responds with an explanation of how
+// Groups are evaluated against the request's query parameters, without
+// recording the request as a machine instance.
+func (s *Server) explainHandler(core server.Server) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		labels := labelsFromRequest(s.logger, req)
+		resp, err := core.Explain(req.Context(), &pb.SelectExplainRequest{Labels: labels})
+		if err != nil {
+			if s.unavailable(w, err) {
+				return
+			}
+			s.logger.Errorf("error explaining selection: %v", err)
+			s.renderError(w, http.StatusInternalServerError, &errorResponse{Error: err.Error()})
+			return
+		}
+
+		exp := &explanation{
+			Labels: labels,
+			Groups: make([]*groupExplanation, 0, len(resp.Groups)),
+		}
+		if resp.Group != nil {
+			exp.Group = resp.Group.Id
+		}
+		if resp.Profile != nil {
+			exp.Profile = resp.Profile.Id
+			exp.Ignition = resp.Profile.IgnitionId
+			exp.Generic = resp.Profile.GenericId
+			exp.Cloud = resp.Profile.CloudId
+		}
+		for _, ge := range resp.Groups {
+			exp.Groups = append(exp.Groups, &groupExplanation{
+				Rank:              ge.Rank,
+				Id:                ge.Group.Id,
+				Name:              ge.Group.Name,
+				Profile:           ge.Group.Profile,
+				Priority:          ge.Group.Priority,
+				Selector:          ge.Group.Selector,
+				MatchExpressions:  ge.Group.MatchExpressions,
+				Matched:           ge.Matched,
+				Selected:          ge.Selected,
+				FailedRequirement: ge.FailedRequirement,
+			})
+		}
+		s.renderJSON(w, exp)
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/server"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+func TestExplainHandler(t *testing.T) {
+	byMAC := &storagepb.Group{
+		Id:       "mac-group",
+		Name:     "mac group",
+		Profile:  fake.Profile.Id,
+		Selector: map[string]string{"mac": validMACStr},
+	}
+	store := &fake.FixedStore{
+		Groups: map[string]*storagepb.Group{
+			fake.Group.Id: fake.Group,
+			byMAC.Id:      byMAC,
+		},
+		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+	}
+	logger, _ := logtest.NewNullLogger()
+	core := server.NewServer(&server.Config{Store: store})
+	srv := NewServer(&Config{Core: core, Logger: logger})
+	h := srv.explainHandler(core)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/explain?mac=52-da-00-89-d8-10", nil)
+	h.ServeHTTP(w, req)
+	// assert that:
+	// - every Group is explained in order of evaluation
+	// - the selected Group, Profile, and templates are reported
+	// - Group metadata is not exposed
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, jsonContentType, w.HeaderMap.Get(contentType))
+	exp := new(explanation)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), exp))
+	assert.Equal(t, map[string]string{"mac": validMACStr}, exp.Labels)
+	assert.Equal(t, byMAC.Id, exp.Group)
+	assert.Equal(t, fake.Profile.Id, exp.Profile)
+	assert.Equal(t, fake.Profile.IgnitionId, exp.Ignition)
+	assert.Equal(t, fake.Profile.GenericId, exp.Generic)
+	assert.Equal(t, fake.Profile.CloudId, exp.Cloud)
+	if assert.Equal(t, 2, len(exp.Groups)) {
+		assert.Equal(t, &groupExplanation{
+			Rank:              0,
+			Id:                fake.Group.Id,
+			Name:              fake.Group.Name,
+			Profile:           fake.Group.Profile,
+			Selector:          fake.Group.Selector,
+			FailedRequirement: "uuid=a1b2c3d4",
+		}, exp.Groups[0])
+		assert.Equal(t, &groupExplanation{
+			Rank:     1,
+			Id:       byMAC.Id,
+			Name:     byMAC.Name,
+			Profile:  byMAC.Profile,
+			Selector: byMAC.Selector,
+			Matched:  true,
+			Selected: true,
+		}, exp.Groups[1])
+	}
+	assert.NotContains(t, w.Body.String(), "pod_network")
+}
+
+func TestExplainHandler_NoMatchingGroup(t *testing.T) {
+	logger, _ := logtest.NewNullLogger()
+	core := server.NewServer(&server.Config{Store: &fake.EmptyStore{}})
+	srv := NewServer(&Config{Core: core, Logger: logger})
+	h := srv.explainHandler(core)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/explain?uuid=a1b2c3d4", nil)
+	h.ServeHTTP(w, req)
+	// assert that an explanation is served even if no Group matches
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"labels":{"uuid":"a1b2c3d4"},"groups":[]}`, w.Body.String())
+}
+
+func TestExplainHandler_BrokenStore(t *testing.T) {
+	logger, _ := logtest.NewNullLogger()
+	core := server.NewServer(&server.Config{Store: &fake.BrokenStore{}})
+	srv := NewServer(&Config{Core: core, Logger: logger})
+	h := srv.explainHandler(core)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/explain", nil)
+	h.ServeHTTP(w, req)
+	// assert that:
+	// - store errors are rendered as JSON error responses
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, jsonContentType, w.HeaderMap.Get(contentType))
+	assert.JSONEq(t, `{"error":"store: error for testing purposes"}`, w.Body.String())
+}
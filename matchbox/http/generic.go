@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -22,7 +23,7 @@ func (s *Server) genericHandler(core server.Server) http.Handler {
 			s.logger.WithFields(logrus.Fields{
 				"labels": labelsFromRequest(nil, req),
 			}).Infof("No matching group")
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusNotFound, &errorResponse{Error: errNoMatchingGroup.Error()})
 			return
 		}
 		profile, err := core.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile})
@@ -32,7 +33,12 @@ func (s *Server) genericHandler(core server.Server) http.Handler {
 				"group":      group.Id,
 				"group_name": group.Name,
 			}).Infof("No profile named: %s", group.Profile)
-			http.NotFound(w, req)
+			recordMatch(ctx, outcomeNoProfile, group.Id, group.Profile)
+			s.renderError(w, http.StatusNotFound, &errorResponse{
+				Error:   fmt.Sprintf("no Profile named %s", group.Profile),
+				Group:   group.Id,
+				Profile: group.Profile,
+			})
 			return
 		}
 		contents, err := core.GenericGet(ctx, &pb.GenericGetRequest{Name: profile.GenericId})
@@ -43,7 +49,8 @@ func (s *Server) genericHandler(core server.Server) http.Handler {
 				"group_name": group.Name,
 				"profile":    group.Profile,
 			}).Infof("No generic template named: %s", profile.GenericId)
-			http.NotFound(w, req)
+			err = fmt.Errorf("no generic template named %s", profile.GenericId)
+			s.renderError(w, http.StatusNotFound, templateError(err, group, profile, profile.GenericId))
 			return
 		}
 
@@ -58,15 +65,22 @@ func (s *Server) genericHandler(core server.Server) http.Handler {
 		data, err := collectVariables(req, group)
 		if err != nil {
 			s.logger.Errorf("error collecting variables: %v", err)
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusInternalServerError, templateError(err, group, profile, profile.GenericId))
+			return
+		}
+
+		partials, err := core.Partials(ctx)
+		if err != nil {
+			s.logger.Errorf("error getting template partials: %v", err)
+			s.renderError(w, http.StatusInternalServerError, templateError(err, group, profile, profile.GenericId))
 			return
 		}
 
 		// render the template of a generic config with data
 		var buf bytes.Buffer
-		err = s.renderTemplate(&buf, data, contents)
+		err = s.renderTemplate(&buf, data, partials, contents)
 		if err != nil {
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusUnprocessableEntity, templateError(err, group, profile, profile.GenericId))
 			return
 		}
 
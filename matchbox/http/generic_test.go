@@ -43,6 +43,76 @@ FOO=some-param
 	assert.Equal(t, expected, w.Body.String())
 }
 
+func TestGenericHandler_Funcs(t *testing.T) {
+	content := `NETWORK={{ .pod_network }}
+GATEWAY={{ cidrhost .pod_network 1 }}
+REGION={{ index . "region" | default "us-west" }}
+SERVICE={{ .service_name | b64enc }}
+`
+	expected := `NETWORK=10.2.0.0/16
+GATEWAY=10.2.0.1
+REGION=us-west
+SERVICE=ZXRjZDI=
+`
+	store := &fake.FixedStore{
+		Profiles:       map[string]*storagepb.Profile{fake.Group.Profile: fake.Profile},
+		GenericConfigs: map[string]string{fake.Profile.GenericId: content},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.genericHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that template functions are available to Generic templates
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, expected, w.Body.String())
+}
+
+func TestGenericHandler_Partials(t *testing.T) {
+	content := `SERVICE={{.service_name}}
+{{template "network" .}}
+`
+	expected := `SERVICE=etcd2
+NETWORK=10.2.0.0/16
+`
+	store := &fake.FixedStore{
+		Profiles:       map[string]*storagepb.Profile{fake.Group.Profile: fake.Profile},
+		GenericConfigs: map[string]string{fake.Profile.GenericId: content},
+		Partials:       map[string]string{"network": `NETWORK={{.pod_network}}`},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.genericHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that template partials can be included by Generic templates
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, expected, w.Body.String())
+}
+
+func TestGenericHandler_InvalidPartial(t *testing.T) {
+	store := &fake.FixedStore{
+		Profiles:       map[string]*storagepb.Profile{fake.Group.Profile: fake.Profile},
+		GenericConfigs: map[string]string{fake.Profile.GenericId: `{{template "network" .}}`},
+		Partials:       map[string]string{"network": `{{.pod_network`},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.genericHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
 func TestGenericHandler_MissingCtxProfile(t *testing.T) {
 	logger, _ := logtest.NewNullLogger()
 	srv := NewServer(&Config{Logger: logger})
@@ -6,6 +6,8 @@ import (
 	"text/template"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
 var grubTemplate = template.Must(template.New("GRUB2 config").Parse(`default=0
@@ -45,8 +47,25 @@ func (s *Server) grubHandler() http.Handler {
 			"profile": profile.Id,
 		}).Debug("Matched a GRUB config")
 
+		// render the NetBoot with Group variables, if any
+		group, err := groupFromContext(ctx)
+		if err != nil {
+			group = &storagepb.Group{}
+		}
+		data, err := collectVariables(req, group)
+		if err != nil {
+			s.logger.Errorf("error collecting variables: %v", err)
+			http.NotFound(w, req)
+			return
+		}
+		boot, err := s.renderNetBoot(profile.Boot, data)
+		if err != nil {
+			http.NotFound(w, req)
+			return
+		}
+
 		var buf bytes.Buffer
-		err = grubTemplate.Execute(&buf, profile.Boot)
+		err = grubTemplate.Execute(&buf, boot)
 		if err != nil {
 			s.logger.Errorf("error rendering template: %v", err)
 			http.NotFound(w, req)
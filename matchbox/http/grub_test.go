@@ -9,6 +9,7 @@ import (
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
 )
 
@@ -41,3 +42,28 @@ initrd  "/image/initrd_a" "/image/initrd_b"
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Equal(t, expectedScript, w.Body.String())
 }
+
+func TestGrubHandler_Variables(t *testing.T) {
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	h := srv.grubHandler()
+	profile := &storagepb.Profile{
+		Boot: &storagepb.NetBoot{
+			Kernel: "/assets/{{.os_version}}/kernel",
+			Initrd: []string{"/assets/{{.os_version}}/initrd"},
+			Args:   []string{"hostname={{.hostname}}"},
+		},
+	}
+	group := &storagepb.Group{
+		Selector: map[string]string{"hostname": "node1"},
+		Metadata: []byte(`{"os_version":"2605.6.0"}`),
+	}
+	ctx := withProfile(withGroup(context.Background(), group), profile)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that the NetBoot is rendered with Group selectors and metadata
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `linux "/assets/2605.6.0/kernel" hostname=node1`)
+	assert.Contains(t, w.Body.String(), `initrd  "/assets/2605.6.0/initrd"`)
+}
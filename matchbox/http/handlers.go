@@ -1,11 +1,15 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/poseidon/matchbox/matchbox/server"
 	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
 // homeHandler shows the server name for rooted requests. Otherwise, a 404 is
@@ -39,29 +43,88 @@ func (s *Server) selectGroup(core server.Server, next http.Handler) http.Handler
 		attrs := labelsFromRequest(s.logger, req)
 		// match machine request
 		group, err := core.SelectGroup(ctx, &pb.SelectGroupRequest{Labels: attrs})
+		if s.unavailable(w, err) {
+			return
+		}
 		if err == nil {
 			// add the Group to the ctx for next handler
 			ctx = withGroup(ctx, group)
+			recordMatch(ctx, outcomeMatched, group.Id, group.Profile)
+		} else {
+			recordMatch(ctx, outcomeNoGroup, "", "")
 		}
+		s.recordInstance(ctx, core, req, attrs, group)
 		next.ServeHTTP(w, req.WithContext(ctx))
 	}
 	return http.HandlerFunc(fn)
 }
 
 // selectProfile selects the Profile for the given query parameters, adds the
-// Profile to the ctx, and calls the next handler. The next handler should
-// handle a missing profile.
+// Group and Profile to the ctx, and calls the next handler. The next handler
+// should handle a missing profile.
 func (s *Server) selectProfile(core server.Server, next http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, req *http.Request) {
 		ctx := req.Context()
 		attrs := labelsFromRequest(s.logger, req)
 		// match machine request
-		profile, err := core.SelectProfile(ctx, &pb.SelectProfileRequest{Labels: attrs})
+		var profile *storagepb.Profile
+		group, err := core.SelectGroup(ctx, &pb.SelectGroupRequest{Labels: attrs})
+		if s.unavailable(w, err) {
+			return
+		}
 		if err == nil {
-			// add the Profile to the ctx for the next handler
-			ctx = withProfile(ctx, profile)
+			// add the Group to the ctx for rendering by the next handler
+			ctx = withGroup(ctx, group)
+			// lookup the Profile by id
+			profile, err = core.ProfileGet(ctx, &pb.ProfileGetRequest{Id: group.Profile})
+			if err == nil {
+				// add the Profile to the ctx for the next handler
+				ctx = withProfile(ctx, profile)
+				recordMatch(ctx, outcomeMatched, group.Id, profile.Id)
+			} else {
+				recordMatch(ctx, outcomeNoProfile, group.Id, group.Profile)
+			}
+		} else {
+			recordMatch(ctx, outcomeNoGroup, "", "")
 		}
+		s.recordInstance(ctx, core, req, attrs, group)
 		next.ServeHTTP(w, req.WithContext(ctx))
 	}
 	return http.HandlerFunc(fn)
 }
+
+// unavailable writes a 503 Service Unavailable error response and returns
+// true if Group selection failed because stored Groups can't be parsed. A
+// machine should retry rather than fall through to a catch-all Profile.
+func (s *Server) unavailable(w http.ResponseWriter, err error) bool {
+	if _, ok := err.(*server.InvalidResourcesError); !ok {
+		return false
+	}
+	s.logger.Errorf("error selecting Group: %v", err)
+	s.renderError(w, http.StatusServiceUnavailable, &errorResponse{Error: err.Error()})
+	return true
+}
+
+// recordInstance records that the machine with the given labels requested an
+// endpoint, along with the matched Group and its Profile (if any), whether or
+// not the endpoint reads the Profile. Only identifying labels and labels the
+// Group selects on are recorded. Machines which can't be identified by their
+// labels are not recorded.
+func (s *Server) recordInstance(ctx context.Context, core server.Server, req *http.Request, labels map[string]string, group *storagepb.Group) {
+	if storagepb.InstanceId(labels) == "" {
+		return
+	}
+	record := &pb.InstanceRecordRequest{
+		Labels:   storagepb.InstanceLabels(labels, group),
+		Endpoint: req.URL.Path,
+	}
+	if group != nil {
+		record.Group = group.Id
+		record.Profile = group.Profile
+	}
+	if _, err := core.InstanceRecord(ctx, record); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"labels": labels,
+		}).Warningf("error recording instance: %v", err)
+	}
+}
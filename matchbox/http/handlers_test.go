@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
 	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
 )
@@ -52,11 +54,14 @@ func TestSelectProfile(t *testing.T) {
 		profile, err := profileFromContext(ctx)
 		assert.Nil(t, err)
 		assert.Equal(t, fake.Profile, profile)
+		group, err := groupFromContext(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, fake.Group, group)
 		fmt.Fprintf(w, "next handler called")
 	}
 	// assert that:
 	// - query params are used to match uuid=a1b2c3d4 to fake.Group's fakeProfile
-	// - the fake.Profile is added to the context
+	// - the fake.Group and fake.Profile are added to the context
 	// - next handler is called
 	h := srv.selectProfile(c, http.HandlerFunc(next))
 	w := httptest.NewRecorder()
@@ -64,3 +69,93 @@ func TestSelectProfile(t *testing.T) {
 	h.ServeHTTP(w, req)
 	assert.Equal(t, "next handler called", w.Body.String())
 }
+
+func TestSelectProfile_RecordsInstance(t *testing.T) {
+	store := &fake.FixedStore{
+		Groups:   map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+		Profiles: map[string]*storagepb.Profile{fake.Group.Profile: fake.Profile},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	next := func(w http.ResponseWriter, req *http.Request) {}
+	// assert that:
+	// - matched machines are recorded with their Group, Profile, and endpoint
+	// - unmatched machines are recorded without a Group or Profile
+	// - labels other than identifying and selected labels are not recorded
+	h := srv.selectProfile(c, http.HandlerFunc(next))
+	req, _ := http.NewRequest("GET", "/ipxe?uuid=a1b2c3d4&token=secret", nil)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	req, _ = http.NewRequest("GET", "/ipxe?uuid=e5f6g7h8", nil)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	instance, err := c.InstanceGet(context.Background(), &pb.InstanceGetRequest{Id: "a1b2c3d4"})
+	if assert.Nil(t, err) {
+		assert.Equal(t, fake.Group.Id, instance.Group)
+		assert.Equal(t, fake.Profile.Id, instance.Profile)
+		assert.Equal(t, "/ipxe", instance.Endpoint)
+		assert.Equal(t, map[string]string{"uuid": "a1b2c3d4"}, instance.Labels)
+	}
+	instance, err = c.InstanceGet(context.Background(), &pb.InstanceGetRequest{Id: "e5f6g7h8"})
+	if assert.Nil(t, err) {
+		assert.Equal(t, "", instance.Group)
+		assert.Equal(t, "", instance.Profile)
+	}
+}
+
+func TestSelectGroup_RecordsProfile(t *testing.T) {
+	store := &fake.FixedStore{
+		Groups:   map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+		Profiles: map[string]*storagepb.Profile{fake.Group.Profile: fake.Profile},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	next := func(w http.ResponseWriter, req *http.Request) {}
+	// assert that:
+	// - requesting /ignition after /ipxe keeps the recorded Profile
+	req, _ := http.NewRequest("GET", "/ipxe?uuid=a1b2c3d4", nil)
+	srv.selectProfile(c, http.HandlerFunc(next)).ServeHTTP(httptest.NewRecorder(), req)
+	req, _ = http.NewRequest("GET", "/ignition?uuid=a1b2c3d4", nil)
+	srv.selectGroup(c, http.HandlerFunc(next)).ServeHTTP(httptest.NewRecorder(), req)
+
+	instance, err := c.InstanceGet(context.Background(), &pb.InstanceGetRequest{Id: "a1b2c3d4"})
+	if assert.Nil(t, err) {
+		assert.Equal(t, fake.Group.Id, instance.Group)
+		assert.Equal(t, fake.Profile.Id, instance.Profile)
+		assert.Equal(t, "/ignition", instance.Endpoint)
+	}
+}
+
+func TestSelect_InvalidGroups(t *testing.T) {
+	store := &fake.FixedStore{
+		Groups:   map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+		Profiles: map[string]*storagepb.Profile{fake.Group.Profile: fake.Profile},
+		InvalidResources: []*storagepb.InvalidResource{
+			{Kind: "Group", Id: "truncated", Error: "unexpected end of JSON input"},
+		},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	next := func(w http.ResponseWriter, req *http.Request) {
+		t.Error("next handler should not be called")
+	}
+	// assert that:
+	// - Group selection fails while a stored Group can't be parsed
+	// - a 503 error response names the invalid Group
+	// - next handler is not called
+	cases := []http.Handler{
+		srv.selectGroup(c, http.HandlerFunc(next)),
+		srv.selectProfile(c, http.HandlerFunc(next)),
+		srv.explainHandler(c),
+	}
+	for _, h := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "?uuid=a1b2c3d4", nil)
+		h.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, jsonContentType, w.HeaderMap.Get(contentType))
+		assert.Contains(t, w.Body.String(), "Group truncated (unexpected end of JSON input)")
+	}
+}
@@ -2,20 +2,20 @@ package http
 
 import (
 	"bytes"
+	"fmt"
 	"net/http"
-	"strings"
 
-	ct "github.com/coreos/container-linux-config-transpiler/config"
-	ignition "github.com/coreos/ignition/config/v2_2"
 	"github.com/sirupsen/logrus"
 
+	"github.com/poseidon/matchbox/matchbox/ignition"
 	"github.com/poseidon/matchbox/matchbox/server"
 	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
 )
 
 // ignitionHandler returns a handler that responds with the Ignition config
 // matching the request. The Ignition file referenced in the Profile is parsed
-// as raw Ignition (for .ign/.ignition) or rendered from a Container Linux
+// as raw Ignition (for .ign/.ignition), rendered from a Butane config (for
+// .bu/.butane) and translated to Ignition, or rendered from a Container Linux
 // Config (YAML) and converted to Ignition. Ignition configs are served as HTTP
 // JSON responses.
 func (s *Server) ignitionHandler(core server.Server) http.Handler {
@@ -26,7 +26,7 @@ func (s *Server) ignitionHandler(core server.Server) http.Handler {
 			s.logger.WithFields(logrus.Fields{
 				"labels": labelsFromRequest(nil, req),
 			}).Infof("No matching group")
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusNotFound, &errorResponse{Error: errNoMatchingGroup.Error()})
 			return
 		}
 
@@ -37,7 +37,12 @@ func (s *Server) ignitionHandler(core server.Server) http.Handler {
 				"group":      group.Id,
 				"group_name": group.Name,
 			}).Infof("No profile named: %s", group.Profile)
-			http.NotFound(w, req)
+			recordMatch(ctx, outcomeNoProfile, group.Id, group.Profile)
+			s.renderError(w, http.StatusNotFound, &errorResponse{
+				Error:   fmt.Sprintf("no Profile named %s", group.Profile),
+				Group:   group.Id,
+				Profile: group.Profile,
+			})
 			return
 		}
 
@@ -48,8 +53,9 @@ func (s *Server) ignitionHandler(core server.Server) http.Handler {
 				"group":      group.Id,
 				"group_name": group.Name,
 				"profile":    group.Profile,
-			}).Infof("No Ignition or Container Linux Config template named: %s", profile.IgnitionId)
-			http.NotFound(w, req)
+			}).Infof("No Ignition, Butane, or Container Linux Config template named: %s", profile.IgnitionId)
+			err = fmt.Errorf("no Ignition, Butane, or Container Linux Config template named %s", profile.IgnitionId)
+			s.renderError(w, http.StatusNotFound, templateError(err, group, profile, profile.IgnitionId))
 			return
 		}
 
@@ -58,59 +64,63 @@ func (s *Server) ignitionHandler(core server.Server) http.Handler {
 			"labels":  labelsFromRequest(nil, req),
 			"group":   group.Id,
 			"profile": profile.Id,
-		}).Debug("Matched an Ignition or Container Linux Config template")
+		}).Debug("Matched an Ignition, Butane, or Container Linux Config template")
 
 		// Skip rendering if raw Ignition JSON is provided
-		if isIgnition(profile.IgnitionId) {
-			_, report, err := ignition.Parse([]byte(contents))
-			if err != nil {
-				s.logger.Warningf("warning parsing Ignition JSON: %s", report.String())
+		if ignition.IsIgnition(profile.IgnitionId) {
+			if err := ignition.Validate([]byte(contents)); err != nil {
+				s.logger.Warningf("warning parsing Ignition JSON: %v", err)
 			}
 			s.writeJSON(w, []byte(contents))
 			return
 		}
 
-		// Container Linux Config template
+		// Butane or Container Linux Config template
 
 		// collect data for rendering
 		data, err := collectVariables(req, group)
 		if err != nil {
 			s.logger.Errorf("error collecting variables: %v", err)
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusInternalServerError, templateError(err, group, profile, profile.IgnitionId))
+			return
+		}
+
+		partials, err := core.Partials(ctx)
+		if err != nil {
+			s.logger.Errorf("error getting template partials: %v", err)
+			s.renderError(w, http.StatusInternalServerError, templateError(err, group, profile, profile.IgnitionId))
 			return
 		}
 
 		// render the template for an Ignition config with data
 		var buf bytes.Buffer
-		err = s.renderTemplate(&buf, data, contents)
+		err = s.renderTemplate(&buf, data, partials, contents)
 		if err != nil {
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusUnprocessableEntity, templateError(err, group, profile, profile.IgnitionId))
 			return
 		}
 
-		// Parse bytes into a Container Linux Config
-		config, ast, report := ct.Parse(buf.Bytes())
-		if report.IsFatal() {
-			s.logger.Errorf("error parsing Container Linux config: %s", report.String())
-			http.NotFound(w, req)
+		// Translate Butane Config into a validated Ignition Config
+		if ignition.IsButane(profile.IgnitionId) {
+			ign, err := ignition.Translate(buf.Bytes())
+			if err != nil {
+				s.logger.Errorf("error translating Butane config: %v", err)
+				s.renderError(w, http.StatusUnprocessableEntity, templateError(err, group, profile, profile.IgnitionId))
+				return
+			}
+			s.writeJSON(w, ign)
 			return
 		}
 
 		// Convert Container Linux Config into an Ignition Config
-		ign, report := ct.Convert(config, "", ast)
-		if report.IsFatal() {
-			s.logger.Errorf("error converting Container Linux config: %s", report.String())
-			http.NotFound(w, req)
+		ign, err := ignition.Convert(buf.Bytes())
+		if err != nil {
+			s.logger.Errorf("error converting Container Linux config: %v", err)
+			s.renderError(w, http.StatusUnprocessableEntity, templateError(err, group, profile, profile.IgnitionId))
 			return
 		}
-
-		s.renderJSON(w, ign)
+		s.writeJSON(w, ign)
 		return
 	}
 	return http.HandlerFunc(fn)
 }
-
-// isIgnition returns true if the file should be treated as plain Ignition.
-func isIgnition(filename string) bool {
-	return strings.HasSuffix(filename, ".ign") || strings.HasSuffix(filename, ".ignition")
-}
@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"context"
+	"encoding/json"
+
+	"github.com/sirupsen/logrus"
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 
@@ -64,6 +67,93 @@ func TestIgnitionHandler_V2_2_JSON(t *testing.T) {
 	assert.Equal(t, content, w.Body.String())
 }
 
+func TestIgnitionHandler_V3_JSON(t *testing.T) {
+	content := `{"ignition":{"version":"3.1.0"},"systemd":{"units":[{"name":"etcd.service","enabled":true}]}}`
+	profile := &storagepb.Profile{
+		Id:         fake.Group.Profile,
+		IgnitionId: "file.ign",
+	}
+	store := &fake.FixedStore{
+		Profiles:        map[string]*storagepb.Profile{fake.Group.Profile: profile},
+		IgnitionConfigs: map[string]string{"file.ign": content},
+	}
+	logger, hook := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.ignitionHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - raw Ignition config served directly
+	// - validated against its declared spec version without warnings
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, jsonContentType, w.HeaderMap.Get(contentType))
+	assert.Equal(t, content, w.Body.String())
+	for _, entry := range hook.AllEntries() {
+		assert.NotEqual(t, logrus.WarnLevel, entry.Level)
+	}
+}
+
+func TestIgnitionHandler_Butane(t *testing.T) {
+	content := `
+variant: fcos
+version: 1.1.0
+systemd:
+  units:
+    - name: {{.service_name}}.service
+      enabled: true
+    - name: {{.request.query.foo}}.service
+      enabled: true
+`
+	expectedIgnition := `{"ignition":{"version":"3.1.0"},"systemd":{"units":[{"enabled":true,"name":"etcd2.service"},{"enabled":true,"name":"some-param.service"}]}}`
+	store := &fake.FixedStore{
+		Profiles:        map[string]*storagepb.Profile{fake.Group.Profile: testProfileIgnitionButane},
+		IgnitionConfigs: map[string]string{testProfileIgnitionButane.IgnitionId: content},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.ignitionHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/?foo=some-param", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - Butane template rendered with Group metadata and query variables
+	// - Translated to an Ignition config of the spec version the Butane
+	// variant and version target
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, jsonContentType, w.HeaderMap.Get(contentType))
+	assert.Equal(t, expectedIgnition, w.Body.String())
+}
+
+func TestIgnitionHandler_InvalidButane(t *testing.T) {
+	content := `
+variant: fcos
+version: 1.1.0
+systemd:
+  units:
+    - enabled: true
+`
+	store := &fake.FixedStore{
+		Profiles:        map[string]*storagepb.Profile{fake.Group.Profile: testProfileIgnitionButane},
+		IgnitionConfigs: map[string]string{testProfileIgnitionButane.IgnitionId: content},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: store})
+	h := srv.ignitionHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - invalid Ignition (a unit without a name) is not served
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
 func TestIgnitionHandler_CL_YAML(t *testing.T) {
 	// exercise templating features, not a realistic Container Linux Config template
 	content := `
@@ -107,6 +197,7 @@ func TestIgnitionHandler_MissingCtxProfile(t *testing.T) {
 	req, _ := http.NewRequest("GET", "/", nil)
 	h.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `{"error":"no Group matches the machine's labels"}`, w.Body.String())
 }
 
 func TestIgnitionHandler_MissingIgnitionConfig(t *testing.T) {
@@ -144,5 +235,29 @@ systemd:
 	// assert that:
 	// - Ignition template rendering errors because "missing_key" is not
 	// present in the template variables
+	// - error body identifies the Group, Profile, and template
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, jsonContentType, w.HeaderMap.Get(contentType))
+	resp := new(errorResponse)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), resp))
+	assert.Contains(t, resp.Error, "missing_key")
+	assert.Equal(t, fake.Group.Id, resp.Group)
+	assert.Equal(t, fake.Profile.Id, resp.Profile)
+	assert.Equal(t, fake.Profile.IgnitionId, resp.Template)
+}
+
+func TestIgnitionHandler_MissingProfile(t *testing.T) {
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	c := server.NewServer(&server.Config{Store: &fake.EmptyStore{}})
+	h := srv.ignitionHandler(c)
+	ctx := withGroup(context.Background(), fake.Group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - missing Profile is a 404 which identifies the Group and Profile
 	assert.Equal(t, http.StatusNotFound, w.Code)
+	expected := `{"error":"no Profile named g1h2i3j4","group":"test-group","profile":"g1h2i3j4"}`
+	assert.Equal(t, expected, w.Body.String())
 }
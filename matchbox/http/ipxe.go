@@ -7,6 +7,8 @@ import (
 	"text/template"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
 const ipxeBootstrap = `#!ipxe
@@ -50,8 +52,25 @@ func (s *Server) ipxeHandler() http.Handler {
 			"profile": profile.Id,
 		}).Debug("Matched an iPXE config")
 
+		// render the NetBoot with Group variables, if any
+		group, err := groupFromContext(ctx)
+		if err != nil {
+			group = &storagepb.Group{}
+		}
+		data, err := collectVariables(req, group)
+		if err != nil {
+			s.logger.Errorf("error collecting variables: %v", err)
+			http.NotFound(w, req)
+			return
+		}
+		boot, err := s.renderNetBoot(profile.Boot, data)
+		if err != nil {
+			http.NotFound(w, req)
+			return
+		}
+
 		var buf bytes.Buffer
-		err = ipxeTemplate.Execute(&buf, profile.Boot)
+		err = ipxeTemplate.Execute(&buf, boot)
 		if err != nil {
 			s.logger.Errorf("error rendering template: %v", err)
 			http.NotFound(w, req)
@@ -42,6 +42,52 @@ boot
 	assert.Equal(t, expectedScript, w.Body.String())
 }
 
+func TestIPXEHandler_Variables(t *testing.T) {
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	h := srv.ipxeHandler()
+	profile := &storagepb.Profile{
+		Id: "g1h2i3j4",
+		Boot: &storagepb.NetBoot{
+			Kernel: "{{.baseurl}}/{{.os_version}}/kernel",
+			Initrd: []string{"{{.baseurl}}/{{.os_version}}/initrd"},
+			Args:   []string{"config.url={{.baseurl}}/ignition?mac=${mac:hexhyp}", "os={{.request.query.os}}"},
+		},
+	}
+	group := &storagepb.Group{
+		Id:       "test-group",
+		Metadata: []byte(`{"baseurl":"http://matchbox.example.com:8080","os_version":"2605.6.0"}`),
+	}
+	ctx := withProfile(withGroup(context.Background(), group), profile)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/?os=installed", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - the NetBoot kernel, initrd, and args are rendered with Group metadata
+	// and query variables
+	expectedScript := `#!ipxe
+kernel http://matchbox.example.com:8080/2605.6.0/kernel config.url=http://matchbox.example.com:8080/ignition?mac=${mac:hexhyp} os=installed
+initrd http://matchbox.example.com:8080/2605.6.0/initrd
+boot
+`
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, expectedScript, w.Body.String())
+}
+
+func TestIPXEHandler_MissingVariable(t *testing.T) {
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	h := srv.ipxeHandler()
+	profile := &storagepb.Profile{
+		Boot: &storagepb.NetBoot{Kernel: "{{.baseurl}}/kernel"},
+	}
+	ctx := withProfile(withGroup(context.Background(), &storagepb.Group{}), profile)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 func TestIPXEHandler_MissingCtxProfile(t *testing.T) {
 	logger, _ := logtest.NewNullLogger()
 	srv := NewServer(&Config{Logger: logger})
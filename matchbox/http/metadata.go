@@ -21,7 +21,7 @@ func (s *Server) metadataHandler() http.Handler {
 			s.logger.WithFields(logrus.Fields{
 				"labels": labelsFromRequest(nil, req),
 			}).Infof("No matching group")
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusNotFound, &errorResponse{Error: errNoMatchingGroup.Error()})
 			return
 		}
 
@@ -35,7 +35,10 @@ func (s *Server) metadataHandler() http.Handler {
 		data, err := collectVariables(req, group)
 		if err != nil {
 			s.logger.Errorf("error collecting variables: %v", err)
-			http.NotFound(w, req)
+			s.renderError(w, http.StatusInternalServerError, &errorResponse{
+				Error: err.Error(),
+				Group: group.Id,
+			})
 			return
 		}
 
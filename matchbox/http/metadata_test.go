@@ -104,3 +104,22 @@ func metadataToMap(metadata string) map[string]string {
 	}
 	return data
 }
+
+func TestMetadataHandler_InvalidMetadata(t *testing.T) {
+	group := &storagepb.Group{
+		Id:       "test-group",
+		Metadata: []byte(`{"invalid`),
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	h := srv.metadataHandler()
+	ctx := withGroup(context.Background(), group)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h.ServeHTTP(w, req.WithContext(ctx))
+	// assert that:
+	// - invalid Group metadata is a server error which identifies the Group
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, jsonContentType, w.HeaderMap.Get(contentType))
+	assert.Contains(t, w.Body.String(), `"group":"test-group"`)
+}
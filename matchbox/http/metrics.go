@@ -0,0 +1,136 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Match outcomes
+const (
+	outcomeMatched     = "matched"
+	outcomeNoGroup     = "no_group"
+	outcomeNoProfile   = "no_profile"
+	outcomeRenderError = "render_error"
+)
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "matchbox",
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by endpoint and status code.",
+	}, []string{"endpoint", "code"})
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "matchbox",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint"})
+	matchesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "matchbox",
+		Subsystem: "http",
+		Name:      "matches_total",
+		Help:      "Number of machine requests by endpoint, match outcome, Group, and Profile.",
+	}, []string{"endpoint", "outcome", "group", "profile"})
+)
+
+func init() {
+	prometheus.MustRegister(requestsTotal, requestDuration, matchesTotal)
+}
+
+// match records how a request was matched to a Group and Profile.
+type match struct {
+	outcome string
+	group   string
+	profile string
+}
+
+// withMatch returns a copy of ctx that stores the given match.
+func withMatch(ctx context.Context, m *match) context.Context {
+	return context.WithValue(ctx, matchKey, m)
+}
+
+// recordMatch records the outcome of matching the request of the ctx to a
+// Group and Profile, if the request is instrumented.
+func recordMatch(ctx context.Context, outcome, group, profile string) {
+	if m, ok := ctx.Value(matchKey).(*match); ok {
+		m.outcome, m.group, m.profile = outcome, group, profile
+	}
+}
+
+// statusRecorder is a ResponseWriter which records the status code.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.code == 0 {
+		r.code = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(data []byte) (int, error) {
+	if r.code == 0 {
+		r.code = http.StatusOK
+	}
+	return r.ResponseWriter.Write(data)
+}
+
+// ReadFrom copies from src, using the ReaderFrom of the ResponseWriter (e.g.
+// sendfile for assets), if any.
+func (r *statusRecorder) ReadFrom(src io.Reader) (int64, error) {
+	if r.code == 0 {
+		r.code = http.StatusOK
+	}
+	return io.Copy(r.ResponseWriter, src)
+}
+
+// Flush sends buffered data to the client, if the ResponseWriter is a
+// Flusher.
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		if r.code == 0 {
+			r.code = http.StatusOK
+		}
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the ResponseWriter, so http.ResponseController can reach
+// its other optional interfaces.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// instrument counts and times requests to an endpoint, along with the match
+// outcome of machine requests. Matched requests which fail are counted as
+// render errors.
+func (s *Server) instrument(endpoint string, next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		m := new(match)
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, req.WithContext(withMatch(req.Context(), m)))
+
+		if rec.code == 0 {
+			rec.code = http.StatusOK
+		}
+		requestsTotal.WithLabelValues(endpoint, strconv.Itoa(rec.code)).Inc()
+		requestDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+		if m.outcome == outcomeMatched && rec.code >= http.StatusBadRequest {
+			m.outcome = outcomeRenderError
+		}
+		if m.outcome != "" {
+			matchesTotal.WithLabelValues(endpoint, m.outcome, m.group, m.profile).Inc()
+		}
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/server"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+func TestMetrics(t *testing.T) {
+	group := &storagepb.Group{
+		Id:       "no-profile",
+		Profile:  "missing",
+		Selector: map[string]string{"uuid": "e5f6"},
+	}
+	store := &fake.FixedStore{
+		Groups: map[string]*storagepb.Group{
+			fake.Group.Id: fake.Group,
+			group.Id:      group,
+		},
+		Profiles:        map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+		IgnitionConfigs: map[string]string{fake.IgnitionYAMLName: fake.IgnitionYAML},
+	}
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{
+		Core:    server.NewServer(&server.Config{Store: store}),
+		Logger:  logger,
+		Metrics: true,
+	})
+	h := srv.HTTPHandler()
+
+	cases := []struct {
+		path    string
+		code    int
+		outcome string
+		group   string
+		profile string
+	}{
+		{"/ignition?uuid=a1b2c3d4", http.StatusOK, outcomeMatched, fake.Group.Id, fake.Profile.Id},
+		{"/ignition?uuid=unknown", http.StatusNotFound, outcomeNoGroup, "", ""},
+		{"/ipxe?uuid=e5f6", http.StatusNotFound, outcomeNoProfile, group.Id, group.Profile},
+		{"/ignition?uuid=e5f6", http.StatusNotFound, outcomeNoProfile, group.Id, group.Profile},
+		// the generic template doesn't exist
+		{"/generic?uuid=a1b2c3d4", http.StatusNotFound, outcomeRenderError, fake.Group.Id, fake.Profile.Id},
+	}
+	// assert that:
+	// - requests are counted by endpoint and status code
+	// - match outcomes are counted with the Group and Profile
+	for _, c := range cases {
+		req, _ := http.NewRequest("GET", c.path, nil)
+		endpoint := req.URL.Path
+		requests := requestsTotal.WithLabelValues(endpoint, strconv.Itoa(c.code))
+		matches := matchesTotal.WithLabelValues(endpoint, c.outcome, c.group, c.profile)
+		beforeRequests, beforeMatches := testutil.ToFloat64(requests), testutil.ToFloat64(matches)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Equal(t, c.code, w.Code)
+		assert.Equal(t, beforeRequests+1, testutil.ToFloat64(requests))
+		assert.Equal(t, beforeMatches+1, testutil.ToFloat64(matches))
+	}
+
+	// assert that:
+	// - metrics are served
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `matchbox_http_matches_total{endpoint="/ignition",group="",outcome="no_group",profile=""}`)
+	assert.Contains(t, w.Body.String(), `matchbox_http_requests_total{code="404",endpoint="/generic"}`)
+}
+
+func TestInstrument_Flush(t *testing.T) {
+	srv := NewServer(&Config{Core: server.NewServer(&server.Config{Store: &fake.FixedStore{}})})
+	h := srv.instrument("/flush", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if assert.True(t, ok) {
+			w.Write([]byte("partial"))
+			flusher.Flush()
+		}
+	}))
+	// assert that:
+	// - instrumented handlers can flush the underlying ResponseWriter
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/flush", nil)
+	h.ServeHTTP(w, req)
+	assert.True(t, w.Flushed)
+	assert.Equal(t, "partial", w.Body.String())
+}
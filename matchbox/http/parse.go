@@ -1,13 +1,13 @@
 package http
 
 import (
-	"encoding/json"
 	"net"
 	"net/http"
 	"strings"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/poseidon/matchbox/matchbox/render"
 	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
@@ -15,23 +15,7 @@ import (
 // query parameters into a single structured map suitable for rendering
 // templates.
 func collectVariables(req *http.Request, group *storagepb.Group) (map[string]interface{}, error) {
-	data := make(map[string]interface{})
-	data["request"] = make(map[string]interface{})
-	if group.Metadata != nil {
-		err := json.Unmarshal(group.Metadata, &data)
-		if err != nil {
-			return nil, err
-		}
-	}
-	for key, value := range group.Selector {
-		data[strings.ToLower(key)] = value
-	}
-	// reserved variables
-	data["request"] = map[string]interface{}{
-		"query":     labelsFromRequest(nil, req),
-		"raw_query": req.URL.RawQuery,
-	}
-	return data, nil
+	return render.Variables(group, labelsFromRequest(nil, req), req.URL.RawQuery)
 }
 
 // labelsFromRequest returns request query parameters.
@@ -1,10 +1,15 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
-	"text/template"
+	"strings"
+
+	"github.com/poseidon/matchbox/matchbox/render"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
 const (
@@ -12,6 +17,8 @@ const (
 	jsonContentType = "application/json"
 )
 
+var errNoMatchingGroup = errors.New("no Group matches the machine's labels")
+
 // renderJSON encodes structs to JSON, writes the response to the
 // ResponseWriter, and logs encoding errors.
 func (s *Server) renderJSON(w http.ResponseWriter, v interface{}) {
@@ -34,19 +41,84 @@ func (s *Server) writeJSON(w http.ResponseWriter, data []byte) {
 	}
 }
 
-func (s *Server) renderTemplate(w io.Writer, data interface{}, contents ...string) (err error) {
-	tmpl := template.New("").Option("missingkey=error")
-	for _, content := range contents {
-		tmpl, err = tmpl.Parse(content)
-		if err != nil {
-			s.logger.Errorf("error parsing template: %v", err)
-			return err
-		}
+// errorResponse is a JSON error body which identifies the Group, Profile, and
+// template involved so machines can log actionable errors.
+type errorResponse struct {
+	Error    string `json:"error"`
+	Group    string `json:"group,omitempty"`
+	Profile  string `json:"profile,omitempty"`
+	Template string `json:"template,omitempty"`
+}
+
+// templateError returns an errorResponse for a failure serving a Profile's
+// template to a machine matching the Group.
+func templateError(err error, group *storagepb.Group, profile *storagepb.Profile, template string) *errorResponse {
+	return &errorResponse{
+		Error:    err.Error(),
+		Group:    group.Id,
+		Profile:  profile.Id,
+		Template: template,
 	}
-	err = tmpl.Execute(w, data)
+}
+
+// renderError writes an errorResponse as JSON with the given status code.
+func (s *Server) renderError(w http.ResponseWriter, code int, resp *errorResponse) {
+	js, err := json.Marshal(resp)
+	if err != nil {
+		s.logger.Errorf("error JSON encoding: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(contentType, jsonContentType)
+	w.WriteHeader(code)
+	if _, err := w.Write(js); err != nil {
+		s.logger.Errorf("error writing to response: %v", err)
+	}
+}
+
+// renderTemplate renders the template contents with data and logs errors.
+// Template partials may be included by the contents (e.g.
+// {{template "sshkeys" .}}).
+func (s *Server) renderTemplate(w io.Writer, data interface{}, partials map[string]string, contents ...string) error {
+	err := render.Template(w, data, partials, contents...)
 	if err != nil {
 		s.logger.Errorf("error rendering template: %v", err)
-		return err
 	}
-	return nil
+	return err
+}
+
+// renderNetBoot returns a copy of the NetBoot with the kernel, initrd, and
+// args rendered as templates with data.
+func (s *Server) renderNetBoot(boot *storagepb.NetBoot, data interface{}) (*storagepb.NetBoot, error) {
+	if boot == nil {
+		return nil, nil
+	}
+	render := func(content string) (string, error) {
+		// skip rendering plain values
+		if !strings.Contains(content, "{{") {
+			return content, nil
+		}
+		var buf bytes.Buffer
+		if err := s.renderTemplate(&buf, data, nil, content); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
+	rendered := boot.Copy()
+	var err error
+	if rendered.Kernel, err = render(boot.Kernel); err != nil {
+		return nil, err
+	}
+	for i, initrd := range boot.Initrd {
+		if rendered.Initrd[i], err = render(initrd); err != nil {
+			return nil, err
+		}
+	}
+	for i, arg := range boot.Args {
+		if rendered.Args[i], err = render(arg); err != nil {
+			return nil, err
+		}
+	}
+	return rendered, nil
 }
@@ -8,6 +8,8 @@ import (
 
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
 func TestRenderJSON(t *testing.T) {
@@ -52,6 +54,36 @@ func TestRenderJSON_WriteError(t *testing.T) {
 	assert.Empty(t, w.Body.String())
 }
 
+func TestRenderNetBoot(t *testing.T) {
+	logger, _ := logtest.NewNullLogger()
+	srv := NewServer(&Config{Logger: logger})
+	boot := &storagepb.NetBoot{
+		Kernel: "{{.baseurl}}/{{.os_version}}/vmlinuz",
+		Initrd: []string{"{{.baseurl}}/{{.os_version}}/initrd.img"},
+		Args:   []string{"config.url={{.baseurl}}/ignition?mac=${mac:hexhyp}", "console=ttyS0"},
+	}
+	data := map[string]interface{}{
+		"baseurl":    "http://matchbox.example.com:8080/assets",
+		"os_version": "2605.6.0",
+	}
+	// assert that:
+	// - the kernel, initrd, and args are rendered with data
+	// - iPXE variables and plain values are left as is
+	// - the NetBoot is not modified
+	rendered, err := srv.renderNetBoot(boot, data)
+	assert.Nil(t, err)
+	assert.Equal(t, &storagepb.NetBoot{
+		Kernel: "http://matchbox.example.com:8080/assets/2605.6.0/vmlinuz",
+		Initrd: []string{"http://matchbox.example.com:8080/assets/2605.6.0/initrd.img"},
+		Args:   []string{"config.url=http://matchbox.example.com:8080/assets/ignition?mac=${mac:hexhyp}", "console=ttyS0"},
+	}, rendered)
+	assert.Equal(t, "{{.baseurl}}/{{.os_version}}/vmlinuz", boot.Kernel)
+
+	// assert that missing variables are rendering errors
+	_, err = srv.renderNetBoot(boot, map[string]interface{}{})
+	assert.Error(t, err)
+}
+
 // UnwritableResponseWriter is a http.ResponseWriter for testing Write
 // failures.
 type UnwriteableResponseWriter struct {
@@ -3,6 +3,7 @@ package http
 import (
 	"net/http"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 
 	"github.com/poseidon/matchbox/matchbox/server"
@@ -18,6 +19,8 @@ type Config struct {
 	// config signers (.sig and .asc)
 	Signer        sign.Signer
 	ArmoredSigner sign.Signer
+	// serve Prometheus metrics at /metrics
+	Metrics bool
 }
 
 // Server serves boot and provisioning configs to machines via HTTP.
@@ -27,6 +30,7 @@ type Server struct {
 	assetsPath    string
 	signer        sign.Signer
 	armoredSigner sign.Signer
+	metrics       bool
 }
 
 // NewServer returns a new Server.
@@ -37,64 +41,78 @@ func NewServer(config *Config) *Server {
 		assetsPath:    config.AssetsPath,
 		signer:        config.Signer,
 		armoredSigner: config.ArmoredSigner,
+		metrics:       config.Metrics,
 	}
 }
 
 // HTTPHandler returns a HTTP handler for the server.
 func (s *Server) HTTPHandler() http.Handler {
 	mux := http.NewServeMux()
+	// count and time requests to each endpoint
+	handle := func(pattern string, handler http.Handler) {
+		mux.Handle(pattern, s.instrument(pattern, handler))
+	}
 
 	chain := func(next http.Handler) http.Handler {
 		return s.logRequest(next)
 	}
 	// matchbox version
-	mux.Handle("/", s.logRequest(homeHandler()))
+	handle("/", s.logRequest(homeHandler()))
 	// Boot via GRUB
-	mux.Handle("/grub", chain(s.selectProfile(s.core, s.grubHandler())))
+	handle("/grub", chain(s.selectProfile(s.core, s.grubHandler())))
 	// Boot via iPXE
-	mux.Handle("/boot.ipxe", chain(ipxeInspect()))
-	mux.Handle("/boot.ipxe.0", chain(ipxeInspect()))
-	mux.Handle("/ipxe", chain(s.selectProfile(s.core, s.ipxeHandler())))
+	handle("/boot.ipxe", chain(ipxeInspect()))
+	handle("/boot.ipxe.0", chain(ipxeInspect()))
+	handle("/ipxe", chain(s.selectProfile(s.core, s.ipxeHandler())))
 	// Ignition Config
-	mux.Handle("/ignition", chain(s.selectGroup(s.core, s.ignitionHandler(s.core))))
+	handle("/ignition", chain(s.selectGroup(s.core, s.ignitionHandler(s.core))))
 	// Cloud-Config
-	mux.Handle("/cloud", chain(s.selectGroup(s.core, s.cloudHandler(s.core))))
+	handle("/cloud", chain(s.selectGroup(s.core, s.cloudHandler(s.core))))
 	// Generic template
-	mux.Handle("/generic", chain(s.selectGroup(s.core, s.genericHandler(s.core))))
+	handle("/generic", chain(s.selectGroup(s.core, s.genericHandler(s.core))))
 	// Metadata
-	mux.Handle("/metadata", chain(s.selectGroup(s.core, s.metadataHandler())))
+	handle("/metadata", chain(s.selectGroup(s.core, s.metadataHandler())))
+	// Selection explanation
+	handle("/explain", chain(s.explainHandler(s.core)))
+	// Store health
+	handle("/status", chain(s.statusHandler(s.core)))
 
 	// Signatures
 	if s.signer != nil {
 		signerChain := func(next http.Handler) http.Handler {
 			return s.logRequest(sign.SignatureHandler(s.signer, next))
 		}
-		mux.Handle("/grub.sig", signerChain(s.selectProfile(s.core, s.grubHandler())))
-		mux.Handle("/boot.ipxe.sig", signerChain(ipxeInspect()))
-		mux.Handle("/boot.ipxe.0.sig", signerChain(ipxeInspect()))
-		mux.Handle("/ipxe.sig", signerChain(s.selectProfile(s.core, s.ipxeHandler())))
-		mux.Handle("/ignition.sig", signerChain(s.selectGroup(s.core, s.ignitionHandler(s.core))))
-		mux.Handle("/cloud.sig", signerChain(s.selectGroup(s.core, s.cloudHandler(s.core))))
-		mux.Handle("/generic.sig", signerChain(s.selectGroup(s.core, s.genericHandler(s.core))))
-		mux.Handle("/metadata.sig", signerChain(s.selectGroup(s.core, s.metadataHandler())))
+		handle("/grub.sig", signerChain(s.selectProfile(s.core, s.grubHandler())))
+		handle("/boot.ipxe.sig", signerChain(ipxeInspect()))
+		handle("/boot.ipxe.0.sig", signerChain(ipxeInspect()))
+		handle("/ipxe.sig", signerChain(s.selectProfile(s.core, s.ipxeHandler())))
+		handle("/ignition.sig", signerChain(s.selectGroup(s.core, s.ignitionHandler(s.core))))
+		handle("/cloud.sig", signerChain(s.selectGroup(s.core, s.cloudHandler(s.core))))
+		handle("/generic.sig", signerChain(s.selectGroup(s.core, s.genericHandler(s.core))))
+		handle("/metadata.sig", signerChain(s.selectGroup(s.core, s.metadataHandler())))
 	}
 	if s.armoredSigner != nil {
 		signerChain := func(next http.Handler) http.Handler {
 			return s.logRequest(sign.SignatureHandler(s.armoredSigner, next))
 		}
-		mux.Handle("/grub.asc", signerChain(s.selectProfile(s.core, s.grubHandler())))
-		mux.Handle("/boot.ipxe.asc", signerChain(ipxeInspect()))
-		mux.Handle("/boot.ipxe.0.asc", signerChain(ipxeInspect()))
-		mux.Handle("/ipxe.asc", signerChain(s.selectProfile(s.core, s.ipxeHandler())))
-		mux.Handle("/ignition.asc", signerChain(s.selectGroup(s.core, s.ignitionHandler(s.core))))
-		mux.Handle("/cloud.asc", signerChain(s.selectGroup(s.core, s.cloudHandler(s.core))))
-		mux.Handle("/generic.asc", signerChain(s.selectGroup(s.core, s.genericHandler(s.core))))
-		mux.Handle("/metadata.asc", signerChain(s.selectGroup(s.core, s.metadataHandler())))
+		handle("/grub.asc", signerChain(s.selectProfile(s.core, s.grubHandler())))
+		handle("/boot.ipxe.asc", signerChain(ipxeInspect()))
+		handle("/boot.ipxe.0.asc", signerChain(ipxeInspect()))
+		handle("/ipxe.asc", signerChain(s.selectProfile(s.core, s.ipxeHandler())))
+		handle("/ignition.asc", signerChain(s.selectGroup(s.core, s.ignitionHandler(s.core))))
+		handle("/cloud.asc", signerChain(s.selectGroup(s.core, s.cloudHandler(s.core))))
+		handle("/generic.asc", signerChain(s.selectGroup(s.core, s.genericHandler(s.core))))
+		handle("/metadata.asc", signerChain(s.selectGroup(s.core, s.metadataHandler())))
 	}
 
 	// kernel, initrd, and TLS assets
 	if s.assetsPath != "" {
-		mux.Handle("/assets/", s.logRequest(http.StripPrefix("/assets/", http.FileServer(http.Dir(s.assetsPath)))))
+		handle("/assets/", s.logRequest(http.StripPrefix("/assets/", http.FileServer(http.Dir(s.assetsPath)))))
+	}
+
+	// Prometheus metrics
+	if s.metrics {
+		mux.Handle("/metrics", promhttp.Handler())
 	}
 	return mux
 }
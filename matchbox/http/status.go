@@ -0,0 +1,49 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// status reports whether all stored resources can be parsed.
+type status struct {
+	Healthy bool                         `json:"healthy"`
+	Invalid []*storagepb.InvalidResource `json:"invalid"`
+}
+
+// statusHandler returns a handler that responds with the stored resources
+// which can't be parsed. The status code is 503 Service Unavailable if any
+// exist so load balancers and monitoring can detect a degraded store.
+func (s *Server) statusHandler(core server.Server) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		invalid, err := core.StatusGet(req.Context(), &pb.StatusGetRequest{})
+		if err != nil {
+			s.logger.Errorf("error getting status: %v", err)
+			s.renderError(w, http.StatusServiceUnavailable, &errorResponse{Error: err.Error()})
+			return
+		}
+		js, err := json.Marshal(&status{
+			Healthy: len(invalid) == 0,
+			Invalid: invalid,
+		})
+		if err != nil {
+			s.logger.Errorf("error JSON encoding: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if len(invalid) > 0 {
+			w.Header().Set(contentType, jsonContentType)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			if _, err := w.Write(js); err != nil {
+				s.logger.Errorf("error writing to response: %v", err)
+			}
+			return
+		}
+		s.writeJSON(w, js)
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/server"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+func TestStatusHandler(t *testing.T) {
+	invalid := &storagepb.InvalidResource{Kind: "Profile", Id: "noid", Error: "missing id"}
+	cases := []struct {
+		invalid []*storagepb.InvalidResource
+		code    int
+		healthy bool
+	}{
+		{nil, http.StatusOK, true},
+		{[]*storagepb.InvalidResource{invalid}, http.StatusServiceUnavailable, false},
+	}
+	logger, _ := logtest.NewNullLogger()
+	for _, c := range cases {
+		core := server.NewServer(&server.Config{Store: &fake.FixedStore{InvalidResources: c.invalid}})
+		srv := NewServer(&Config{Core: core, Logger: logger})
+		h := srv.statusHandler(core)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/status", nil)
+		h.ServeHTTP(w, req)
+		// assert that:
+		// - the status code reflects whether the store is healthy
+		// - invalid resources are listed
+		assert.Equal(t, c.code, w.Code)
+		assert.Equal(t, jsonContentType, w.HeaderMap.Get(contentType))
+		st := new(status)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), st))
+		assert.Equal(t, c.healthy, st.Healthy)
+		assert.Equal(t, len(c.invalid), len(st.Invalid))
+	}
+}
@@ -12,6 +12,11 @@ var (
 		IgnitionId: "ignition.yaml",
 	}
 
+	testProfileIgnitionButane = &storagepb.Profile{
+		Id:         "g1h2i3j4",
+		IgnitionId: "ignition.bu",
+	}
+
 	testProfileGeneric = &storagepb.Profile{
 		Id:         "g1h2i3j4",
 		IgnitionId: "generic.tmpl",
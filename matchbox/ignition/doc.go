@@ -0,0 +1,3 @@
+// Package ignition validates Ignition configs and translates Butane configs
+// to Ignition.
+package ignition
@@ -0,0 +1,132 @@
+package ignition
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/butane/config"
+	"github.com/coreos/butane/config/common"
+	ct "github.com/coreos/container-linux-config-transpiler/config"
+	v2_0 "github.com/coreos/ignition/config/v2_0"
+	v2_1 "github.com/coreos/ignition/config/v2_1"
+	v2_2 "github.com/coreos/ignition/config/v2_2"
+	v2_3 "github.com/coreos/ignition/config/v2_3"
+	v2_4 "github.com/coreos/ignition/config/v2_4"
+	"github.com/coreos/ignition/v2/config/util"
+	v3_0 "github.com/coreos/ignition/v2/config/v3_0"
+	v3_1 "github.com/coreos/ignition/v2/config/v3_1"
+	v3_2 "github.com/coreos/ignition/v2/config/v3_2"
+	v3_3 "github.com/coreos/ignition/v2/config/v3_3"
+)
+
+// parsers validate raw Ignition configs by declared spec version.
+var parsers = map[string]func(data []byte) error{
+	"2.0.0": func(data []byte) error {
+		_, rpt, err := v2_0.Parse(data)
+		return parseError(err, rpt)
+	},
+	"2.1.0": func(data []byte) error {
+		_, rpt, err := v2_1.Parse(data)
+		return parseError(err, rpt)
+	},
+	"2.2.0": func(data []byte) error {
+		_, rpt, err := v2_2.Parse(data)
+		return parseError(err, rpt)
+	},
+	"2.3.0": func(data []byte) error {
+		_, rpt, err := v2_3.Parse(data)
+		return parseError(err, rpt)
+	},
+	"2.4.0": func(data []byte) error {
+		_, rpt, err := v2_4.Parse(data)
+		return parseError(err, rpt)
+	},
+	"3.0.0": func(data []byte) error {
+		_, rpt, err := v3_0.Parse(data)
+		return parseError(err, rpt)
+	},
+	"3.1.0": func(data []byte) error {
+		_, rpt, err := v3_1.Parse(data)
+		return parseError(err, rpt)
+	},
+	"3.2.0": func(data []byte) error {
+		_, rpt, err := v3_2.Parse(data)
+		return parseError(err, rpt)
+	},
+	"3.3.0": func(data []byte) error {
+		_, rpt, err := v3_3.Parse(data)
+		return parseError(err, rpt)
+	},
+}
+
+// IsIgnition returns true if the file should be treated as plain Ignition.
+func IsIgnition(filename string) bool {
+	return strings.HasSuffix(filename, ".ign") || strings.HasSuffix(filename, ".ignition")
+}
+
+// IsButane returns true if the file should be treated as a Butane config.
+func IsButane(filename string) bool {
+	return strings.HasSuffix(filename, ".bu") || strings.HasSuffix(filename, ".butane")
+}
+
+// Version returns the Ignition spec version a raw Ignition config declares.
+func Version(data []byte) (string, error) {
+	version, rpt, err := util.GetConfigVersion(data)
+	if err != nil {
+		return "", parseError(err, rpt)
+	}
+	return version.String(), nil
+}
+
+// Validate validates a raw Ignition config against the spec version it
+// declares. Returns nil if there are no validation errors.
+func Validate(data []byte) error {
+	version, err := Version(data)
+	if err != nil {
+		return err
+	}
+	parse, ok := parsers[version]
+	if !ok {
+		return fmt.Errorf("unsupported Ignition spec version %s", version)
+	}
+	return parse(data)
+}
+
+// Translate translates a Butane config into a validated Ignition config. The
+// Butane variant and version determine the Ignition spec version.
+func Translate(data []byte) ([]byte, error) {
+	ign, rpt, err := config.TranslateBytes(data, common.TranslateBytesOptions{})
+	if err != nil {
+		return nil, parseError(err, rpt)
+	}
+	if err := Validate(ign); err != nil {
+		return nil, err
+	}
+	return ign, nil
+}
+
+// Convert converts a Container Linux Config into an Ignition config (spec
+// 2.2).
+func Convert(data []byte) ([]byte, error) {
+	config, ast, rpt := ct.Parse(data)
+	if rpt.IsFatal() {
+		return nil, fmt.Errorf("error parsing Container Linux config: %s", strings.TrimSpace(rpt.String()))
+	}
+	ign, rpt := ct.Convert(config, "", ast)
+	if rpt.IsFatal() {
+		return nil, fmt.Errorf("error converting Container Linux config: %s", strings.TrimSpace(rpt.String()))
+	}
+	return json.Marshal(ign)
+}
+
+// parseError combines a parse error with its report, if any.
+func parseError(err error, rpt fmt.Stringer) error {
+	if err == nil {
+		return nil
+	}
+	if details := strings.TrimSpace(rpt.String()); details != "" {
+		return fmt.Errorf("%v: %s", err, details)
+	}
+	return err
+}
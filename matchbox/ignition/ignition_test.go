@@ -0,0 +1,105 @@
+package ignition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsIgnition(t *testing.T) {
+	assert.True(t, IsIgnition("fedora-coreos.ign"))
+	assert.True(t, IsIgnition("fedora-coreos.ignition"))
+	assert.False(t, IsIgnition("fedora-coreos.bu"))
+	assert.False(t, IsIgnition("flatcar.yaml"))
+}
+
+func TestIsButane(t *testing.T) {
+	assert.True(t, IsButane("fedora-coreos.bu"))
+	assert.True(t, IsButane("fedora-coreos.butane"))
+	assert.False(t, IsButane("fedora-coreos.ign"))
+	assert.False(t, IsButane("flatcar.yaml"))
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		config string
+		valid  bool
+	}{
+		{`{"ignition":{"version":"2.2.0"},"systemd":{"units":[{"name":"etcd2.service","enable":true}]}}`, true},
+		{`{"ignition":{"version":"2.3.0"}}`, true},
+		{`{"ignition":{"version":"3.0.0"}}`, true},
+		{`{"ignition":{"version":"3.1.0"},"systemd":{"units":[{"name":"etcd.service","enabled":true}]}}`, true},
+		{`{"ignition":{"version":"3.3.0"}}`, true},
+		// unit without a name
+		{`{"ignition":{"version":"3.1.0"},"systemd":{"units":[{"enabled":true}]}}`, false},
+		// unsupported spec versions
+		{`{"ignition":{"version":"3.4.0-experimental"}}`, false},
+		{`{"ignition":{"version":"9.0.0"}}`, false},
+		{`{"ignition":{}}`, false},
+		{`not json`, false},
+		{``, false},
+	}
+	for _, c := range cases {
+		err := Validate([]byte(c.config))
+		if c.valid {
+			assert.Nil(t, err, c.config)
+		} else {
+			assert.Error(t, err, c.config)
+		}
+	}
+}
+
+func TestVersion(t *testing.T) {
+	version, err := Version([]byte(`{"ignition":{"version":"3.2.0"}}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "3.2.0", version)
+	_, err = Version([]byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestTranslate(t *testing.T) {
+	cases := []struct {
+		config   string
+		expected string
+	}{
+		{"variant: fcos\nversion: 1.0.0\n", `{"ignition":{"version":"3.0.0"}}`},
+		{"variant: fcos\nversion: 1.1.0\n", `{"ignition":{"version":"3.1.0"}}`},
+		{"variant: fcos\nversion: 1.4.0\n", `{"ignition":{"version":"3.3.0"}}`},
+		{"variant: flatcar\nversion: 1.0.0\n", `{"ignition":{"version":"3.3.0"}}`},
+	}
+	for _, c := range cases {
+		ign, err := Translate([]byte(c.config))
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, string(ign))
+	}
+}
+
+func TestTranslate_Invalid(t *testing.T) {
+	cases := []string{
+		// missing variant
+		"version: 1.1.0\n",
+		// unknown version
+		"variant: fcos\nversion: 9.0.0\n",
+		// unit without a name
+		"variant: fcos\nversion: 1.1.0\nsystemd:\n  units:\n    - enabled: true\n",
+		"not: [yaml",
+	}
+	for _, c := range cases {
+		_, err := Translate([]byte(c))
+		assert.Error(t, err, c)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	config := "systemd:\n  units:\n    - name: etcd2.service\n      enable: true\n"
+	expected := `{"ignition":{"config":{},"security":{"tls":{}},"timeouts":{},"version":"2.2.0"},"networkd":{},"passwd":{},"storage":{},"systemd":{"units":[{"enable":true,"name":"etcd2.service"}]}}`
+	ign, err := Convert([]byte(config))
+	assert.Nil(t, err)
+	assert.Equal(t, expected, string(ign))
+
+	// unit without a name
+	_, err = Convert([]byte("systemd:\n  units:\n    - enable: true\n"))
+	assert.Error(t, err)
+	_, err = Convert([]byte("systemd: ["))
+	assert.Error(t, err)
+}
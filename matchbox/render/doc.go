@@ -0,0 +1,3 @@
+// Package render renders Ignition, Generic, Cloud-Config, and NetBoot
+// templates with machine variables.
+package render
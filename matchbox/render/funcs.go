@@ -0,0 +1,159 @@
+package render
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Funcs are functions available to Ignition, Generic, Cloud-Config, and
+// NetBoot templates.
+var Funcs = template.FuncMap{
+	"default":   defaultValue,
+	"b64enc":    b64enc,
+	"b64dec":    b64dec,
+	"indent":    indent,
+	"nindent":   nindent,
+	"join":      join,
+	"split":     split,
+	"toJSON":    toJSON,
+	"cidrhost":  cidrhost,
+	"sha512sum": sha512sum,
+}
+
+// defaultValue returns the value, or def if the value is missing or empty.
+// Missing map keys should be looked up with index (e.g.
+// {{ index . "key" | default "value" }}).
+func defaultValue(def interface{}, value interface{}) interface{} {
+	if value == nil {
+		return def
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		if v.Len() == 0 {
+			return def
+		}
+	case reflect.Bool:
+		if !v.Bool() {
+			return def
+		}
+	}
+	return value
+}
+
+// b64enc returns the standard base64 encoding of s.
+func b64enc(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+// b64dec returns the decoded standard base64 string s.
+func b64dec(s string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	return string(data), err
+}
+
+// indent indents every line of s by n spaces.
+func indent(n interface{}, s string) (string, error) {
+	spaces, err := toInt(n)
+	if err != nil {
+		return "", err
+	}
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.Replace(s, "\n", "\n"+pad, -1), nil
+}
+
+// nindent indents every line of s by n spaces, preceded by a newline.
+func nindent(n interface{}, s string) (string, error) {
+	indented, err := indent(n, s)
+	return "\n" + indented, err
+}
+
+// join joins the elements of a list with sep.
+func join(sep string, list interface{}) (string, error) {
+	switch l := list.(type) {
+	case []string:
+		return strings.Join(l, sep), nil
+	case []interface{}:
+		elems := make([]string, len(l))
+		for i, elem := range l {
+			elems[i] = fmt.Sprint(elem)
+		}
+		return strings.Join(elems, sep), nil
+	}
+	return "", fmt.Errorf("join: expected a list, got %T", list)
+}
+
+// split splits s into substrings separated by sep.
+func split(sep, s string) []string {
+	return strings.Split(s, sep)
+}
+
+// toJSON returns the JSON encoding of v.
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// cidrhost returns the IP address of host number hostnum within the CIDR
+// prefix (e.g. cidrhost "10.0.0.0/24" 5 is 10.0.0.5). Negative host numbers
+// count back from the end of the range.
+func cidrhost(prefix string, hostnum interface{}) (string, error) {
+	num, err := toInt(hostnum)
+	if err != nil {
+		return "", err
+	}
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return "", err
+	}
+	ones, bits := network.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	host := big.NewInt(int64(num))
+	if num < 0 {
+		host.Add(size, host)
+	}
+	if host.Sign() < 0 || host.Cmp(size) >= 0 {
+		return "", fmt.Errorf("cidrhost: prefix %s has no host %d", prefix, num)
+	}
+
+	ip := network.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	addr := new(big.Int).Add(new(big.Int).SetBytes(ip), host).Bytes()
+	// left pad to the address length
+	result := make(net.IP, len(ip))
+	copy(result[len(result)-len(addr):], addr)
+	return result.String(), nil
+}
+
+// sha512sum returns the hex encoded SHA-512 digest of s.
+func sha512sum(s string) string {
+	sum := sha512.Sum512([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// toInt converts template numbers (including JSON float64 metadata and
+// numeric strings) to an int.
+func toInt(v interface{}) (int, error) {
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int64:
+		return int(n), nil
+	case float64:
+		return int(n), nil
+	case string:
+		return strconv.Atoi(n)
+	}
+	return 0, fmt.Errorf("expected a number, got %T", v)
+}
@@ -0,0 +1,69 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	data := map[string]interface{}{
+		"name":    "node1",
+		"empty":   "",
+		"count":   float64(3),
+		"servers": []interface{}{"a", "b", "c"},
+		"network": map[string]interface{}{"cidr": "10.0.0.0/24"},
+		"file":    "line1\nline2",
+	}
+	cases := []struct {
+		template string
+		expected string
+	}{
+		// default
+		{`{{ index . "missing" | default "x" }}`, "x"},
+		{`{{ .empty | default "x" }}`, "x"},
+		{`{{ .name | default "x" }}`, "node1"},
+		// base64
+		{`{{ .name | b64enc }}`, "bm9kZTE="},
+		{`{{ "bm9kZTE=" | b64dec }}`, "node1"},
+		// indent
+		{`{{ .file | indent 2 }}`, "  line1\n  line2"},
+		{`key:{{ .file | nindent 4 }}`, "key:\n    line1\n    line2"},
+		// join and split
+		{`{{ join "," .servers }}`, "a,b,c"},
+		{`{{ split "," "a,b" | join " " }}`, "a b"},
+		// toJSON
+		{`{{ toJSON .servers }}`, `["a","b","c"]`},
+		// CIDR host math
+		{`{{ cidrhost .network.cidr 5 }}`, "10.0.0.5"},
+		{`{{ cidrhost .network.cidr .count }}`, "10.0.0.3"},
+		{`{{ cidrhost .network.cidr -2 }}`, "10.0.0.254"},
+		{`{{ cidrhost "10.0.1.0/23" 256 }}`, "10.0.1.0"},
+		{`{{ cidrhost "fd00::/64" 17 }}`, "fd00::11"},
+		// sha512
+		{`{{ sha512sum "" }}`, "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e"},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		err := Template(&buf, data, nil, c.template)
+		if assert.Nil(t, err, c.template) {
+			assert.Equal(t, c.expected, buf.String(), c.template)
+		}
+	}
+}
+
+func TestTemplateFuncs_Errors(t *testing.T) {
+	cases := []string{
+		`{{ "not base64!" | b64dec }}`,
+		`{{ "line" | indent "two" }}`,
+		`{{ join "," "not-a-list" }}`,
+		`{{ cidrhost "10.0.0.0/24" 256 }}`,
+		`{{ cidrhost "10.0.0.0/24" -257 }}`,
+		`{{ cidrhost "not-a-cidr" 1 }}`,
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		assert.Error(t, Template(&buf, nil, nil, c), c)
+	}
+}
@@ -0,0 +1,110 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// Parse parses the template contents. Template partials which the contents
+// include (e.g. {{template "sshkeys" .}}), directly or through other
+// partials, are parsed as named templates. Other partials aren't parsed, so
+// they can't break the template.
+func Parse(partials map[string]string, contents ...string) (*template.Template, error) {
+	tmpl := template.New("").Option("missingkey=error").Funcs(Funcs)
+	var err error
+	for _, content := range contents {
+		tmpl, err = tmpl.Parse(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var pending []string
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			pending = append(pending, includes(t.Tree.Root)...)
+		}
+	}
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		partial, ok := partials[name]
+		if !ok || tmpl.Lookup(name) != nil {
+			continue
+		}
+		t, err := tmpl.New(name).Parse(partial)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing template partial %s: %v", name, err)
+		}
+		if t.Tree != nil {
+			pending = append(pending, includes(t.Tree.Root)...)
+		}
+	}
+	return tmpl, nil
+}
+
+// ParsePartial parses a template partial to check its syntax.
+func ParsePartial(name, contents string) error {
+	_, err := template.New(name).Funcs(Funcs).Parse(contents)
+	return err
+}
+
+// includes returns the names of the templates a parsed node includes.
+func includes(node parse.Node) []string {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return nil
+		}
+		var names []string
+		for _, child := range node.Nodes {
+			names = append(names, includes(child)...)
+		}
+		return names
+	case *parse.TemplateNode:
+		return []string{node.Name}
+	case *parse.IfNode:
+		return append(includes(node.List), includes(node.ElseList)...)
+	case *parse.RangeNode:
+		return append(includes(node.List), includes(node.ElseList)...)
+	case *parse.WithNode:
+		return append(includes(node.List), includes(node.ElseList)...)
+	}
+	return nil
+}
+
+// Template renders the template contents with data. See Parse.
+func Template(w io.Writer, data interface{}, partials map[string]string, contents ...string) error {
+	tmpl, err := Parse(partials, contents...)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, data)
+}
+
+// Variables collects group selectors, metadata, and request-scoped query
+// parameters into a single structured map suitable for rendering templates.
+func Variables(group *storagepb.Group, query map[string]string, rawQuery string) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	data["request"] = make(map[string]interface{})
+	if group.Metadata != nil {
+		err := json.Unmarshal(group.Metadata, &data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range group.Selector {
+		data[strings.ToLower(key)] = value
+	}
+	// reserved variables
+	data["request"] = map[string]interface{}{
+		"query":     query,
+		"raw_query": rawQuery,
+	}
+	return data, nil
+}
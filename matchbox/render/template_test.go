@@ -0,0 +1,36 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate_Partials(t *testing.T) {
+	partials := map[string]string{
+		"hostname": `{{.name}}`,
+		"nested":   `host {{template "hostname" .}}`,
+		"broken":   `{{.name`,
+	}
+	data := map[string]interface{}{"name": "node1"}
+	// assert that:
+	// - included partials are rendered, including nested partials
+	// - unparsable partials which aren't included are ignored
+	// - unparsable partials which are included fail the render
+	var buf bytes.Buffer
+	err := Template(&buf, data, partials, `{{if .name}}{{template "nested" .}}{{end}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "host node1", buf.String())
+
+	_, err = Parse(partials, `{{template "broken" .}}`)
+	assert.EqualError(t, err, `error parsing template partial broken: template: broken:1: unclosed action`)
+}
+
+func TestParsePartial(t *testing.T) {
+	// assert that:
+	// - partials with valid syntax parse
+	// - partials with invalid syntax fail
+	assert.Nil(t, ParsePartial("ok", `{{.name | default "x"}}`))
+	assert.NotNil(t, ParsePartial("broken", `{{.name`))
+}
@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"bufio"
+
+	"github.com/poseidon/matchbox/matchbox/rpc/rpcpb"
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// archiveChunkSize is the maximum size of streamed archive chunks.
+const archiveChunkSize = 64 * 1024
+
+// archiveServer takes a matchbox Server and implements a gRPC ArchiveServer.
+type archiveServer struct {
+	srv server.Server
+}
+
+func newArchiveServer(s server.Server) rpcpb.ArchiveServer {
+	return &archiveServer{
+		srv: s,
+	}
+}
+
+func (s *archiveServer) Export(req *pb.ArchiveExportRequest, stream rpcpb.Archive_ExportServer) error {
+	w := bufio.NewWriterSize(&exportWriter{stream: stream}, archiveChunkSize)
+	if err := s.srv.Export(stream.Context(), w); err != nil {
+		return grpcError(err)
+	}
+	return w.Flush()
+}
+
+func (s *archiveServer) Import(stream rpcpb.Archive_ImportServer) error {
+	err := s.srv.Import(stream.Context(), &importReader{stream: stream})
+	if err != nil {
+		return grpcError(err)
+	}
+	return stream.SendAndClose(&pb.ArchiveImportResponse{})
+}
+
+// exportWriter writes to an Export stream in chunks.
+type exportWriter struct {
+	stream rpcpb.Archive_ExportServer
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := len(p)
+		if n > archiveChunkSize {
+			n = archiveChunkSize
+		}
+		if err := w.stream.Send(&pb.ArchiveExportResponse{Data: p[:n]}); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// importReader reads the chunks of an Import stream.
+type importReader struct {
+	stream rpcpb.Archive_ImportServer
+	buf    []byte
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			// io.EOF once the client has sent the whole archive
+			return 0, err
+		}
+		r.buf = req.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+// exportStream collects the chunks sent on an Export stream.
+type exportStream struct {
+	grpc.ServerStream
+	chunks [][]byte
+}
+
+func (s *exportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *exportStream) Send(resp *pb.ArchiveExportResponse) error {
+	// like gRPC, don't retain the sent buffer
+	s.chunks = append(s.chunks, append([]byte{}, resp.Data...))
+	return nil
+}
+
+// importStream receives chunks on an Import stream.
+type importStream struct {
+	grpc.ServerStream
+	chunks [][]byte
+	closed bool
+}
+
+func (s *importStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *importStream) Recv() (*pb.ArchiveImportRequest, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return &pb.ArchiveImportRequest{Data: chunk}, nil
+}
+
+func (s *importStream) SendAndClose(resp *pb.ArchiveImportResponse) error {
+	s.closed = true
+	return nil
+}
+
+func TestArchiveServer(t *testing.T) {
+	source := fake.NewFixedStore()
+	source.Groups[fake.Group.Id] = fake.Group
+	source.Profiles[fake.Profile.Id] = fake.Profile
+	source.IgnitionConfigs["large.ign"] = string(bytes.Repeat([]byte("a"), 3*archiveChunkSize))
+	export := &exportStream{}
+	// assert that:
+	// - archives are exported in chunks no larger than archiveChunkSize
+	err := newArchiveServer(server.NewServer(&server.Config{Store: source})).Export(&pb.ArchiveExportRequest{}, export)
+	assert.Nil(t, err)
+	assert.True(t, len(export.chunks) > 3)
+	for _, chunk := range export.chunks {
+		assert.True(t, len(chunk) <= archiveChunkSize)
+	}
+
+	target := fake.NewFixedStore()
+	stream := &importStream{chunks: export.chunks}
+	// assert that:
+	// - chunked archives are imported
+	err = newArchiveServer(server.NewServer(&server.Config{Store: target})).Import(stream)
+	assert.Nil(t, err)
+	assert.True(t, stream.closed)
+	assert.Equal(t, fake.Group.Id, target.Groups[fake.Group.Id].Id)
+	assert.Equal(t, fake.Profile.Id, target.Profiles[fake.Profile.Id].Id)
+	assert.Equal(t, source.IgnitionConfigs["large.ign"], target.IgnitionConfigs["large.ign"])
+}
@@ -0,0 +1,271 @@
+package rpc
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/poseidon/matchbox/matchbox/rpc/rpcpb"
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// auditedStreams are the streaming methods which write resources.
+var auditedStreams = map[string]bool{
+	"/rpcpb.Archive/Import": true,
+}
+
+// auditServer takes a matchbox Server and implements a gRPC AuditServer.
+type auditServer struct {
+	srv server.Server
+}
+
+func newAuditServer(s server.Server) rpcpb.AuditServer {
+	return &auditServer{
+		srv: s,
+	}
+}
+
+func (s *auditServer) List(ctx context.Context, req *pb.AuditListRequest) (*pb.AuditListResponse, error) {
+	entries, err := s.srv.AuditList(ctx, req)
+	return &pb.AuditListResponse{Entries: entries}, grpcError(err)
+}
+
+// resource identifies a Group, Profile, or template.
+type resource struct {
+	kind string
+	name string
+}
+
+// auditUnaryInterceptor records each call which writes or deletes resources
+// in the audit log, with the subject of the client certificate and a diff of
+// each resource from before and after the call. Failed calls are recorded
+// without diffs. Dry runs aren't recorded.
+func auditUnaryInterceptor(srv server.Server) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resources, ok := writtenResources(req)
+		if !ok || dryRun(req) {
+			return handler(ctx, req)
+		}
+		before := make([]string, len(resources))
+		for i, r := range resources {
+			before[i] = resourceContents(ctx, srv, r)
+		}
+		resp, err := handler(ctx, req)
+
+		entry := newAuditEntry(ctx, info.FullMethod, err)
+		for i, r := range resources {
+			change := &storagepb.AuditChange{Kind: r.kind, Name: r.name}
+			if err == nil {
+				change.Diff = resourceDiff(r, before[i], resourceContents(ctx, srv, r))
+			}
+			entry.Changes = append(entry.Changes, change)
+		}
+		if auditErr := srv.AuditRecord(ctx, entry); auditErr != nil && err == nil {
+			return nil, grpcError(auditErr)
+		}
+		return resp, err
+	}
+}
+
+// auditStreamInterceptor records each streaming call which writes resources
+// in the audit log, with the subject of the client certificate. Streamed
+// resources are not diffed.
+func auditStreamInterceptor(srv server.Server) grpc.StreamServerInterceptor {
+	return func(s interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !auditedStreams[info.FullMethod] {
+			return handler(s, stream)
+		}
+		err := handler(s, stream)
+		entry := newAuditEntry(stream.Context(), info.FullMethod, err)
+		if auditErr := srv.AuditRecord(stream.Context(), entry); auditErr != nil && err == nil {
+			return grpcError(auditErr)
+		}
+		return err
+	}
+}
+
+// newAuditEntry returns an audit log entry for a call by the client of the
+// ctx, which returned err.
+func newAuditEntry(ctx context.Context, method string, err error) *storagepb.AuditEntry {
+	entry := &storagepb.AuditEntry{
+		Timestamp: time.Now().Unix(),
+		Subject:   clientSubject(ctx),
+		Method:    method,
+		Code:      status.Code(err).String(),
+	}
+	if err != nil {
+		entry.Error = status.Convert(err).Message()
+	}
+	return entry
+}
+
+// writtenResources returns the resources a request writes or deletes, and
+// whether the request is a write.
+func writtenResources(req interface{}) ([]resource, bool) {
+	switch r := req.(type) {
+	case *pb.GroupPutRequest:
+		return []resource{{storage.KindGroup, r.GetGroup().GetId()}}, true
+	case *pb.GroupDeleteRequest:
+		return []resource{{storage.KindGroup, r.Id}}, true
+	case *pb.ProfilePutRequest:
+		return []resource{{storage.KindProfile, r.GetProfile().GetId()}}, true
+	case *pb.ProfileDeleteRequest:
+		return []resource{{storage.KindProfile, r.Id}}, true
+	case *pb.IgnitionPutRequest:
+		return []resource{{storage.KindIgnition, r.Name}}, true
+	case *pb.IgnitionDeleteRequest:
+		return []resource{{storage.KindIgnition, r.Name}}, true
+	case *pb.GenericPutRequest:
+		return []resource{{storage.KindGeneric, r.Name}}, true
+	case *pb.GenericDeleteRequest:
+		return []resource{{storage.KindGeneric, r.Name}}, true
+	case *pb.CloudPutRequest:
+		return []resource{{storage.KindCloud, r.Name}}, true
+	case *pb.CloudDeleteRequest:
+		return []resource{{storage.KindCloud, r.Name}}, true
+	case *pb.PartialPutRequest:
+		return []resource{{storage.KindPartial, r.Name}}, true
+	case *pb.PartialDeleteRequest:
+		return []resource{{storage.KindPartial, r.Name}}, true
+	case *pb.HistoryRollbackRequest:
+		return []resource{{r.Kind, r.Name}}, true
+	case *pb.BatchApplyRequest:
+		return batchResources(r.GetBatch()), true
+	}
+	return nil, false
+}
+
+// dryRun returns true if a request only validates a write.
+func dryRun(req interface{}) bool {
+	switch r := req.(type) {
+	case *pb.IgnitionPutRequest:
+		return r.DryRun
+	case *pb.BatchApplyRequest:
+		return r.DryRun
+	}
+	return false
+}
+
+// batchResources returns the resources a Batch writes or deletes.
+func batchResources(batch *storagepb.Batch) []resource {
+	var resources []resource
+	for _, group := range batch.GetGroups() {
+		resources = append(resources, resource{storage.KindGroup, group.Id})
+	}
+	for _, profile := range batch.GetProfiles() {
+		resources = append(resources, resource{storage.KindProfile, profile.Id})
+	}
+	templates := []struct {
+		kind      string
+		templates []*storagepb.Template
+	}{
+		{storage.KindIgnition, batch.GetIgnition()},
+		{storage.KindGeneric, batch.GetGeneric()},
+		{storage.KindCloud, batch.GetCloud()},
+		{storage.KindPartial, batch.GetPartials()},
+	}
+	for _, t := range templates {
+		for _, template := range t.templates {
+			resources = append(resources, resource{t.kind, template.Name})
+		}
+	}
+	for _, deletion := range batch.GetDeletes() {
+		resources = append(resources, resource{deletion.Kind, deletion.Name})
+	}
+	return resources
+}
+
+// resourceContents returns the contents of a resource as stored, or an empty
+// string if it doesn't exist. Profiles are shown without inherited fields.
+func resourceContents(ctx context.Context, srv server.Server, r resource) string {
+	switch r.kind {
+	case storage.KindGroup:
+		group, err := srv.GroupGet(ctx, &pb.GroupGetRequest{Id: r.name})
+		if err != nil {
+			return ""
+		}
+		rich, err := group.ToRichGroup()
+		if err != nil {
+			return ""
+		}
+		return marshalIndent(rich)
+	case storage.KindProfile:
+		// ProfileGet resolves inherited fields, but ProfileList doesn't
+		profiles, err := srv.ProfileList(ctx, &pb.ProfileListRequest{})
+		if err != nil {
+			return ""
+		}
+		for _, profile := range profiles {
+			if profile.Id == r.name {
+				profile = profile.Copy()
+				profile.ResourceVersion = ""
+				return marshalIndent(profile)
+			}
+		}
+		return ""
+	case storage.KindIgnition:
+		return templateContents(srv.IgnitionGet(ctx, &pb.IgnitionGetRequest{Name: r.name}))
+	case storage.KindGeneric:
+		return templateContents(srv.GenericGet(ctx, &pb.GenericGetRequest{Name: r.name}))
+	case storage.KindCloud:
+		return templateContents(srv.CloudGet(ctx, &pb.CloudGetRequest{Name: r.name}))
+	case storage.KindPartial:
+		return templateContents(srv.PartialGet(ctx, &pb.PartialGetRequest{Name: r.name}))
+	}
+	return ""
+}
+
+// templateContents returns the contents of a template, or an empty string if
+// it couldn't be read.
+func templateContents(contents string, err error) string {
+	if err != nil {
+		return ""
+	}
+	return contents
+}
+
+// marshalIndent returns the indented JSON encoding of v, or an empty string
+// if it can't be encoded.
+func marshalIndent(v interface{}) string {
+	data, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return ""
+	}
+	return string(data) + "\n"
+}
+
+// resourceDiff returns a unified diff of the contents of a resource.
+func resourceDiff(r resource, before, after string) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(before),
+		B:        splitLines(after),
+		FromFile: "a/" + r.kind + "/" + r.name,
+		ToFile:   "b/" + r.kind + "/" + r.name,
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// splitLines splits text into lines which each end in a newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	lines := strings.SplitAfter(text, "\n")
+	// drop the empty string after the final newline
+	return lines[:len(lines)-1]
+}
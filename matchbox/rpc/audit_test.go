@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+func TestAuditUnaryInterceptor(t *testing.T) {
+	srv := server.NewServer(&server.Config{Store: fake.NewFixedStore()})
+	interceptor := auditUnaryInterceptor(srv)
+	info := &grpc.UnaryServerInfo{FullMethod: "/rpcpb.Ignition/IgnitionPut"}
+	put := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.IgnitionPut(ctx, req.(*pb.IgnitionPutRequest))
+	}
+	ctx := context.Background()
+
+	_, err := interceptor(ctx, &pb.IgnitionPutRequest{Name: "a.yaml", Config: []byte("a: 1\n")}, info, put)
+	assert.Nil(t, err)
+	_, err = interceptor(ctx, &pb.IgnitionPutRequest{Name: "a.yaml", Config: []byte("a: 2\n")}, info, put)
+	assert.Nil(t, err)
+	// assert that:
+	// - calls which write resources are recorded with a diff of each resource
+	entries, err := srv.AuditList(ctx, &pb.AuditListRequest{})
+	assert.Nil(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "/rpcpb.Ignition/IgnitionPut", entries[1].Method)
+		assert.Equal(t, "OK", entries[1].Code)
+		if assert.Len(t, entries[1].Changes, 1) {
+			change := entries[1].Changes[0]
+			assert.Equal(t, storage.KindIgnition, change.Kind)
+			assert.Equal(t, "a.yaml", change.Name)
+			assert.Contains(t, change.Diff, "-a: 1\n+a: 2\n")
+		}
+	}
+
+	// assert that:
+	// - failed calls are recorded without diffs
+	// - dry runs and reads aren't recorded
+	failed := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, grpcError(errors.New("bad"))
+	}
+	_, err = interceptor(ctx, &pb.GroupDeleteRequest{Id: "a"}, info, failed)
+	assert.NotNil(t, err)
+	_, err = interceptor(ctx, &pb.IgnitionPutRequest{Name: "a.yaml", DryRun: true}, info, put)
+	assert.Nil(t, err)
+	_, err = interceptor(ctx, &pb.GroupListRequest{}, info, failed)
+	assert.NotNil(t, err)
+	entries, err = srv.AuditList(ctx, &pb.AuditListRequest{})
+	assert.Nil(t, err)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, "Unknown", entries[2].Code)
+		assert.Equal(t, "bad", entries[2].Error)
+		assert.Equal(t, "", entries[2].Changes[0].Diff)
+	}
+}
+
+func TestResourceDiff(t *testing.T) {
+	r := resource{kind: storage.KindGeneric, name: "a"}
+	// assert that:
+	// - created and deleted resources diff against nothing
+	assert.Equal(t, "--- a/Generic/a\n+++ b/Generic/a\n@@ -0,0 +1,2 @@\n+x\n+y\n", resourceDiff(r, "", "x\ny"))
+	assert.Equal(t, "--- a/Generic/a\n+++ b/Generic/a\n@@ -1 +0,0 @@\n-x\n", resourceDiff(r, "x\n", ""))
+	assert.Equal(t, "", resourceDiff(r, "x\n", "x\n"))
+}
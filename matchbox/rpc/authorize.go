@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+)
+
+// streamRoles are the roles required to call streaming methods. Other
+// streaming methods require RoleAdmin.
+var streamRoles = map[string]Role{
+	"/rpcpb.Archive/Export": RoleReadOnly,
+	"/rpcpb.Archive/Import": RoleAdmin,
+}
+
+// authorizeUnaryInterceptor denies calls by clients whose role, granted by
+// the Policy, doesn't allow the request with PermissionDenied.
+func authorizeUnaryInterceptor(policy *Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, policy, info.FullMethod, requiredRole(req)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authorizeStreamInterceptor denies streaming calls by clients whose role,
+// granted by the Policy, doesn't allow the method with PermissionDenied.
+func authorizeStreamInterceptor(policy *Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		required, ok := streamRoles[info.FullMethod]
+		if !ok {
+			required = RoleAdmin
+		}
+		if err := authorize(stream.Context(), policy, info.FullMethod, required); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// authorize returns a PermissionDenied error if the role granted to the
+// client of the ctx doesn't allow the required role.
+func authorize(ctx context.Context, policy *Policy, method string, required Role) error {
+	role := policy.Role(clientCertificate(ctx))
+	if role.allows(required) {
+		return nil
+	}
+	if role == RoleNone {
+		return grpcErrorf(codes.PermissionDenied, "matchbox: client %q is not permitted to call %s", clientSubject(ctx), method)
+	}
+	return grpcErrorf(codes.PermissionDenied, "matchbox: client %q has role %s, but %s requires role %s", clientSubject(ctx), role, method, required)
+}
+
+// requiredRole returns the role required to make a request. Writes which
+// only change templates require RoleTemplatesOnly, other writes require
+// RoleAdmin, and reads require RoleReadOnly. Unknown requests require
+// RoleAdmin.
+func requiredRole(req interface{}) Role {
+	if resources, ok := writtenResources(req); ok {
+		for _, r := range resources {
+			if !isTemplate(r.kind) {
+				return RoleAdmin
+			}
+		}
+		return RoleTemplatesOnly
+	}
+	switch req.(type) {
+	case *pb.GroupGetRequest, *pb.GroupListRequest,
+		*pb.ProfileGetRequest, *pb.ProfileListRequest,
+		*pb.IgnitionGetRequest, *pb.IgnitionListRequest,
+		*pb.GenericGetRequest, *pb.GenericListRequest,
+		*pb.CloudGetRequest, *pb.CloudListRequest,
+		*pb.PartialGetRequest, *pb.PartialListRequest,
+		*pb.SelectGroupRequest, *pb.SelectProfileRequest, *pb.SelectExplainRequest,
+		*pb.InstanceGetRequest, *pb.InstanceListRequest,
+		*pb.StatusGetRequest, *pb.HistoryListRequest, *pb.AuditListRequest:
+		return RoleReadOnly
+	}
+	return RoleAdmin
+}
+
+// isTemplate returns true if the kind is a kind of template.
+func isTemplate(kind string) bool {
+	switch kind {
+	case storage.KindIgnition, storage.KindGeneric, storage.KindCloud, storage.KindPartial:
+		return true
+	}
+	return false
+}
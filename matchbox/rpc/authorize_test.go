@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+func TestRequiredRole(t *testing.T) {
+	cases := []struct {
+		req  interface{}
+		role Role
+	}{
+		{&pb.GroupListRequest{}, RoleReadOnly},
+		{&pb.SelectExplainRequest{}, RoleReadOnly},
+		{&pb.AuditListRequest{}, RoleReadOnly},
+		{&pb.IgnitionPutRequest{Name: "a.yaml"}, RoleTemplatesOnly},
+		{&pb.IgnitionPutRequest{Name: "a.yaml", DryRun: true}, RoleTemplatesOnly},
+		{&pb.PartialDeleteRequest{Name: "a"}, RoleTemplatesOnly},
+		{&pb.GroupDeleteRequest{Id: "a"}, RoleAdmin},
+		{&pb.ProfilePutRequest{}, RoleAdmin},
+		{&pb.HistoryRollbackRequest{Kind: storage.KindIgnition, Name: "a.yaml"}, RoleTemplatesOnly},
+		{&pb.HistoryRollbackRequest{Kind: storage.KindGroup, Name: "a"}, RoleAdmin},
+		{&pb.BatchApplyRequest{Batch: &storagepb.Batch{Generic: []*storagepb.Template{{Name: "a"}}}}, RoleTemplatesOnly},
+		{&pb.BatchApplyRequest{Batch: &storagepb.Batch{Deletes: []*storagepb.Deletion{{Kind: storage.KindGroup, Name: "a"}}}}, RoleAdmin},
+		// unknown requests require the admin role
+		{&pb.InstanceRecordRequest{}, RoleAdmin},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.role, requiredRole(c.req))
+	}
+}
+
+func TestAuthorizeUnaryInterceptor(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`{"bindings": [{"role": "templates-only", "organizational_units": ["ci"]}]}`))
+	assert.Nil(t, err)
+	interceptor := authorizeUnaryInterceptor(policy)
+	ci := &x509.Certificate{Subject: pkix.Name{CommonName: "runner", OrganizationalUnit: []string{"ci"}}}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{ci}}},
+	}})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "handled", nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/rpcpb.Groups/GroupDelete"}
+
+	// assert that:
+	// - permitted calls are handled
+	// - denied calls fail with PermissionDenied
+	resp, err := interceptor(ctx, &pb.IgnitionPutRequest{Name: "a.yaml"}, info, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "handled", resp)
+	_, err = interceptor(ctx, &pb.GroupDeleteRequest{Id: "a"}, info, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = interceptor(context.Background(), &pb.GroupListRequest{}, info, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,25 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/poseidon/matchbox/matchbox/rpc/rpcpb"
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// batchServer takes a matchbox Server and implements a gRPC BatchServer.
+type batchServer struct {
+	srv server.Server
+}
+
+func newBatchServer(s server.Server) rpcpb.BatchServer {
+	return &batchServer{
+		srv: s,
+	}
+}
+
+func (s *batchServer) Apply(ctx context.Context, req *pb.BatchApplyRequest) (*pb.BatchApplyResponse, error) {
+	err := s.srv.Apply(ctx, req)
+	return &pb.BatchApplyResponse{}, grpcError(err)
+}
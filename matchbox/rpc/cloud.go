@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/poseidon/matchbox/matchbox/rpc/rpcpb"
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+)
+
+// cloudServer takes a matchbox Server and implements a gRPC CloudServer.
+type cloudServer struct {
+	srv server.Server
+}
+
+func newCloudServer(s server.Server) rpcpb.CloudServer {
+	return &cloudServer{
+		srv: s,
+	}
+}
+
+func (s *cloudServer) CloudPut(ctx context.Context, req *pb.CloudPutRequest) (*pb.CloudPutResponse, error) {
+	template, err := s.srv.CloudPut(ctx, req)
+	return &pb.CloudPutResponse{ResourceVersion: storage.Version([]byte(template))}, grpcError(err)
+}
+
+func (s *cloudServer) CloudGet(ctx context.Context, req *pb.CloudGetRequest) (*pb.CloudGetResponse, error) {
+	template, err := s.srv.CloudGet(ctx, req)
+	return &pb.CloudGetResponse{Config: []byte(template), ResourceVersion: storage.Version([]byte(template))}, grpcError(err)
+}
+
+func (s *cloudServer) CloudDelete(ctx context.Context, req *pb.CloudDeleteRequest) (*pb.CloudDeleteResponse, error) {
+	err := s.srv.CloudDelete(ctx, req)
+	return &pb.CloudDeleteResponse{}, grpcError(err)
+}
+
+func (s *cloudServer) CloudList(ctx context.Context, req *pb.CloudListRequest) (*pb.CloudListResponse, error) {
+	templates, err := s.srv.CloudList(ctx, req)
+	return &pb.CloudListResponse{Templates: templates}, grpcError(err)
+}
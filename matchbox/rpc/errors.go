@@ -5,6 +5,8 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/poseidon/matchbox/matchbox/server"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
 var (
@@ -12,6 +14,7 @@ var (
 	grpcErrorf           = grpc.Errorf
 	errNoMatchingGroup   = grpcErrorf(codes.NotFound, "matchbox: No matching Group")
 	errNoMatchingProfile = grpcErrorf(codes.NotFound, "matchbox: No matching Profile")
+	errNoInstance        = grpcErrorf(codes.NotFound, "matchbox: No Instance found")
 )
 
 // grpcError transforms an error into a gRPC errors with canonical error codes.
@@ -19,11 +22,31 @@ func grpcError(err error) error {
 	if err == nil {
 		return err
 	}
+	switch err.(type) {
+	case *server.InvalidTemplateError:
+		return grpcErrorf(codes.InvalidArgument, err.Error())
+	case *server.ReferenceError:
+		return grpcErrorf(codes.FailedPrecondition, err.Error())
+	case *server.InvalidResourcesError:
+		return grpcErrorf(codes.FailedPrecondition, err.Error())
+	case *storage.InvalidBatchError:
+		return grpcErrorf(codes.InvalidArgument, err.Error())
+	}
 	switch err {
 	case server.ErrNoMatchingGroup:
 		return errNoMatchingGroup
 	case server.ErrNoMatchingProfile:
 		return errNoMatchingProfile
+	case storage.ErrInstanceNotFound:
+		return errNoInstance
+	case storage.ErrRevisionNotFound:
+		return grpcErrorf(codes.NotFound, err.Error())
+	case storagepb.ErrProfileCycle:
+		return grpcErrorf(codes.FailedPrecondition, err.Error())
+	case storage.ErrUnknownKind:
+		return grpcErrorf(codes.InvalidArgument, err.Error())
+	case storage.ErrVersionConflict:
+		return grpcErrorf(codes.Aborted, err.Error())
 	default:
 		return grpcErrorf(codes.Unknown, err.Error())
 	}
@@ -8,6 +8,8 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/poseidon/matchbox/matchbox/server"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
 func TestGRPCError(t *testing.T) {
@@ -18,6 +20,15 @@ func TestGRPCError(t *testing.T) {
 		{nil, nil},
 		{server.ErrNoMatchingGroup, errNoMatchingGroup},
 		{server.ErrNoMatchingProfile, errNoMatchingProfile},
+		{storage.ErrInstanceNotFound, errNoInstance},
+		{storage.ErrVersionConflict, grpcErrorf(codes.Aborted, "storage: resource version conflict")},
+		{storage.ErrRevisionNotFound, grpcErrorf(codes.NotFound, "storage: No revision found")},
+		{storage.ErrUnknownKind, grpcErrorf(codes.InvalidArgument, "storage: unknown resource kind")},
+		{storagepb.ErrProfileCycle, grpcErrorf(codes.FailedPrecondition, "Profile inherits from itself")},
+		{&server.InvalidTemplateError{Name: "a.yaml", Err: errors.New("bad")}, grpcErrorf(codes.InvalidArgument, "matchbox: invalid template a.yaml: bad")},
+		{&server.ReferenceError{Message: "a is still referenced"}, grpcErrorf(codes.FailedPrecondition, "matchbox: a is still referenced")},
+		{&server.InvalidResourcesError{Resources: []*storagepb.InvalidResource{{Kind: "Group", Id: "a", Error: "bad"}}}, grpcErrorf(codes.FailedPrecondition, "matchbox: stored resources can't be parsed: Group a (bad)")},
+		{&storage.InvalidBatchError{Reason: "bad"}, grpcErrorf(codes.InvalidArgument, "storage: invalid batch: bad")},
 		{errors.New("other error"), grpcErrorf(codes.Unknown, "other error")},
 	}
 	for _, c := range cases {
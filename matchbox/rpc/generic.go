@@ -6,6 +6,7 @@ import (
 	"github.com/poseidon/matchbox/matchbox/rpc/rpcpb"
 	"github.com/poseidon/matchbox/matchbox/server"
 	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
 )
 
 // genericServer takes a matchbox Server and implements a gRPC GenericServer.
@@ -20,16 +21,21 @@ func newGenericServer(s server.Server) rpcpb.GenericServer {
 }
 
 func (s *genericServer) GenericPut(ctx context.Context, req *pb.GenericPutRequest) (*pb.GenericPutResponse, error) {
-	_, err := s.srv.GenericPut(ctx, req)
-	return &pb.GenericPutResponse{}, grpcError(err)
+	template, err := s.srv.GenericPut(ctx, req)
+	return &pb.GenericPutResponse{ResourceVersion: storage.Version([]byte(template))}, grpcError(err)
 }
 
 func (s *genericServer) GenericGet(ctx context.Context, req *pb.GenericGetRequest) (*pb.GenericGetResponse, error) {
 	template, err := s.srv.GenericGet(ctx, req)
-	return &pb.GenericGetResponse{Config: []byte(template)}, grpcError(err)
+	return &pb.GenericGetResponse{Config: []byte(template), ResourceVersion: storage.Version([]byte(template))}, grpcError(err)
 }
 
 func (s *genericServer) GenericDelete(ctx context.Context, req *pb.GenericDeleteRequest) (*pb.GenericDeleteResponse, error) {
 	err := s.srv.GenericDelete(ctx, req)
 	return &pb.GenericDeleteResponse{}, grpcError(err)
 }
+
+func (s *genericServer) GenericList(ctx context.Context, req *pb.GenericListRequest) (*pb.GenericListResponse, error) {
+	templates, err := s.srv.GenericList(ctx, req)
+	return &pb.GenericListResponse{Templates: templates}, grpcError(err)
+}
@@ -20,8 +20,8 @@ func newGroupServer(s server.Server) rpcpb.GroupsServer {
 }
 
 func (s *groupServer) GroupPut(ctx context.Context, req *pb.GroupPutRequest) (*pb.GroupPutResponse, error) {
-	_, err := s.srv.GroupPut(ctx, req)
-	return &pb.GroupPutResponse{}, grpcError(err)
+	group, err := s.srv.GroupPut(ctx, req)
+	return &pb.GroupPutResponse{ResourceVersion: group.GetResourceVersion()}, grpcError(err)
 }
 
 func (s *groupServer) GroupGet(ctx context.Context, req *pb.GroupGetRequest) (*pb.GroupGetResponse, error) {
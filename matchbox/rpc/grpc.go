@@ -10,9 +10,21 @@ import (
 	"github.com/poseidon/matchbox/matchbox/server"
 )
 
-// NewServer wraps the matchbox Server to return a new gRPC Server.
-func NewServer(s server.Server, tls *tls.Config) *grpc.Server {
-	var opts []grpc.ServerOption
+// NewServer wraps the matchbox Server to return a new gRPC Server. If a
+// Policy is given, calls are only permitted by clients with a sufficient
+// role. Otherwise, all clients may call all methods.
+func NewServer(s server.Server, tls *tls.Config, policy *Policy) *grpc.Server {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(metricsUnaryInterceptor, authorUnaryInterceptor, auditUnaryInterceptor(s)),
+		grpc.ChainStreamInterceptor(metricsStreamInterceptor, authorStreamInterceptor, auditStreamInterceptor(s)),
+	}
+	if policy != nil {
+		// authorize after auditing, so denied calls are audited
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(authorizeUnaryInterceptor(policy)),
+			grpc.ChainStreamInterceptor(authorizeStreamInterceptor(policy)),
+		)
+	}
 	if tls != nil {
 		// Add TLS Credentials as a ServerOption for server connections.
 		opts = append(opts, grpc.Creds(credentials.NewTLS(tls)))
@@ -24,5 +36,13 @@ func NewServer(s server.Server, tls *tls.Config) *grpc.Server {
 	rpcpb.RegisterSelectServer(grpcServer, newSelectServer(s))
 	rpcpb.RegisterIgnitionServer(grpcServer, newIgnitionServer(s))
 	rpcpb.RegisterGenericServer(grpcServer, newGenericServer(s))
+	rpcpb.RegisterCloudServer(grpcServer, newCloudServer(s))
+	rpcpb.RegisterPartialsServer(grpcServer, newPartialServer(s))
+	rpcpb.RegisterInstancesServer(grpcServer, newInstanceServer(s))
+	rpcpb.RegisterStatusServer(grpcServer, newStatusServer(s))
+	rpcpb.RegisterBatchServer(grpcServer, newBatchServer(s))
+	rpcpb.RegisterArchiveServer(grpcServer, newArchiveServer(s))
+	rpcpb.RegisterHistoryServer(grpcServer, newHistoryServer(s))
+	rpcpb.RegisterAuditServer(grpcServer, newAuditServer(s))
 	return grpcServer
 }
@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/poseidon/matchbox/matchbox/rpc/rpcpb"
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// historyServer takes a matchbox Server and implements a gRPC HistoryServer.
+type historyServer struct {
+	srv server.Server
+}
+
+func newHistoryServer(s server.Server) rpcpb.HistoryServer {
+	return &historyServer{
+		srv: s,
+	}
+}
+
+func (s *historyServer) List(ctx context.Context, req *pb.HistoryListRequest) (*pb.HistoryListResponse, error) {
+	revisions, err := s.srv.HistoryList(ctx, req)
+	return &pb.HistoryListResponse{Revisions: revisions}, grpcError(err)
+}
+
+func (s *historyServer) Rollback(ctx context.Context, req *pb.HistoryRollbackRequest) (*pb.HistoryRollbackResponse, error) {
+	err := s.srv.Rollback(ctx, req)
+	return &pb.HistoryRollbackResponse{}, grpcError(err)
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"crypto/x509"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/poseidon/matchbox/matchbox/server"
+)
+
+// clientCertificate returns the client certificate the peer of a request
+// authenticated with, or nil for unauthenticated peers. Only verified
+// certificates are returned, since peers may present any certificate when the
+// server doesn't require client certificates.
+func clientCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+	if chains := info.State.VerifiedChains; len(chains) > 0 && len(chains[0]) > 0 {
+		return chains[0][0]
+	}
+	return nil
+}
+
+// clientName returns the common name of the client certificate of a request,
+// or an empty string for unauthenticated peers.
+func clientName(ctx context.Context) string {
+	if cert := clientCertificate(ctx); cert != nil {
+		return cert.Subject.CommonName
+	}
+	return ""
+}
+
+// clientSubject returns the subject of the client certificate of a request
+// (e.g. CN=ci,O=example), or an empty string for unauthenticated peers.
+func clientSubject(ctx context.Context) string {
+	if cert := clientCertificate(ctx); cert != nil {
+		return cert.Subject.String()
+	}
+	return ""
+}
+
+// authorUnaryInterceptor identifies the client making a request as the author
+// of any revisions it writes.
+func authorUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(server.WithAuthor(ctx, clientName(ctx)), req)
+}
+
+// authorStreamInterceptor identifies the client making a streaming request as
+// the author of any revisions it writes.
+func authorStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := stream.Context()
+	return handler(srv, &contextStream{ServerStream: stream, ctx: server.WithAuthor(ctx, clientName(ctx))})
+}
+
+// contextStream is a ServerStream with a replaced context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
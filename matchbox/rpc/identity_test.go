@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientName(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "ci"}}
+	cases := []struct {
+		ctx  context.Context
+		name string
+	}{
+		// unauthenticated peers
+		{context.Background(), ""},
+		{peer.NewContext(context.Background(), &peer.Peer{}), ""},
+		{peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}}), ""},
+		// peers with a verified client certificate
+		{peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+		}}), "ci"},
+		// peers with an unverified client certificate
+		{peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+		}}), ""},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.name, clientName(c.ctx))
+	}
+}
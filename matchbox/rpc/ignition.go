@@ -6,6 +6,7 @@ import (
 	"github.com/poseidon/matchbox/matchbox/rpc/rpcpb"
 	"github.com/poseidon/matchbox/matchbox/server"
 	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
 )
 
 // ignitionServer takes a matchbox Server and implements a gRPC IgnitionServer.
@@ -20,16 +21,21 @@ func newIgnitionServer(s server.Server) rpcpb.IgnitionServer {
 }
 
 func (s *ignitionServer) IgnitionPut(ctx context.Context, req *pb.IgnitionPutRequest) (*pb.IgnitionPutResponse, error) {
-	_, err := s.srv.IgnitionPut(ctx, req)
-	return &pb.IgnitionPutResponse{}, grpcError(err)
+	template, err := s.srv.IgnitionPut(ctx, req)
+	return &pb.IgnitionPutResponse{ResourceVersion: storage.Version([]byte(template))}, grpcError(err)
 }
 
 func (s *ignitionServer) IgnitionGet(ctx context.Context, req *pb.IgnitionGetRequest) (*pb.IgnitionGetResponse, error) {
 	template, err := s.srv.IgnitionGet(ctx, req)
-	return &pb.IgnitionGetResponse{Config: []byte(template)}, grpcError(err)
+	return &pb.IgnitionGetResponse{Config: []byte(template), ResourceVersion: storage.Version([]byte(template))}, grpcError(err)
 }
 
 func (s *ignitionServer) IgnitionDelete(ctx context.Context, req *pb.IgnitionDeleteRequest) (*pb.IgnitionDeleteResponse, error) {
 	err := s.srv.IgnitionDelete(ctx, req)
 	return &pb.IgnitionDeleteResponse{}, grpcError(err)
 }
+
+func (s *ignitionServer) IgnitionList(ctx context.Context, req *pb.IgnitionListRequest) (*pb.IgnitionListResponse, error) {
+	templates, err := s.srv.IgnitionList(ctx, req)
+	return &pb.IgnitionListResponse{Templates: templates}, grpcError(err)
+}
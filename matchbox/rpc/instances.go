@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/poseidon/matchbox/matchbox/rpc/rpcpb"
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// instanceServer takes a matchbox Server and implements a gRPC InstancesServer.
+type instanceServer struct {
+	srv server.Server
+}
+
+func newInstanceServer(s server.Server) rpcpb.InstancesServer {
+	return &instanceServer{
+		srv: s,
+	}
+}
+
+func (s *instanceServer) InstanceGet(ctx context.Context, req *pb.InstanceGetRequest) (*pb.InstanceGetResponse, error) {
+	instance, err := s.srv.InstanceGet(ctx, req)
+	return &pb.InstanceGetResponse{Instance: instance}, grpcError(err)
+}
+
+func (s *instanceServer) InstanceList(ctx context.Context, req *pb.InstanceListRequest) (*pb.InstanceListResponse, error) {
+	instances, err := s.srv.InstanceList(ctx, req)
+	return &pb.InstanceListResponse{Instances: instances}, grpcError(err)
+}
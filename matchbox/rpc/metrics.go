@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "matchbox",
+	Subsystem: "grpc",
+	Name:      "request_duration_seconds",
+	Help:      "Latency of gRPC calls by method and status code.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "code"})
+
+func init() {
+	prometheus.MustRegister(requestDuration)
+}
+
+// observe records the latency of a call which started at the given time and
+// returned err.
+func observe(method string, start time.Time, err error) {
+	requestDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+}
+
+// metricsUnaryInterceptor records the latency of each call.
+func metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observe(info.FullMethod, start, err)
+	return resp, err
+}
+
+// metricsStreamInterceptor records the latency of each streaming call.
+func metricsStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	observe(info.FullMethod, start, err)
+	return err
+}
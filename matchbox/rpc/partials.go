@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/poseidon/matchbox/matchbox/rpc/rpcpb"
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+)
+
+// partialServer takes a matchbox Server and implements a gRPC PartialsServer.
+type partialServer struct {
+	srv server.Server
+}
+
+func newPartialServer(s server.Server) rpcpb.PartialsServer {
+	return &partialServer{
+		srv: s,
+	}
+}
+
+func (s *partialServer) PartialPut(ctx context.Context, req *pb.PartialPutRequest) (*pb.PartialPutResponse, error) {
+	template, err := s.srv.PartialPut(ctx, req)
+	return &pb.PartialPutResponse{ResourceVersion: storage.Version([]byte(template))}, grpcError(err)
+}
+
+func (s *partialServer) PartialGet(ctx context.Context, req *pb.PartialGetRequest) (*pb.PartialGetResponse, error) {
+	template, err := s.srv.PartialGet(ctx, req)
+	return &pb.PartialGetResponse{Config: []byte(template), ResourceVersion: storage.Version([]byte(template))}, grpcError(err)
+}
+
+func (s *partialServer) PartialDelete(ctx context.Context, req *pb.PartialDeleteRequest) (*pb.PartialDeleteResponse, error) {
+	err := s.srv.PartialDelete(ctx, req)
+	return &pb.PartialDeleteResponse{}, grpcError(err)
+}
+
+func (s *partialServer) PartialList(ctx context.Context, req *pb.PartialListRequest) (*pb.PartialListResponse, error) {
+	names, err := s.srv.PartialList(ctx, req)
+	return &pb.PartialListResponse{Names: names}, grpcError(err)
+}
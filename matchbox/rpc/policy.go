@@ -0,0 +1,158 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// Role is a set of gRPC methods a client may call.
+type Role string
+
+// Roles, from least to most privileged
+const (
+	// RoleNone may not call any methods
+	RoleNone Role = ""
+	// RoleReadOnly may call methods which don't change resources
+	RoleReadOnly Role = "read-only"
+	// RoleTemplatesOnly may also write and delete templates
+	RoleTemplatesOnly Role = "templates-only"
+	// RoleAdmin may call all methods
+	RoleAdmin Role = "admin"
+)
+
+// roleRanks orders roles by privilege.
+var roleRanks = map[Role]int{
+	RoleNone:          0,
+	RoleReadOnly:      1,
+	RoleTemplatesOnly: 2,
+	RoleAdmin:         3,
+}
+
+// allows returns true if the role grants the required role.
+func (r Role) allows(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+// Policy grants roles to gRPC clients by the identity of their client
+// certificates.
+type Policy struct {
+	// Bindings grant roles to clients with matching certificates
+	Bindings []*Binding `json:"bindings"`
+	// DefaultRole is granted to clients which match no Binding (defaults to
+	// no access)
+	DefaultRole Role `json:"default_role,omitempty"`
+}
+
+// Binding grants a role to clients whose certificate has any of the given
+// common names, organizational units, or subject alternative names.
+type Binding struct {
+	Role                Role     `json:"role"`
+	CommonNames         []string `json:"common_names,omitempty"`
+	OrganizationalUnits []string `json:"organizational_units,omitempty"`
+	// SANs are DNS names, email addresses, IP addresses, or URIs
+	SANs []string `json:"sans,omitempty"`
+}
+
+// ParsePolicy parses and validates a JSON Policy.
+func ParsePolicy(data []byte) (*Policy, error) {
+	policy := new(Policy)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("rpc: invalid policy: %v", err)
+	}
+	if err := policy.AssertValid(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// LoadPolicy reads and parses a JSON Policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePolicy(data)
+}
+
+// AssertValid validates the Policy. Returns nil if there are no validation
+// errors.
+func (p *Policy) AssertValid() error {
+	if _, ok := roleRanks[p.DefaultRole]; !ok {
+		return fmt.Errorf("rpc: invalid policy: unknown default role %q", p.DefaultRole)
+	}
+	for i, binding := range p.Bindings {
+		if _, ok := roleRanks[binding.Role]; !ok || binding.Role == RoleNone {
+			return fmt.Errorf("rpc: invalid policy: binding %d has unknown role %q", i, binding.Role)
+		}
+		if len(binding.CommonNames)+len(binding.OrganizationalUnits)+len(binding.SANs) == 0 {
+			return fmt.Errorf("rpc: invalid policy: binding %d matches no certificates", i)
+		}
+	}
+	return nil
+}
+
+// Role returns the most privileged role granted to a client certificate, or
+// the default role if no Binding matches. A nil certificate matches no
+// Binding.
+func (p *Policy) Role(cert *x509.Certificate) Role {
+	role := p.DefaultRole
+	if cert == nil {
+		return role
+	}
+	for _, binding := range p.Bindings {
+		if binding.matches(cert) && !role.allows(binding.Role) {
+			role = binding.Role
+		}
+	}
+	return role
+}
+
+// matches returns true if the certificate has any identity of the Binding.
+func (b *Binding) matches(cert *x509.Certificate) bool {
+	if contains(b.CommonNames, cert.Subject.CommonName) {
+		return true
+	}
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if contains(b.OrganizationalUnits, ou) {
+			return true
+		}
+	}
+	for _, san := range subjectAltNames(cert) {
+		if contains(b.SANs, san) {
+			return true
+		}
+	}
+	return false
+}
+
+// subjectAltNames returns the subject alternative names of a certificate as
+// strings.
+func subjectAltNames(cert *x509.Certificate) []string {
+	sans := append([]string{}, cert.DNSNames...)
+	sans = append(sans, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
+
+// contains returns true if the values contain the value.
+func contains(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
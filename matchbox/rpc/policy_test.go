@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `{
+  "bindings": [
+    {"role": "admin", "common_names": ["ops"]},
+    {"role": "templates-only", "organizational_units": ["ci"]},
+    {"role": "read-only", "sans": ["monitor.example.com", "10.0.0.2"]},
+    {"role": "admin", "sans": ["spiffe://example.com/admin"]}
+  ],
+  "default_role": "read-only"
+}`
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	assert.Nil(t, err)
+	assert.Len(t, policy.Bindings, 4)
+	assert.Equal(t, RoleReadOnly, policy.DefaultRole)
+
+	cases := []string{
+		`{"bindings": [{"role": "owner", "common_names": ["a"]}]}`,
+		`{"bindings": [{"role": "admin"}]}`,
+		`{"bindings": [{"role": "", "common_names": ["a"]}]}`,
+		`{"default_role": "root"}`,
+		`{"bindings": [{"role": "admin", "cn": ["a"]}]}`,
+		`{`,
+	}
+	for _, c := range cases {
+		// assert that:
+		// - unknown roles, bindings which match nothing, and unknown fields are rejected
+		_, err := ParsePolicy([]byte(c))
+		assert.NotNil(t, err, c)
+	}
+}
+
+func TestPolicyRole(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	assert.Nil(t, err)
+	spiffe, err := url.Parse("spiffe://example.com/admin")
+	assert.Nil(t, err)
+	cases := []struct {
+		cert *x509.Certificate
+		role Role
+	}{
+		{&x509.Certificate{Subject: pkix.Name{CommonName: "ops"}}, RoleAdmin},
+		{&x509.Certificate{Subject: pkix.Name{CommonName: "runner", OrganizationalUnit: []string{"eng", "ci"}}}, RoleTemplatesOnly},
+		{&x509.Certificate{DNSNames: []string{"monitor.example.com"}}, RoleReadOnly},
+		{&x509.Certificate{IPAddresses: []net.IP{net.ParseIP("10.0.0.2")}}, RoleReadOnly},
+		{&x509.Certificate{URIs: []*url.URL{spiffe}}, RoleAdmin},
+		// the most privileged matching role is granted
+		{&x509.Certificate{Subject: pkix.Name{CommonName: "ops", OrganizationalUnit: []string{"ci"}}}, RoleAdmin},
+		{&x509.Certificate{Subject: pkix.Name{CommonName: "other"}}, RoleReadOnly},
+		{nil, RoleReadOnly},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.role, policy.Role(c.cert))
+	}
+	// assert that:
+	// - clients matching no binding have no access by default
+	assert.Equal(t, RoleNone, (&Policy{}).Role(&x509.Certificate{}))
+}
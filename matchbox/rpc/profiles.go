@@ -20,9 +20,9 @@ func newProfileServer(s server.Server) rpcpb.ProfilesServer {
 }
 
 func (s *profileServer) ProfilePut(ctx context.Context, req *pb.ProfilePutRequest) (*pb.ProfilePutResponse, error) {
-	_, err := s.srv.ProfilePut(ctx, req)
+	profile, err := s.srv.ProfilePut(ctx, req)
 	// TODO(dghubble): Decide on create/put and response(s).
-	return &pb.ProfilePutResponse{}, grpcError(err)
+	return &pb.ProfilePutResponse{ResourceVersion: profile.GetResourceVersion()}, grpcError(err)
 }
 
 func (s *profileServer) ProfileGet(ctx context.Context, req *pb.ProfileGetRequest) (*pb.ProfileGetResponse, error) {
@@ -28,34 +28,55 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 func init() { proto.RegisterFile("matchbox/rpc/rpcpb/rpc.proto", fileDescriptor_16cc910f0e1e5aa8) }
 
 var fileDescriptor_16cc910f0e1e5aa8 = []byte{
-	// 430 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xc1, 0x4e, 0xe3, 0x30,
-	0x10, 0x86, 0xb7, 0x95, 0xb6, 0xdb, 0xf5, 0x6a, 0x39, 0xe4, 0x46, 0x49, 0x29, 0xe2, 0xc2, 0x2d,
-	0x11, 0xe5, 0x0d, 0x00, 0x11, 0x55, 0xea, 0xa1, 0x2a, 0xe2, 0xc2, 0xad, 0x09, 0x43, 0x1b, 0xa9,
-	0x8d, 0x43, 0xec, 0x20, 0x1e, 0x09, 0xf1, 0x18, 0xbc, 0x12, 0x67, 0x24, 0x64, 0xc7, 0x76, 0x26,
-	0xb1, 0x7d, 0x68, 0x6b, 0xfd, 0x9f, 0xfd, 0x77, 0xf2, 0xcf, 0xc4, 0x24, 0x3c, 0x6c, 0x78, 0xb6,
-	0x4b, 0xe9, 0x5b, 0x5c, 0x95, 0x99, 0xf8, 0x94, 0xa9, 0xf8, 0x8e, 0xca, 0x8a, 0x72, 0x1a, 0xfc,
-	0x96, 0xc2, 0xe4, 0xc2, 0x6c, 0x62, 0x50, 0xbd, 0x42, 0xa5, 0x7e, 0xca, 0x34, 0x3e, 0x00, 0x63,
-	0x9b, 0x2d, 0xb0, 0x66, 0xff, 0xfc, 0x7d, 0x48, 0x46, 0x49, 0x45, 0xeb, 0x92, 0x05, 0x37, 0x64,
-	0x2c, 0x57, 0xab, 0x9a, 0x07, 0xc7, 0x91, 0x3e, 0x10, 0x69, 0x6d, 0x0d, 0x2f, 0x35, 0x30, 0x3e,
-	0x99, 0xb8, 0x10, 0x2b, 0x69, 0xc1, 0xe0, 0xfc, 0x97, 0x31, 0x49, 0xc0, 0x36, 0x49, 0xc0, 0x6b,
-	0x22, 0x91, 0x31, 0x59, 0x92, 0x7f, 0x52, 0xbd, 0x85, 0x3d, 0x70, 0x08, 0xc2, 0xde, 0xe6, 0x46,
-	0xd6, 0x56, 0x53, 0x0f, 0x35, 0x6e, 0x77, 0xe4, 0xaf, 0x04, 0xcb, 0x9c, 0xf1, 0xa0, 0xff, 0xc7,
-	0x42, 0xd4, 0x4e, 0x27, 0x4e, 0xa6, 0x7d, 0xe6, 0x9f, 0x43, 0x32, 0x5e, 0x55, 0xf4, 0x39, 0xdf,
-	0x03, 0x0b, 0x16, 0x84, 0xa8, 0xb5, 0x88, 0x0b, 0x9d, 0x6c, 0x55, 0x6d, 0x1b, 0xba, 0xa1, 0xa9,
-	0xaf, 0xb5, 0x12, 0xa1, 0xd9, 0x56, 0x28, 0xb6, 0xd0, 0x0d, 0x8d, 0xd5, 0x9a, 0xfc, 0x57, 0xba,
-	0x8a, 0xee, 0xd4, 0x3a, 0xd0, 0x0d, 0x6f, 0xe6, 0xe5, 0xb8, 0x19, 0x0a, 0xc9, 0x00, 0xed, 0x12,
-	0x70, 0x84, 0x53, 0x0f, 0x35, 0x21, 0x7e, 0x0f, 0xc8, 0x78, 0xb1, 0x2d, 0x72, 0x9e, 0xd3, 0x42,
-	0x58, 0xeb, 0xb5, 0x48, 0x11, 0x59, 0x23, 0xd9, 0x61, 0xdd, 0xa1, 0xb8, 0x50, 0x0d, 0x44, 0x90,
-	0x0e, 0x37, 0x94, 0xe4, 0xd4, 0x43, 0x8d, 0xdb, 0x03, 0x39, 0xd2, 0x40, 0x65, 0x39, 0xb3, 0x8f,
-	0x74, 0xc3, 0x3c, 0xf3, 0x6f, 0x30, 0xcf, 0xff, 0x35, 0x20, 0x7f, 0x12, 0x28, 0xa0, 0xca, 0x33,
-	0xd1, 0x78, 0xb5, 0xec, 0xcd, 0x50, 0xab, 0x3a, 0x1a, 0x8f, 0x21, 0x9e, 0x21, 0xa5, 0xf7, 0x66,
-	0xa8, 0x55, 0xfd, 0x56, 0xd6, 0x0c, 0x29, 0xdd, 0x9e, 0xa1, 0x0e, 0x70, 0xcc, 0x50, 0x8f, 0x9b,
-	0xa7, 0xfe, 0x18, 0x90, 0xd1, 0x3d, 0xec, 0x21, 0xe3, 0xa2, 0x4b, 0xcd, 0x4a, 0xbe, 0x62, 0xb8,
-	0x4b, 0x48, 0x76, 0x74, 0xa9, 0x43, 0x71, 0xb1, 0x0d, 0x50, 0xd3, 0x86, 0x8b, 0xed, 0x00, 0x47,
-	0xb1, 0x3d, 0xae, 0x3d, 0xaf, 0x2f, 0x1f, 0xe3, 0x6d, 0xce, 0x77, 0x75, 0x1a, 0x65, 0xf4, 0x10,
-	0x97, 0x94, 0x41, 0xfe, 0x44, 0x8b, 0xd8, 0xdc, 0xa8, 0xf6, 0xfd, 0x9b, 0x8e, 0xe4, 0x65, 0x7a,
-	0xf5, 0x13, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x95, 0x02, 0xe5, 0x9c, 0x05, 0x00, 0x00,
+	// 762 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x96, 0xcb, 0x72, 0xd3, 0x30,
+	0x14, 0x86, 0x49, 0x86, 0x5c, 0x2a, 0x2e, 0x0b, 0xef, 0x28, 0xbd, 0xc1, 0x06, 0x56, 0x09, 0x94,
+	0x27, 0x48, 0x2f, 0x98, 0x30, 0xed, 0x10, 0xda, 0x61, 0xc3, 0xce, 0x71, 0x0f, 0xad, 0x07, 0xc7,
+	0x32, 0x96, 0xdc, 0x49, 0x9f, 0x87, 0x05, 0x0c, 0x6f, 0x01, 0x0f, 0xc0, 0xf3, 0xb0, 0x64, 0x2c,
+	0x4b, 0xf2, 0xd1, 0x2d, 0x8b, 0xb6, 0xea, 0xff, 0xc9, 0x7f, 0xa4, 0xa3, 0xa3, 0x3f, 0x26, 0x3b,
+	0xab, 0x84, 0xa7, 0x37, 0x4b, 0xba, 0x9e, 0x56, 0x65, 0xda, 0xfc, 0x94, 0xcb, 0xe6, 0xf7, 0xa4,
+	0xac, 0x28, 0xa7, 0xd1, 0x40, 0x08, 0xdb, 0x2f, 0xf4, 0x24, 0x06, 0xd5, 0x2d, 0x54, 0xf2, 0x4f,
+	0xb9, 0x9c, 0xae, 0x80, 0xb1, 0xe4, 0x1a, 0x58, 0x3b, 0xff, 0xf0, 0x67, 0x9f, 0x0c, 0xe3, 0x8a,
+	0xd6, 0x25, 0x8b, 0x8e, 0xc9, 0x58, 0x8c, 0x16, 0x35, 0x8f, 0x9e, 0x4c, 0xd4, 0x03, 0x13, 0xa5,
+	0x5d, 0xc0, 0xb7, 0x1a, 0x18, 0xdf, 0xde, 0xf6, 0x21, 0x56, 0xd2, 0x82, 0xc1, 0xf3, 0x7b, 0xda,
+	0x24, 0x06, 0xd7, 0x24, 0x86, 0xa0, 0x49, 0x0c, 0xd8, 0xe4, 0x8c, 0x3c, 0x10, 0xea, 0x09, 0xe4,
+	0xc0, 0x21, 0xda, 0xb1, 0x26, 0xb7, 0xb2, 0xb2, 0xda, 0x0d, 0x50, 0xed, 0xf6, 0x96, 0x6c, 0x09,
+	0x70, 0x96, 0x31, 0x1e, 0xd9, 0x1f, 0xdc, 0x88, 0xca, 0xe9, 0xa9, 0x97, 0x29, 0x9f, 0xc3, 0x3f,
+	0x7d, 0x32, 0x5e, 0x54, 0xf4, 0x4b, 0x96, 0x03, 0x8b, 0xe6, 0x84, 0xc8, 0x71, 0x53, 0x2e, 0xf4,
+	0x64, 0xa7, 0x2a, 0xdb, 0x1d, 0x3f, 0xd4, 0xeb, 0xeb, 0xac, 0x62, 0xf0, 0x59, 0xc5, 0xb0, 0xc1,
+	0xca, 0x2c, 0xdc, 0x05, 0x79, 0x24, 0x75, 0x59, 0xba, 0x3d, 0xe7, 0x01, 0xb3, 0x78, 0xfb, 0x41,
+	0x8e, 0x0f, 0x43, 0x22, 0x51, 0x40, 0x77, 0x09, 0xb8, 0x84, 0xbb, 0x01, 0xaa, 0x8b, 0xf8, 0xb7,
+	0x4f, 0xc6, 0xf3, 0xeb, 0x22, 0xe3, 0x19, 0x2d, 0x1a, 0x6b, 0x35, 0x5e, 0xd4, 0x86, 0x35, 0x92,
+	0x3d, 0xd6, 0x06, 0xc5, 0x0b, 0x55, 0x20, 0x06, 0xaf, 0x5b, 0x0c, 0x9b, 0xdc, 0xcc, 0x52, 0x7e,
+	0x22, 0x8f, 0x15, 0x90, 0xb5, 0xdc, 0x77, 0x1f, 0x31, 0x8b, 0x79, 0x10, 0x9e, 0xa0, 0x6d, 0x3f,
+	0x90, 0x87, 0x8a, 0x89, 0x72, 0x7a, 0xd6, 0x81, 0xeb, 0xb9, 0x17, 0xc2, 0xba, 0xa0, 0xbf, 0xfb,
+	0x64, 0x14, 0x43, 0x01, 0x55, 0x96, 0x36, 0x9d, 0x24, 0x87, 0x56, 0x53, 0x76, 0xaa, 0xa7, 0x93,
+	0x30, 0xc4, 0x4d, 0x29, 0x75, 0xab, 0x29, 0x3b, 0x35, 0x6c, 0xe5, 0x34, 0xa5, 0xd4, 0xdd, 0xa6,
+	0x34, 0x80, 0xa7, 0x29, 0x2d, 0x6e, 0x24, 0x44, 0x8b, 0xec, 0xa6, 0x44, 0xb2, 0x2f, 0x21, 0x30,
+	0xd5, 0x35, 0xfc, 0xd1, 0x27, 0x83, 0xe3, 0x9c, 0xd6, 0x57, 0x4d, 0x7c, 0x89, 0x81, 0x95, 0x81,
+	0x4a, 0xf3, 0xc4, 0x57, 0x87, 0x70, 0x06, 0x0a, 0xd5, 0xca, 0x40, 0xa5, 0x85, 0x4c, 0x9c, 0x0c,
+	0x14, 0xaa, 0x9b, 0x81, 0x48, 0xf6, 0xec, 0xd0, 0xa0, 0x38, 0x03, 0x05, 0xb0, 0x33, 0x50, 0x8b,
+	0x9e, 0x0c, 0x44, 0xcc, 0xcc, 0xc0, 0xa4, 0xe2, 0x59, 0x92, 0xb7, 0x19, 0xd8, 0x8e, 0xed, 0x0c,
+	0xd4, 0xaa, 0x2f, 0xb8, 0x10, 0x34, 0x32, 0xb0, 0xd5, 0xed, 0x0c, 0xd4, 0x6a, 0xd8, 0xca, 0xcd,
+	0xc0, 0x56, 0xf7, 0x64, 0x20, 0x06, 0xbe, 0x0c, 0x34, 0xb9, 0x91, 0x81, 0x2d, 0x72, 0x32, 0xb0,
+	0x93, 0x7d, 0x19, 0x88, 0xa9, 0x2e, 0xe2, 0xbf, 0x1e, 0x19, 0x5e, 0x42, 0x0e, 0x29, 0x6f, 0x8c,
+	0xdb, 0x91, 0xf8, 0xc2, 0xc1, 0xc6, 0x48, 0xf6, 0x18, 0x1b, 0x14, 0x6f, 0xbd, 0x05, 0x32, 0x7b,
+	0xf1, 0xd6, 0x0d, 0xe0, 0xd9, 0xba, 0xc5, 0xd1, 0xd6, 0x47, 0xa7, 0xeb, 0x32, 0x4f, 0xb2, 0xc2,
+	0x75, 0x93, 0x20, 0xe8, 0xa6, 0xb9, 0xde, 0xfa, 0xaf, 0x1e, 0xd9, 0x9a, 0x17, 0x8c, 0x27, 0x45,
+	0x0a, 0x4c, 0x24, 0xb6, 0xfc, 0xc7, 0x4e, 0xec, 0x4e, 0xf6, 0x25, 0x36, 0xa6, 0x46, 0xb4, 0x4a,
+	0xe0, 0x44, 0x2b, 0xd2, 0x7d, 0xd1, 0x6a, 0x60, 0xbd, 0xd8, 0x05, 0x19, 0x5e, 0xf2, 0x84, 0xd7,
+	0xac, 0xb9, 0x3e, 0xed, 0x28, 0x06, 0xe3, 0xfa, 0x68, 0xd1, 0x73, 0x7d, 0x10, 0xd3, 0x8e, 0xe7,
+	0x64, 0x70, 0xd4, 0xbc, 0x98, 0x45, 0x27, 0x64, 0x30, 0x2b, 0xcb, 0xfc, 0x0e, 0xb7, 0xba, 0x20,
+	0x42, 0xf5, 0xb4, 0x3a, 0x86, 0x5d, 0x6e, 0xf5, 0xc8, 0x68, 0x56, 0xa5, 0x37, 0xd9, 0x2d, 0x44,
+	0xe7, 0x64, 0x78, 0xba, 0x2e, 0x69, 0xc5, 0xf1, 0x31, 0x49, 0xd8, 0x02, 0xcf, 0x31, 0x59, 0x5c,
+	0x19, 0xbf, 0xea, 0x35, 0x76, 0xf3, 0x55, 0xc0, 0x6e, 0xbe, 0xda, 0x6c, 0x37, 0x5f, 0x99, 0x76,
+	0x2f, 0x7b, 0x87, 0xdf, 0x7b, 0x64, 0xf4, 0x2e, 0x63, 0x9c, 0x56, 0x77, 0xd1, 0x29, 0xb9, 0x6f,
+	0xdf, 0x22, 0x89, 0x02, 0xb7, 0xc8, 0xa0, 0xfa, 0xb8, 0x3f, 0x92, 0xf1, 0x05, 0xcd, 0xf3, 0x65,
+	0x92, 0x7e, 0x8d, 0x0e, 0x9c, 0xc9, 0x0a, 0x29, 0xbb, 0x67, 0x1b, 0x66, 0xe8, 0x7a, 0xbe, 0x27,
+	0x83, 0x59, 0x7d, 0x95, 0xf1, 0x68, 0x26, 0x97, 0x88, 0x8e, 0x5a, 0x80, 0x40, 0x52, 0x22, 0xa6,
+	0xbc, 0x8e, 0x5e, 0x7f, 0x9e, 0x5e, 0x67, 0xfc, 0xa6, 0x5e, 0x4e, 0x52, 0xba, 0x9a, 0x96, 0x94,
+	0x41, 0x76, 0x45, 0x8b, 0xa9, 0x7e, 0x2f, 0x77, 0xdf, 0xe2, 0x97, 0x43, 0xf1, 0x4a, 0xfe, 0xe6,
+	0xff, 0x00, 0x3b, 0xcb, 0xf0, 0xb4, 0xe2, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -452,6 +473,8 @@ type IgnitionClient interface {
 	IgnitionGet(ctx context.Context, in *serverpb.IgnitionGetRequest, opts ...grpc.CallOption) (*serverpb.IgnitionGetResponse, error)
 	// Delete a Container Linux Config template by name.
 	IgnitionDelete(ctx context.Context, in *serverpb.IgnitionDeleteRequest, opts ...grpc.CallOption) (*serverpb.IgnitionDeleteResponse, error)
+	// List Container Linux Config templates.
+	IgnitionList(ctx context.Context, in *serverpb.IgnitionListRequest, opts ...grpc.CallOption) (*serverpb.IgnitionListResponse, error)
 }
 
 type ignitionClient struct {
@@ -489,6 +512,15 @@ func (c *ignitionClient) IgnitionDelete(ctx context.Context, in *serverpb.Igniti
 	return out, nil
 }
 
+func (c *ignitionClient) IgnitionList(ctx context.Context, in *serverpb.IgnitionListRequest, opts ...grpc.CallOption) (*serverpb.IgnitionListResponse, error) {
+	out := new(serverpb.IgnitionListResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Ignition/IgnitionList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IgnitionServer is the server API for Ignition service.
 type IgnitionServer interface {
 	// Create or update a Container Linux Config template.
@@ -497,6 +529,8 @@ type IgnitionServer interface {
 	IgnitionGet(context.Context, *serverpb.IgnitionGetRequest) (*serverpb.IgnitionGetResponse, error)
 	// Delete a Container Linux Config template by name.
 	IgnitionDelete(context.Context, *serverpb.IgnitionDeleteRequest) (*serverpb.IgnitionDeleteResponse, error)
+	// List Container Linux Config templates.
+	IgnitionList(context.Context, *serverpb.IgnitionListRequest) (*serverpb.IgnitionListResponse, error)
 }
 
 // UnimplementedIgnitionServer can be embedded to have forward compatible implementations.
@@ -512,6 +546,9 @@ func (*UnimplementedIgnitionServer) IgnitionGet(ctx context.Context, req *server
 func (*UnimplementedIgnitionServer) IgnitionDelete(ctx context.Context, req *serverpb.IgnitionDeleteRequest) (*serverpb.IgnitionDeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IgnitionDelete not implemented")
 }
+func (*UnimplementedIgnitionServer) IgnitionList(ctx context.Context, req *serverpb.IgnitionListRequest) (*serverpb.IgnitionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IgnitionList not implemented")
+}
 
 func RegisterIgnitionServer(s *grpc.Server, srv IgnitionServer) {
 	s.RegisterService(&_Ignition_serviceDesc, srv)
@@ -571,6 +608,24 @@ func _Ignition_IgnitionDelete_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Ignition_IgnitionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.IgnitionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IgnitionServer).IgnitionList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Ignition/IgnitionList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IgnitionServer).IgnitionList(ctx, req.(*serverpb.IgnitionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Ignition_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.Ignition",
 	HandlerType: (*IgnitionServer)(nil),
@@ -587,6 +642,10 @@ var _Ignition_serviceDesc = grpc.ServiceDesc{
 			MethodName: "IgnitionDelete",
 			Handler:    _Ignition_IgnitionDelete_Handler,
 		},
+		{
+			MethodName: "IgnitionList",
+			Handler:    _Ignition_IgnitionList_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "matchbox/rpc/rpcpb/rpc.proto",
@@ -602,6 +661,8 @@ type GenericClient interface {
 	GenericGet(ctx context.Context, in *serverpb.GenericGetRequest, opts ...grpc.CallOption) (*serverpb.GenericGetResponse, error)
 	// Delete a Generic template by name.
 	GenericDelete(ctx context.Context, in *serverpb.GenericDeleteRequest, opts ...grpc.CallOption) (*serverpb.GenericDeleteResponse, error)
+	// List Generic templates.
+	GenericList(ctx context.Context, in *serverpb.GenericListRequest, opts ...grpc.CallOption) (*serverpb.GenericListResponse, error)
 }
 
 type genericClient struct {
@@ -639,6 +700,15 @@ func (c *genericClient) GenericDelete(ctx context.Context, in *serverpb.GenericD
 	return out, nil
 }
 
+func (c *genericClient) GenericList(ctx context.Context, in *serverpb.GenericListRequest, opts ...grpc.CallOption) (*serverpb.GenericListResponse, error) {
+	out := new(serverpb.GenericListResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Generic/GenericList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GenericServer is the server API for Generic service.
 type GenericServer interface {
 	// Create or update a Generic template.
@@ -647,6 +717,8 @@ type GenericServer interface {
 	GenericGet(context.Context, *serverpb.GenericGetRequest) (*serverpb.GenericGetResponse, error)
 	// Delete a Generic template by name.
 	GenericDelete(context.Context, *serverpb.GenericDeleteRequest) (*serverpb.GenericDeleteResponse, error)
+	// List Generic templates.
+	GenericList(context.Context, *serverpb.GenericListRequest) (*serverpb.GenericListResponse, error)
 }
 
 // UnimplementedGenericServer can be embedded to have forward compatible implementations.
@@ -662,6 +734,9 @@ func (*UnimplementedGenericServer) GenericGet(ctx context.Context, req *serverpb
 func (*UnimplementedGenericServer) GenericDelete(ctx context.Context, req *serverpb.GenericDeleteRequest) (*serverpb.GenericDeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenericDelete not implemented")
 }
+func (*UnimplementedGenericServer) GenericList(ctx context.Context, req *serverpb.GenericListRequest) (*serverpb.GenericListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenericList not implemented")
+}
 
 func RegisterGenericServer(s *grpc.Server, srv GenericServer) {
 	s.RegisterService(&_Generic_serviceDesc, srv)
@@ -721,6 +796,24 @@ func _Generic_GenericDelete_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Generic_GenericList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.GenericListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GenericServer).GenericList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Generic/GenericList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GenericServer).GenericList(ctx, req.(*serverpb.GenericListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Generic_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.Generic",
 	HandlerType: (*GenericServer)(nil),
@@ -737,117 +830,1156 @@ var _Generic_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GenericDelete",
 			Handler:    _Generic_GenericDelete_Handler,
 		},
+		{
+			MethodName: "GenericList",
+			Handler:    _Generic_GenericList_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "matchbox/rpc/rpcpb/rpc.proto",
 }
 
-// SelectClient is the client API for Select service.
+// CloudClient is the client API for Cloud service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SelectClient interface {
-	// SelectGroup returns the Group matching the given labels.
-	SelectGroup(ctx context.Context, in *serverpb.SelectGroupRequest, opts ...grpc.CallOption) (*serverpb.SelectGroupResponse, error)
-	// SelectProfile returns the Profile matching the given labels.
-	SelectProfile(ctx context.Context, in *serverpb.SelectProfileRequest, opts ...grpc.CallOption) (*serverpb.SelectProfileResponse, error)
+type CloudClient interface {
+	// Create or update a Cloud-Config template.
+	CloudPut(ctx context.Context, in *serverpb.CloudPutRequest, opts ...grpc.CallOption) (*serverpb.CloudPutResponse, error)
+	// Get a Cloud-Config template by name.
+	CloudGet(ctx context.Context, in *serverpb.CloudGetRequest, opts ...grpc.CallOption) (*serverpb.CloudGetResponse, error)
+	// Delete a Cloud-Config template by name.
+	CloudDelete(ctx context.Context, in *serverpb.CloudDeleteRequest, opts ...grpc.CallOption) (*serverpb.CloudDeleteResponse, error)
+	// List Cloud-Config templates.
+	CloudList(ctx context.Context, in *serverpb.CloudListRequest, opts ...grpc.CallOption) (*serverpb.CloudListResponse, error)
+}
+
+type cloudClient struct {
+	cc *grpc.ClientConn
 }
 
-type selectClient struct {
+func NewCloudClient(cc *grpc.ClientConn) CloudClient {
+	return &cloudClient{cc}
+}
+
+func (c *cloudClient) CloudPut(ctx context.Context, in *serverpb.CloudPutRequest, opts ...grpc.CallOption) (*serverpb.CloudPutResponse, error) {
+	out := new(serverpb.CloudPutResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Cloud/CloudPut", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudClient) CloudGet(ctx context.Context, in *serverpb.CloudGetRequest, opts ...grpc.CallOption) (*serverpb.CloudGetResponse, error) {
+	out := new(serverpb.CloudGetResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Cloud/CloudGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudClient) CloudDelete(ctx context.Context, in *serverpb.CloudDeleteRequest, opts ...grpc.CallOption) (*serverpb.CloudDeleteResponse, error) {
+	out := new(serverpb.CloudDeleteResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Cloud/CloudDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudClient) CloudList(ctx context.Context, in *serverpb.CloudListRequest, opts ...grpc.CallOption) (*serverpb.CloudListResponse, error) {
+	out := new(serverpb.CloudListResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Cloud/CloudList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CloudServer is the server API for Cloud service.
+type CloudServer interface {
+	// Create or update a Cloud-Config template.
+	CloudPut(context.Context, *serverpb.CloudPutRequest) (*serverpb.CloudPutResponse, error)
+	// Get a Cloud-Config template by name.
+	CloudGet(context.Context, *serverpb.CloudGetRequest) (*serverpb.CloudGetResponse, error)
+	// Delete a Cloud-Config template by name.
+	CloudDelete(context.Context, *serverpb.CloudDeleteRequest) (*serverpb.CloudDeleteResponse, error)
+	// List Cloud-Config templates.
+	CloudList(context.Context, *serverpb.CloudListRequest) (*serverpb.CloudListResponse, error)
+}
+
+// UnimplementedCloudServer can be embedded to have forward compatible implementations.
+type UnimplementedCloudServer struct {
+}
+
+func (*UnimplementedCloudServer) CloudPut(ctx context.Context, req *serverpb.CloudPutRequest) (*serverpb.CloudPutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloudPut not implemented")
+}
+func (*UnimplementedCloudServer) CloudGet(ctx context.Context, req *serverpb.CloudGetRequest) (*serverpb.CloudGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloudGet not implemented")
+}
+func (*UnimplementedCloudServer) CloudDelete(ctx context.Context, req *serverpb.CloudDeleteRequest) (*serverpb.CloudDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloudDelete not implemented")
+}
+func (*UnimplementedCloudServer) CloudList(ctx context.Context, req *serverpb.CloudListRequest) (*serverpb.CloudListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloudList not implemented")
+}
+
+func RegisterCloudServer(s *grpc.Server, srv CloudServer) {
+	s.RegisterService(&_Cloud_serviceDesc, srv)
+}
+
+func _Cloud_CloudPut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.CloudPutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudServer).CloudPut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Cloud/CloudPut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudServer).CloudPut(ctx, req.(*serverpb.CloudPutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cloud_CloudGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.CloudGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudServer).CloudGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Cloud/CloudGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudServer).CloudGet(ctx, req.(*serverpb.CloudGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cloud_CloudDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.CloudDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudServer).CloudDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Cloud/CloudDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudServer).CloudDelete(ctx, req.(*serverpb.CloudDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cloud_CloudList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.CloudListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudServer).CloudList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Cloud/CloudList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudServer).CloudList(ctx, req.(*serverpb.CloudListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Cloud_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Cloud",
+	HandlerType: (*CloudServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CloudPut",
+			Handler:    _Cloud_CloudPut_Handler,
+		},
+		{
+			MethodName: "CloudGet",
+			Handler:    _Cloud_CloudGet_Handler,
+		},
+		{
+			MethodName: "CloudDelete",
+			Handler:    _Cloud_CloudDelete_Handler,
+		},
+		{
+			MethodName: "CloudList",
+			Handler:    _Cloud_CloudList_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "matchbox/rpc/rpcpb/rpc.proto",
+}
+
+// PartialsClient is the client API for Partials service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PartialsClient interface {
+	// Create or update a template partial.
+	PartialPut(ctx context.Context, in *serverpb.PartialPutRequest, opts ...grpc.CallOption) (*serverpb.PartialPutResponse, error)
+	// Get a template partial by name.
+	PartialGet(ctx context.Context, in *serverpb.PartialGetRequest, opts ...grpc.CallOption) (*serverpb.PartialGetResponse, error)
+	// Delete a template partial by name.
+	PartialDelete(ctx context.Context, in *serverpb.PartialDeleteRequest, opts ...grpc.CallOption) (*serverpb.PartialDeleteResponse, error)
+	// List all template partial names.
+	PartialList(ctx context.Context, in *serverpb.PartialListRequest, opts ...grpc.CallOption) (*serverpb.PartialListResponse, error)
+}
+
+type partialsClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewSelectClient(cc *grpc.ClientConn) SelectClient {
-	return &selectClient{cc}
+func NewPartialsClient(cc *grpc.ClientConn) PartialsClient {
+	return &partialsClient{cc}
 }
 
-func (c *selectClient) SelectGroup(ctx context.Context, in *serverpb.SelectGroupRequest, opts ...grpc.CallOption) (*serverpb.SelectGroupResponse, error) {
-	out := new(serverpb.SelectGroupResponse)
-	err := c.cc.Invoke(ctx, "/rpcpb.Select/SelectGroup", in, out, opts...)
+func (c *partialsClient) PartialPut(ctx context.Context, in *serverpb.PartialPutRequest, opts ...grpc.CallOption) (*serverpb.PartialPutResponse, error) {
+	out := new(serverpb.PartialPutResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Partials/PartialPut", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *selectClient) SelectProfile(ctx context.Context, in *serverpb.SelectProfileRequest, opts ...grpc.CallOption) (*serverpb.SelectProfileResponse, error) {
-	out := new(serverpb.SelectProfileResponse)
-	err := c.cc.Invoke(ctx, "/rpcpb.Select/SelectProfile", in, out, opts...)
+func (c *partialsClient) PartialGet(ctx context.Context, in *serverpb.PartialGetRequest, opts ...grpc.CallOption) (*serverpb.PartialGetResponse, error) {
+	out := new(serverpb.PartialGetResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Partials/PartialGet", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// SelectServer is the server API for Select service.
-type SelectServer interface {
-	// SelectGroup returns the Group matching the given labels.
-	SelectGroup(context.Context, *serverpb.SelectGroupRequest) (*serverpb.SelectGroupResponse, error)
-	// SelectProfile returns the Profile matching the given labels.
-	SelectProfile(context.Context, *serverpb.SelectProfileRequest) (*serverpb.SelectProfileResponse, error)
+func (c *partialsClient) PartialDelete(ctx context.Context, in *serverpb.PartialDeleteRequest, opts ...grpc.CallOption) (*serverpb.PartialDeleteResponse, error) {
+	out := new(serverpb.PartialDeleteResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Partials/PartialDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedSelectServer can be embedded to have forward compatible implementations.
-type UnimplementedSelectServer struct {
+func (c *partialsClient) PartialList(ctx context.Context, in *serverpb.PartialListRequest, opts ...grpc.CallOption) (*serverpb.PartialListResponse, error) {
+	out := new(serverpb.PartialListResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Partials/PartialList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (*UnimplementedSelectServer) SelectGroup(ctx context.Context, req *serverpb.SelectGroupRequest) (*serverpb.SelectGroupResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SelectGroup not implemented")
+// PartialsServer is the server API for Partials service.
+type PartialsServer interface {
+	// Create or update a template partial.
+	PartialPut(context.Context, *serverpb.PartialPutRequest) (*serverpb.PartialPutResponse, error)
+	// Get a template partial by name.
+	PartialGet(context.Context, *serverpb.PartialGetRequest) (*serverpb.PartialGetResponse, error)
+	// Delete a template partial by name.
+	PartialDelete(context.Context, *serverpb.PartialDeleteRequest) (*serverpb.PartialDeleteResponse, error)
+	// List all template partial names.
+	PartialList(context.Context, *serverpb.PartialListRequest) (*serverpb.PartialListResponse, error)
 }
-func (*UnimplementedSelectServer) SelectProfile(ctx context.Context, req *serverpb.SelectProfileRequest) (*serverpb.SelectProfileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SelectProfile not implemented")
+
+// UnimplementedPartialsServer can be embedded to have forward compatible implementations.
+type UnimplementedPartialsServer struct {
 }
 
-func RegisterSelectServer(s *grpc.Server, srv SelectServer) {
-	s.RegisterService(&_Select_serviceDesc, srv)
+func (*UnimplementedPartialsServer) PartialPut(ctx context.Context, req *serverpb.PartialPutRequest) (*serverpb.PartialPutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PartialPut not implemented")
+}
+func (*UnimplementedPartialsServer) PartialGet(ctx context.Context, req *serverpb.PartialGetRequest) (*serverpb.PartialGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PartialGet not implemented")
+}
+func (*UnimplementedPartialsServer) PartialDelete(ctx context.Context, req *serverpb.PartialDeleteRequest) (*serverpb.PartialDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PartialDelete not implemented")
+}
+func (*UnimplementedPartialsServer) PartialList(ctx context.Context, req *serverpb.PartialListRequest) (*serverpb.PartialListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PartialList not implemented")
 }
 
-func _Select_SelectGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(serverpb.SelectGroupRequest)
+func RegisterPartialsServer(s *grpc.Server, srv PartialsServer) {
+	s.RegisterService(&_Partials_serviceDesc, srv)
+}
+
+func _Partials_PartialPut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.PartialPutRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SelectServer).SelectGroup(ctx, in)
+		return srv.(PartialsServer).PartialPut(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.Select/SelectGroup",
+		FullMethod: "/rpcpb.Partials/PartialPut",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SelectServer).SelectGroup(ctx, req.(*serverpb.SelectGroupRequest))
+		return srv.(PartialsServer).PartialPut(ctx, req.(*serverpb.PartialPutRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Select_SelectProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(serverpb.SelectProfileRequest)
+func _Partials_PartialGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.PartialGetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SelectServer).SelectProfile(ctx, in)
+		return srv.(PartialsServer).PartialGet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/rpcpb.Select/SelectProfile",
+		FullMethod: "/rpcpb.Partials/PartialGet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SelectServer).SelectProfile(ctx, req.(*serverpb.SelectProfileRequest))
+		return srv.(PartialsServer).PartialGet(ctx, req.(*serverpb.PartialGetRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Select_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "rpcpb.Select",
-	HandlerType: (*SelectServer)(nil),
+func _Partials_PartialDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.PartialDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartialsServer).PartialDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Partials/PartialDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartialsServer).PartialDelete(ctx, req.(*serverpb.PartialDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partials_PartialList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.PartialListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartialsServer).PartialList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Partials/PartialList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartialsServer).PartialList(ctx, req.(*serverpb.PartialListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Partials_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Partials",
+	HandlerType: (*PartialsServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "SelectGroup",
-			Handler:    _Select_SelectGroup_Handler,
+			MethodName: "PartialPut",
+			Handler:    _Partials_PartialPut_Handler,
 		},
 		{
-			MethodName: "SelectProfile",
-			Handler:    _Select_SelectProfile_Handler,
+			MethodName: "PartialGet",
+			Handler:    _Partials_PartialGet_Handler,
+		},
+		{
+			MethodName: "PartialDelete",
+			Handler:    _Partials_PartialDelete_Handler,
+		},
+		{
+			MethodName: "PartialList",
+			Handler:    _Partials_PartialList_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "matchbox/rpc/rpcpb/rpc.proto",
+}
+
+// SelectClient is the client API for Select service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SelectClient interface {
+	// SelectGroup returns the Group matching the given labels.
+	SelectGroup(ctx context.Context, in *serverpb.SelectGroupRequest, opts ...grpc.CallOption) (*serverpb.SelectGroupResponse, error)
+	// SelectProfile returns the Profile matching the given labels.
+	SelectProfile(ctx context.Context, in *serverpb.SelectProfileRequest, opts ...grpc.CallOption) (*serverpb.SelectProfileResponse, error)
+	// Explain explains how each Group is evaluated against the given labels.
+	Explain(ctx context.Context, in *serverpb.SelectExplainRequest, opts ...grpc.CallOption) (*serverpb.SelectExplainResponse, error)
+}
+
+type selectClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSelectClient(cc *grpc.ClientConn) SelectClient {
+	return &selectClient{cc}
+}
+
+func (c *selectClient) SelectGroup(ctx context.Context, in *serverpb.SelectGroupRequest, opts ...grpc.CallOption) (*serverpb.SelectGroupResponse, error) {
+	out := new(serverpb.SelectGroupResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Select/SelectGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *selectClient) SelectProfile(ctx context.Context, in *serverpb.SelectProfileRequest, opts ...grpc.CallOption) (*serverpb.SelectProfileResponse, error) {
+	out := new(serverpb.SelectProfileResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Select/SelectProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *selectClient) Explain(ctx context.Context, in *serverpb.SelectExplainRequest, opts ...grpc.CallOption) (*serverpb.SelectExplainResponse, error) {
+	out := new(serverpb.SelectExplainResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Select/Explain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SelectServer is the server API for Select service.
+type SelectServer interface {
+	// SelectGroup returns the Group matching the given labels.
+	SelectGroup(context.Context, *serverpb.SelectGroupRequest) (*serverpb.SelectGroupResponse, error)
+	// SelectProfile returns the Profile matching the given labels.
+	SelectProfile(context.Context, *serverpb.SelectProfileRequest) (*serverpb.SelectProfileResponse, error)
+	// Explain explains how each Group is evaluated against the given labels.
+	Explain(context.Context, *serverpb.SelectExplainRequest) (*serverpb.SelectExplainResponse, error)
+}
+
+// UnimplementedSelectServer can be embedded to have forward compatible implementations.
+type UnimplementedSelectServer struct {
+}
+
+func (*UnimplementedSelectServer) SelectGroup(ctx context.Context, req *serverpb.SelectGroupRequest) (*serverpb.SelectGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelectGroup not implemented")
+}
+func (*UnimplementedSelectServer) SelectProfile(ctx context.Context, req *serverpb.SelectProfileRequest) (*serverpb.SelectProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelectProfile not implemented")
+}
+func (*UnimplementedSelectServer) Explain(ctx context.Context, req *serverpb.SelectExplainRequest) (*serverpb.SelectExplainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Explain not implemented")
+}
+
+func RegisterSelectServer(s *grpc.Server, srv SelectServer) {
+	s.RegisterService(&_Select_serviceDesc, srv)
+}
+
+func _Select_SelectGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.SelectGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SelectServer).SelectGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Select/SelectGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SelectServer).SelectGroup(ctx, req.(*serverpb.SelectGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Select_SelectProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.SelectProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SelectServer).SelectProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Select/SelectProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SelectServer).SelectProfile(ctx, req.(*serverpb.SelectProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Select_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.SelectExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SelectServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Select/Explain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SelectServer).Explain(ctx, req.(*serverpb.SelectExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Select_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Select",
+	HandlerType: (*SelectServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SelectGroup",
+			Handler:    _Select_SelectGroup_Handler,
+		},
+		{
+			MethodName: "SelectProfile",
+			Handler:    _Select_SelectProfile_Handler,
+		},
+		{
+			MethodName: "Explain",
+			Handler:    _Select_Explain_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "matchbox/rpc/rpcpb/rpc.proto",
+}
+
+// InstancesClient is the client API for Instances service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type InstancesClient interface {
+	// Get an observed machine Instance by id.
+	InstanceGet(ctx context.Context, in *serverpb.InstanceGetRequest, opts ...grpc.CallOption) (*serverpb.InstanceGetResponse, error)
+	// List all observed machine Instances.
+	InstanceList(ctx context.Context, in *serverpb.InstanceListRequest, opts ...grpc.CallOption) (*serverpb.InstanceListResponse, error)
+}
+
+type instancesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewInstancesClient(cc *grpc.ClientConn) InstancesClient {
+	return &instancesClient{cc}
+}
+
+func (c *instancesClient) InstanceGet(ctx context.Context, in *serverpb.InstanceGetRequest, opts ...grpc.CallOption) (*serverpb.InstanceGetResponse, error) {
+	out := new(serverpb.InstanceGetResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Instances/InstanceGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instancesClient) InstanceList(ctx context.Context, in *serverpb.InstanceListRequest, opts ...grpc.CallOption) (*serverpb.InstanceListResponse, error) {
+	out := new(serverpb.InstanceListResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Instances/InstanceList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InstancesServer is the server API for Instances service.
+type InstancesServer interface {
+	// Get an observed machine Instance by id.
+	InstanceGet(context.Context, *serverpb.InstanceGetRequest) (*serverpb.InstanceGetResponse, error)
+	// List all observed machine Instances.
+	InstanceList(context.Context, *serverpb.InstanceListRequest) (*serverpb.InstanceListResponse, error)
+}
+
+// UnimplementedInstancesServer can be embedded to have forward compatible implementations.
+type UnimplementedInstancesServer struct {
+}
+
+func (*UnimplementedInstancesServer) InstanceGet(ctx context.Context, req *serverpb.InstanceGetRequest) (*serverpb.InstanceGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstanceGet not implemented")
+}
+func (*UnimplementedInstancesServer) InstanceList(ctx context.Context, req *serverpb.InstanceListRequest) (*serverpb.InstanceListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstanceList not implemented")
+}
+
+func RegisterInstancesServer(s *grpc.Server, srv InstancesServer) {
+	s.RegisterService(&_Instances_serviceDesc, srv)
+}
+
+func _Instances_InstanceGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.InstanceGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstancesServer).InstanceGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Instances/InstanceGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstancesServer).InstanceGet(ctx, req.(*serverpb.InstanceGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Instances_InstanceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.InstanceListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstancesServer).InstanceList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Instances/InstanceList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstancesServer).InstanceList(ctx, req.(*serverpb.InstanceListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Instances_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Instances",
+	HandlerType: (*InstancesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InstanceGet",
+			Handler:    _Instances_InstanceGet_Handler,
+		},
+		{
+			MethodName: "InstanceList",
+			Handler:    _Instances_InstanceList_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "matchbox/rpc/rpcpb/rpc.proto",
+}
+
+// StatusClient is the client API for Status service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StatusClient interface {
+	// Get the status of stored resources.
+	StatusGet(ctx context.Context, in *serverpb.StatusGetRequest, opts ...grpc.CallOption) (*serverpb.StatusGetResponse, error)
+}
+
+type statusClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStatusClient(cc *grpc.ClientConn) StatusClient {
+	return &statusClient{cc}
+}
+
+func (c *statusClient) StatusGet(ctx context.Context, in *serverpb.StatusGetRequest, opts ...grpc.CallOption) (*serverpb.StatusGetResponse, error) {
+	out := new(serverpb.StatusGetResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Status/StatusGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatusServer is the server API for Status service.
+type StatusServer interface {
+	// Get the status of stored resources.
+	StatusGet(context.Context, *serverpb.StatusGetRequest) (*serverpb.StatusGetResponse, error)
+}
+
+// UnimplementedStatusServer can be embedded to have forward compatible implementations.
+type UnimplementedStatusServer struct {
+}
+
+func (*UnimplementedStatusServer) StatusGet(ctx context.Context, req *serverpb.StatusGetRequest) (*serverpb.StatusGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatusGet not implemented")
+}
+
+func RegisterStatusServer(s *grpc.Server, srv StatusServer) {
+	s.RegisterService(&_Status_serviceDesc, srv)
+}
+
+func _Status_StatusGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.StatusGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).StatusGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Status/StatusGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).StatusGet(ctx, req.(*serverpb.StatusGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Status_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Status",
+	HandlerType: (*StatusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StatusGet",
+			Handler:    _Status_StatusGet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "matchbox/rpc/rpcpb/rpc.proto",
+}
+
+// BatchClient is the client API for Batch service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BatchClient interface {
+	// Apply validates a batch of resources and writes and deletes them
+	// together, or not at all.
+	Apply(ctx context.Context, in *serverpb.BatchApplyRequest, opts ...grpc.CallOption) (*serverpb.BatchApplyResponse, error)
+}
+
+type batchClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBatchClient(cc *grpc.ClientConn) BatchClient {
+	return &batchClient{cc}
+}
+
+func (c *batchClient) Apply(ctx context.Context, in *serverpb.BatchApplyRequest, opts ...grpc.CallOption) (*serverpb.BatchApplyResponse, error) {
+	out := new(serverpb.BatchApplyResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Batch/Apply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BatchServer is the server API for Batch service.
+type BatchServer interface {
+	// Apply validates a batch of resources and writes and deletes them
+	// together, or not at all.
+	Apply(context.Context, *serverpb.BatchApplyRequest) (*serverpb.BatchApplyResponse, error)
+}
+
+// UnimplementedBatchServer can be embedded to have forward compatible implementations.
+type UnimplementedBatchServer struct {
+}
+
+func (*UnimplementedBatchServer) Apply(ctx context.Context, req *serverpb.BatchApplyRequest) (*serverpb.BatchApplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Apply not implemented")
+}
+
+func RegisterBatchServer(s *grpc.Server, srv BatchServer) {
+	s.RegisterService(&_Batch_serviceDesc, srv)
+}
+
+func _Batch_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.BatchApplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BatchServer).Apply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Batch/Apply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BatchServer).Apply(ctx, req.(*serverpb.BatchApplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Batch_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Batch",
+	HandlerType: (*BatchServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Apply",
+			Handler:    _Batch_Apply_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "matchbox/rpc/rpcpb/rpc.proto",
+}
+
+// ArchiveClient is the client API for Archive service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ArchiveClient interface {
+	// Export streams an archive of all Groups, Profiles, and templates.
+	Export(ctx context.Context, in *serverpb.ArchiveExportRequest, opts ...grpc.CallOption) (Archive_ExportClient, error)
+	// Import writes the resources of a streamed archive together.
+	Import(ctx context.Context, opts ...grpc.CallOption) (Archive_ImportClient, error)
+}
+
+type archiveClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewArchiveClient(cc *grpc.ClientConn) ArchiveClient {
+	return &archiveClient{cc}
+}
+
+func (c *archiveClient) Export(ctx context.Context, in *serverpb.ArchiveExportRequest, opts ...grpc.CallOption) (Archive_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Archive_serviceDesc.Streams[0], "/rpcpb.Archive/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &archiveExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Archive_ExportClient interface {
+	Recv() (*serverpb.ArchiveExportResponse, error)
+	grpc.ClientStream
+}
+
+type archiveExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *archiveExportClient) Recv() (*serverpb.ArchiveExportResponse, error) {
+	m := new(serverpb.ArchiveExportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *archiveClient) Import(ctx context.Context, opts ...grpc.CallOption) (Archive_ImportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Archive_serviceDesc.Streams[1], "/rpcpb.Archive/Import", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &archiveImportClient{stream}
+	return x, nil
+}
+
+type Archive_ImportClient interface {
+	Send(*serverpb.ArchiveImportRequest) error
+	CloseAndRecv() (*serverpb.ArchiveImportResponse, error)
+	grpc.ClientStream
+}
+
+type archiveImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *archiveImportClient) Send(m *serverpb.ArchiveImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *archiveImportClient) CloseAndRecv() (*serverpb.ArchiveImportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(serverpb.ArchiveImportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ArchiveServer is the server API for Archive service.
+type ArchiveServer interface {
+	// Export streams an archive of all Groups, Profiles, and templates.
+	Export(*serverpb.ArchiveExportRequest, Archive_ExportServer) error
+	// Import writes the resources of a streamed archive together.
+	Import(Archive_ImportServer) error
+}
+
+// UnimplementedArchiveServer can be embedded to have forward compatible implementations.
+type UnimplementedArchiveServer struct {
+}
+
+func (*UnimplementedArchiveServer) Export(req *serverpb.ArchiveExportRequest, srv Archive_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (*UnimplementedArchiveServer) Import(srv Archive_ImportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
+
+func RegisterArchiveServer(s *grpc.Server, srv ArchiveServer) {
+	s.RegisterService(&_Archive_serviceDesc, srv)
+}
+
+func _Archive_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(serverpb.ArchiveExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArchiveServer).Export(m, &archiveExportServer{stream})
+}
+
+type Archive_ExportServer interface {
+	Send(*serverpb.ArchiveExportResponse) error
+	grpc.ServerStream
+}
+
+type archiveExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *archiveExportServer) Send(m *serverpb.ArchiveExportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Archive_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ArchiveServer).Import(&archiveImportServer{stream})
+}
+
+type Archive_ImportServer interface {
+	SendAndClose(*serverpb.ArchiveImportResponse) error
+	Recv() (*serverpb.ArchiveImportRequest, error)
+	grpc.ServerStream
+}
+
+type archiveImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *archiveImportServer) SendAndClose(m *serverpb.ArchiveImportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *archiveImportServer) Recv() (*serverpb.ArchiveImportRequest, error) {
+	m := new(serverpb.ArchiveImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Archive_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Archive",
+	HandlerType: (*ArchiveServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Export",
+			Handler:       _Archive_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Import",
+			Handler:       _Archive_Import_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "matchbox/rpc/rpcpb/rpc.proto",
+}
+
+// HistoryClient is the client API for History service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HistoryClient interface {
+	// List lists the kept revisions of a Group, Profile, or template.
+	List(ctx context.Context, in *serverpb.HistoryListRequest, opts ...grpc.CallOption) (*serverpb.HistoryListResponse, error)
+	// Rollback restores a Group, Profile, or template to a kept revision.
+	Rollback(ctx context.Context, in *serverpb.HistoryRollbackRequest, opts ...grpc.CallOption) (*serverpb.HistoryRollbackResponse, error)
+}
+
+type historyClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHistoryClient(cc *grpc.ClientConn) HistoryClient {
+	return &historyClient{cc}
+}
+
+func (c *historyClient) List(ctx context.Context, in *serverpb.HistoryListRequest, opts ...grpc.CallOption) (*serverpb.HistoryListResponse, error) {
+	out := new(serverpb.HistoryListResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.History/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyClient) Rollback(ctx context.Context, in *serverpb.HistoryRollbackRequest, opts ...grpc.CallOption) (*serverpb.HistoryRollbackResponse, error) {
+	out := new(serverpb.HistoryRollbackResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.History/Rollback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HistoryServer is the server API for History service.
+type HistoryServer interface {
+	// List lists the kept revisions of a Group, Profile, or template.
+	List(context.Context, *serverpb.HistoryListRequest) (*serverpb.HistoryListResponse, error)
+	// Rollback restores a Group, Profile, or template to a kept revision.
+	Rollback(context.Context, *serverpb.HistoryRollbackRequest) (*serverpb.HistoryRollbackResponse, error)
+}
+
+// UnimplementedHistoryServer can be embedded to have forward compatible implementations.
+type UnimplementedHistoryServer struct {
+}
+
+func (*UnimplementedHistoryServer) List(ctx context.Context, req *serverpb.HistoryListRequest) (*serverpb.HistoryListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedHistoryServer) Rollback(ctx context.Context, req *serverpb.HistoryRollbackRequest) (*serverpb.HistoryRollbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rollback not implemented")
+}
+
+func RegisterHistoryServer(s *grpc.Server, srv HistoryServer) {
+	s.RegisterService(&_History_serviceDesc, srv)
+}
+
+func _History_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.HistoryListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.History/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServer).List(ctx, req.(*serverpb.HistoryListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _History_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.HistoryRollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.History/Rollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServer).Rollback(ctx, req.(*serverpb.HistoryRollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _History_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.History",
+	HandlerType: (*HistoryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _History_List_Handler,
+		},
+		{
+			MethodName: "Rollback",
+			Handler:    _History_Rollback_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "matchbox/rpc/rpcpb/rpc.proto",
+}
+
+// AuditClient is the client API for Audit service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AuditClient interface {
+	// List lists recorded calls which wrote or deleted resources.
+	List(ctx context.Context, in *serverpb.AuditListRequest, opts ...grpc.CallOption) (*serverpb.AuditListResponse, error)
+}
+
+type auditClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuditClient(cc *grpc.ClientConn) AuditClient {
+	return &auditClient{cc}
+}
+
+func (c *auditClient) List(ctx context.Context, in *serverpb.AuditListRequest, opts ...grpc.CallOption) (*serverpb.AuditListResponse, error) {
+	out := new(serverpb.AuditListResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.Audit/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditServer is the server API for Audit service.
+type AuditServer interface {
+	// List lists recorded calls which wrote or deleted resources.
+	List(context.Context, *serverpb.AuditListRequest) (*serverpb.AuditListResponse, error)
+}
+
+// UnimplementedAuditServer can be embedded to have forward compatible implementations.
+type UnimplementedAuditServer struct {
+}
+
+func (*UnimplementedAuditServer) List(ctx context.Context, req *serverpb.AuditListRequest) (*serverpb.AuditListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+
+func RegisterAuditServer(s *grpc.Server, srv AuditServer) {
+	s.RegisterService(&_Audit_serviceDesc, srv)
+}
+
+func _Audit_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(serverpb.AuditListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.Audit/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditServer).List(ctx, req.(*serverpb.AuditListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Audit_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.Audit",
+	HandlerType: (*AuditServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _Audit_List_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -28,3 +28,8 @@ func (s *selectServer) SelectProfile(ctx context.Context, req *pb.SelectProfileR
 	profile, err := s.srv.SelectProfile(ctx, req)
 	return &pb.SelectProfileResponse{Profile: profile}, grpcError(err)
 }
+
+func (s *selectServer) Explain(ctx context.Context, req *pb.SelectExplainRequest) (*pb.SelectExplainResponse, error) {
+	resp, err := s.srv.Explain(ctx, req)
+	return resp, grpcError(err)
+}
@@ -0,0 +1,25 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/poseidon/matchbox/matchbox/rpc/rpcpb"
+	"github.com/poseidon/matchbox/matchbox/server"
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+)
+
+// statusServer takes a matchbox Server and implements a gRPC StatusServer.
+type statusServer struct {
+	srv server.Server
+}
+
+func newStatusServer(s server.Server) rpcpb.StatusServer {
+	return &statusServer{
+		srv: s,
+	}
+}
+
+func (s *statusServer) StatusGet(ctx context.Context, req *pb.StatusGetRequest) (*pb.StatusGetResponse, error) {
+	invalid, err := s.srv.StatusGet(ctx, req)
+	return &pb.StatusGetResponse{Healthy: err == nil && len(invalid) == 0, Invalid: invalid}, grpcError(err)
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+	"io"
+
+	"github.com/poseidon/matchbox/matchbox/storage"
+)
+
+// Export writes an archive of all Groups, Profiles, and templates. Returns an
+// InvalidResourcesError rather than an incomplete archive if any stored
+// resources can't be parsed.
+func (s *server) Export(ctx context.Context, w io.Writer) error {
+	invalid, err := s.invalid()
+	if err != nil {
+		return err
+	}
+	if len(invalid) > 0 {
+		return &InvalidResourcesError{Resources: invalid}
+	}
+	return storage.Export(s.store, w)
+}
+
+// Import writes the resources of an archive to the Store together. Archived
+// resources are restored as given, without validating references.
+func (s *server) Import(ctx context.Context, r io.Reader) error {
+	batch, err := storage.ReadArchive(r)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Apply(batch); err != nil {
+		return err
+	}
+	return s.recordBatch(ctx, batch)
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// AuditRecord appends an entry to the audit log.
+func (s *server) AuditRecord(ctx context.Context, entry *storagepb.AuditEntry) error {
+	return s.audit.AuditAppend(entry)
+}
+
+// AuditList lists audit log entries which match the request filters, oldest
+// first. Entries match a kind or name if any of their changes do.
+func (s *server) AuditList(ctx context.Context, req *pb.AuditListRequest) ([]*storagepb.AuditEntry, error) {
+	entries, err := s.audit.AuditList()
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]*storagepb.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		if req.Subject != "" && entry.Subject != req.Subject {
+			continue
+		}
+		if (req.Kind != "" || req.Name != "") && !auditChanged(entry, req.Kind, req.Name) {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+	if req.Limit > 0 && len(matched) > int(req.Limit) {
+		matched = matched[len(matched)-int(req.Limit):]
+	}
+	return matched, nil
+}
+
+// auditChanged returns true if an audit log entry changed a resource of the
+// kind and with the name, if given.
+func auditChanged(entry *storagepb.AuditEntry, kind, name string) bool {
+	for _, change := range entry.Changes {
+		if (kind == "" || change.Kind == kind) && (name == "" || change.Name == name) {
+			return true
+		}
+	}
+	return false
+}
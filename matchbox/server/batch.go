@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// Apply validates a Batch and applies it to the Store. The Batch is
+// validated together, as if it had already been applied: Groups and Profiles
+// must be valid and may reference resources in the Store or in the Batch,
+// templates and partials must parse, Ignition templates are validated with
+// the partials in the Store or in the Batch, and deleted resources must not be referenced, unless forced.
+func (s *server) Apply(ctx context.Context, req *pb.BatchApplyRequest) error {
+	batch := req.Batch
+	if batch == nil {
+		batch = &storagepb.Batch{}
+	}
+	var refs []*storage.Reference
+	for _, group := range batch.Groups {
+		if err := group.AssertValid(); err != nil {
+			return err
+		}
+		refs = append(refs, storage.GroupReferences(group)...)
+	}
+	for _, profile := range batch.Profiles {
+		if err := profile.AssertValid(); err != nil {
+			return err
+		}
+		refs = append(refs, storage.ProfileReferences(profile)...)
+	}
+
+	view, err := storage.NewBatchView(s.store, batch)
+	if err != nil {
+		return err
+	}
+	applied := &server{store: view, instances: s.instances, history: s.history, audit: s.audit}
+	if err := applied.checkDangling(refs); err != nil {
+		return err
+	}
+	for _, profile := range batch.Profiles {
+		if err := applied.checkParents(profile); err != nil {
+			return err
+		}
+	}
+	for _, deletion := range batch.Deletes {
+		if fields := referringFields(deletion.Kind); len(fields) > 0 {
+			if err := applied.checkReferrers(req.Force, deletion.Name, fields...); err != nil {
+				return err
+			}
+		}
+	}
+	for _, template := range batch.Partials {
+		if err := validatePartial(template.Name, template.Contents); err != nil {
+			return err
+		}
+	}
+	for _, template := range batch.Ignition {
+		if err := applied.validateIgnition(template.Name, template.Contents, nil); err != nil {
+			return err
+		}
+	}
+	for _, templates := range [][]*storagepb.Template{batch.Generic, batch.Cloud} {
+		for _, template := range templates {
+			if err := applied.validateTemplate(template.Name, template.Contents); err != nil {
+				return err
+			}
+		}
+	}
+
+	if req.DryRun {
+		return nil
+	}
+	if err := s.store.Apply(batch); err != nil {
+		return err
+	}
+	return s.recordBatch(ctx, batch)
+}
+
+// referringFields returns the Group and Profile fields which may reference a
+// resource of the given kind.
+func referringFields(kind string) []string {
+	switch kind {
+	case storage.KindProfile:
+		return []string{storage.FieldProfile, storage.FieldParent}
+	case storage.KindIgnition:
+		return []string{storage.FieldIgnitionId}
+	case storage.KindGeneric:
+		return []string{storage.FieldGenericId}
+	case storage.KindCloud:
+		return []string{storage.FieldCloudId}
+	}
+	return nil
+}
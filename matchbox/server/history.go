@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// authorKey is the context key of the identity of the client making a
+// request.
+type authorKey struct{}
+
+// WithAuthor returns a copy of ctx which identifies the client making a
+// request (e.g. by certificate CN). Revisions written with the context are
+// recorded with the author.
+func WithAuthor(ctx context.Context, author string) context.Context {
+	return context.WithValue(ctx, authorKey{}, author)
+}
+
+// authorFromContext returns the identity of the client making a request, if
+// known.
+func authorFromContext(ctx context.Context) string {
+	author, _ := ctx.Value(authorKey{}).(string)
+	return author
+}
+
+// HistoryList lists the kept revisions of a Group, Profile, or template,
+// oldest first.
+func (s *server) HistoryList(ctx context.Context, req *pb.HistoryListRequest) ([]*storagepb.Revision, error) {
+	return s.history.RevisionList(req.Kind, req.Name)
+}
+
+// Rollback restores a Group, Profile, or template to a kept revision, which
+// is validated and recorded as a new revision. Rolling back to a deleted
+// revision deletes the resource. Returns ErrRevisionNotFound if the revision
+// isn't kept.
+func (s *server) Rollback(ctx context.Context, req *pb.HistoryRollbackRequest) error {
+	revisions, err := s.history.RevisionList(req.Kind, req.Name)
+	if err != nil {
+		return err
+	}
+	var revision *storagepb.Revision
+	for _, r := range revisions {
+		if r.Revision == req.Revision {
+			revision = r
+			break
+		}
+	}
+	if revision == nil {
+		return storage.ErrRevisionNotFound
+	}
+
+	batch := &storagepb.Batch{}
+	switch {
+	case revision.Deleted:
+		batch.Deletes = []*storagepb.Deletion{{Kind: req.Kind, Name: req.Name}}
+	case req.Kind == storage.KindGroup:
+		batch.Groups = []*storagepb.Group{revision.Group}
+	case req.Kind == storage.KindProfile:
+		batch.Profiles = []*storagepb.Profile{revision.Profile}
+	default:
+		template := &storagepb.Template{Name: req.Name, Contents: revision.Contents}
+		switch req.Kind {
+		case storage.KindIgnition:
+			batch.Ignition = []*storagepb.Template{template}
+		case storage.KindGeneric:
+			batch.Generic = []*storagepb.Template{template}
+		case storage.KindCloud:
+			batch.Cloud = []*storagepb.Template{template}
+		case storage.KindPartial:
+			batch.Partials = []*storagepb.Template{template}
+		}
+	}
+	return s.Apply(ctx, &pb.BatchApplyRequest{Batch: batch, Force: req.Force})
+}
+
+// record records a revision of a resource written with the ctx.
+func (s *server) record(ctx context.Context, kind, name string, revision *storagepb.Revision) error {
+	revision.Timestamp = time.Now().Unix()
+	revision.Author = authorFromContext(ctx)
+	// record the resource as written, not the version it replaced
+	if revision.Group != nil {
+		revision.Group = revision.Group.Copy()
+		revision.Group.ResourceVersion = ""
+	}
+	if revision.Profile != nil {
+		revision.Profile = revision.Profile.Copy()
+		revision.Profile.ResourceVersion = ""
+	}
+	return s.history.RevisionAdd(kind, name, revision)
+}
+
+// recordBatch records a revision of each resource written or deleted by a
+// Batch.
+func (s *server) recordBatch(ctx context.Context, batch *storagepb.Batch) error {
+	for _, group := range batch.Groups {
+		if err := s.record(ctx, storage.KindGroup, group.Id, &storagepb.Revision{Group: group}); err != nil {
+			return err
+		}
+	}
+	for _, profile := range batch.Profiles {
+		if err := s.record(ctx, storage.KindProfile, profile.Id, &storagepb.Revision{Profile: profile}); err != nil {
+			return err
+		}
+	}
+	templates := []struct {
+		kind      string
+		templates []*storagepb.Template
+	}{
+		{storage.KindIgnition, batch.Ignition},
+		{storage.KindGeneric, batch.Generic},
+		{storage.KindCloud, batch.Cloud},
+		{storage.KindPartial, batch.Partials},
+	}
+	for _, t := range templates {
+		for _, template := range t.templates {
+			if err := s.record(ctx, t.kind, template.Name, &storagepb.Revision{Contents: template.Contents}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, deletion := range batch.Deletes {
+		if err := s.record(ctx, deletion.Kind, deletion.Name, &storagepb.Revision{Deleted: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// ReferenceError is returned when a write would leave references between
+// Groups, Profiles, and templates dangling.
+type ReferenceError struct {
+	// Message describes the failed write
+	Message string
+	// References are the offending references
+	References []*storage.Reference
+}
+
+func (e *ReferenceError) Error() string {
+	if len(e.References) == 0 {
+		return "matchbox: " + e.Message
+	}
+	refs := make([]string, len(e.References))
+	for i, ref := range e.References {
+		refs[i] = ref.String()
+	}
+	return "matchbox: " + e.Message + ": " + strings.Join(refs, ", ")
+}
+
+// checkDangling returns a ReferenceError if any of the references are
+// dangling, or the error if their targets can't be read.
+func (s *server) checkDangling(refs []*storage.Reference) error {
+	dangling, err := storage.Dangling(s.store, refs)
+	if err != nil {
+		return err
+	}
+	if len(dangling) > 0 {
+		return &ReferenceError{Message: "references missing resources", References: dangling}
+	}
+	return nil
+}
+
+// checkParents returns ErrProfileCycle if the Profile would inherit from
+// itself through its chain of parent Profiles. Missing ancestors end the
+// chain, since dangling references are checked separately.
+func (s *server) checkParents(profile *storagepb.Profile) error {
+	seen := map[string]bool{profile.Id: true}
+	for parent := profile.Parent; parent != ""; {
+		if seen[parent] {
+			return storagepb.ErrProfileCycle
+		}
+		seen[parent] = true
+		ancestor, err := s.store.ProfileGet(parent)
+		if err != nil {
+			return nil
+		}
+		parent = ancestor.Parent
+	}
+	return nil
+}
+
+// checkReferrers returns a ReferenceError if any Groups or Profiles still
+// reference the target by one of the given fields, unless forced.
+func (s *server) checkReferrers(force bool, target string, fields ...string) error {
+	if force {
+		return nil
+	}
+	referrers, err := storage.Referrers(s.store, target, fields...)
+	if err != nil {
+		return err
+	}
+	if len(referrers) > 0 {
+		return &ReferenceError{Message: target + " is still referenced", References: referrers}
+	}
+	return nil
+}
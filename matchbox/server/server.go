@@ -2,7 +2,8 @@ package server
 
 import (
 	"errors"
-	"sort"
+	"io"
+	"time"
 
 	"context"
 
@@ -23,6 +24,8 @@ type Server interface {
 	SelectGroup(context.Context, *pb.SelectGroupRequest) (*storagepb.Group, error)
 	// SelectProfile returns the Profile matching the given labels.
 	SelectProfile(context.Context, *pb.SelectProfileRequest) (*storagepb.Profile, error)
+	// Explain explains how each Group is evaluated against the given labels.
+	Explain(context.Context, *pb.SelectExplainRequest) (*pb.SelectExplainResponse, error)
 
 	// Create or update a Group.
 	GroupPut(context.Context, *pb.GroupPutRequest) (*storagepb.Group, error)
@@ -42,12 +45,14 @@ type Server interface {
 	// List all Profiles.
 	ProfileList(context.Context, *pb.ProfileListRequest) ([]*storagepb.Profile, error)
 
-	// Create or update an Ignition template.
+	// Validate and create or update an Ignition template.
 	IgnitionPut(context.Context, *pb.IgnitionPutRequest) (string, error)
 	// Get an Ignition template by name.
 	IgnitionGet(context.Context, *pb.IgnitionGetRequest) (string, error)
 	// Delete an Ignition template by name.
 	IgnitionDelete(context.Context, *pb.IgnitionDeleteRequest) error
+	// List all Ignition templates.
+	IgnitionList(context.Context, *pb.IgnitionListRequest) ([]*storagepb.TemplateInfo, error)
 
 	// Create or update an Generic template.
 	GenericPut(context.Context, *pb.GenericPutRequest) (string, error)
@@ -55,25 +60,99 @@ type Server interface {
 	GenericGet(context.Context, *pb.GenericGetRequest) (string, error)
 	// Delete an Generic template by name.
 	GenericDelete(context.Context, *pb.GenericDeleteRequest) error
+	// List all Generic templates.
+	GenericList(context.Context, *pb.GenericListRequest) ([]*storagepb.TemplateInfo, error)
 
+	// Create or update a Cloud-Config template.
+	CloudPut(context.Context, *pb.CloudPutRequest) (string, error)
 	// Get a Cloud-Config template by name.
-	CloudGet(ctx context.Context, name string) (string, error)
+	CloudGet(context.Context, *pb.CloudGetRequest) (string, error)
+	// Delete a Cloud-Config template by name.
+	CloudDelete(context.Context, *pb.CloudDeleteRequest) error
+	// List all Cloud-Config templates.
+	CloudList(context.Context, *pb.CloudListRequest) ([]*storagepb.TemplateInfo, error)
+
+	// Create or update a template partial.
+	PartialPut(context.Context, *pb.PartialPutRequest) (string, error)
+	// Get a template partial by name.
+	PartialGet(context.Context, *pb.PartialGetRequest) (string, error)
+	// Delete a template partial by name.
+	PartialDelete(context.Context, *pb.PartialDeleteRequest) error
+	// List the names of all template partials.
+	PartialList(context.Context, *pb.PartialListRequest) ([]string, error)
+	// Get all template partials by name, for rendering. The map must not be
+	// modified.
+	Partials(context.Context) (map[string]string, error)
+
+	// Record a machine Instance request.
+	InstanceRecord(context.Context, *pb.InstanceRecordRequest) (*storagepb.Instance, error)
+	// Get an observed machine Instance by id.
+	InstanceGet(context.Context, *pb.InstanceGetRequest) (*storagepb.Instance, error)
+	// List all observed machine Instances.
+	InstanceList(context.Context, *pb.InstanceListRequest) ([]*storagepb.Instance, error)
+
+	// List stored resources which can't be parsed.
+	StatusGet(context.Context, *pb.StatusGetRequest) ([]*storagepb.InvalidResource, error)
+
+	// Validate and apply a Batch of resources together.
+	Apply(context.Context, *pb.BatchApplyRequest) error
+
+	// Write an archive of all Groups, Profiles, and templates.
+	Export(context.Context, io.Writer) error
+	// Import the resources of an archive together.
+	Import(context.Context, io.Reader) error
+
+	// List the kept revisions of a Group, Profile, or template.
+	HistoryList(context.Context, *pb.HistoryListRequest) ([]*storagepb.Revision, error)
+	// Restore a Group, Profile, or template to a kept revision.
+	Rollback(context.Context, *pb.HistoryRollbackRequest) error
+
+	// Record a call which wrote or deleted resources in the audit log.
+	AuditRecord(context.Context, *storagepb.AuditEntry) error
+	// List audit log entries.
+	AuditList(context.Context, *pb.AuditListRequest) ([]*storagepb.AuditEntry, error)
 }
 
 // Config configures a server implementation.
 type Config struct {
 	Store storage.Store
+	// Instances stores observed machines (defaults to in-memory, keeping up
+	// to DefaultInstances)
+	Instances storage.InstanceStore
+	// History keeps revisions of resources (defaults to in-memory)
+	History storage.HistoryStore
+	// Audit logs calls which write or delete resources (defaults to
+	// in-memory)
+	Audit storage.AuditStore
 }
 
 // server implements the Server interface.
 type server struct {
-	store storage.Store
+	store     storage.Store
+	instances storage.InstanceStore
+	history   storage.HistoryStore
+	audit     storage.AuditStore
 }
 
 // NewServer returns a new Server.
 func NewServer(config *Config) Server {
+	instances := config.Instances
+	if instances == nil {
+		instances = storage.NewMemInstanceStore(storage.DefaultInstances)
+	}
+	history := config.History
+	if history == nil {
+		history = storage.NewMemHistoryStore(storage.DefaultRevisions)
+	}
+	audit := config.Audit
+	if audit == nil {
+		audit = storage.NewMemAuditStore()
+	}
 	return &server{
-		store: config.Store,
+		store:     config.Store,
+		instances: instances,
+		history:   history,
+		audit:     audit,
 	}
 }
 
@@ -81,11 +160,22 @@ func (s *server) GroupPut(ctx context.Context, req *pb.GroupPutRequest) (*storag
 	if err := req.Group.AssertValid(); err != nil {
 		return nil, err
 	}
+	if err := s.checkDangling(storage.GroupReferences(req.Group)); err != nil {
+		return nil, err
+	}
 	err := s.store.GroupPut(req.Group)
 	if err != nil {
 		return nil, err
 	}
-	return req.Group, nil
+	if err := s.record(ctx, storage.KindGroup, req.Group.Id, &storagepb.Revision{Group: req.Group}); err != nil {
+		return nil, err
+	}
+	group := req.Group.Copy()
+	group.ResourceVersion, err = storage.GroupVersion(group)
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
 }
 
 func (s *server) GroupGet(ctx context.Context, req *pb.GroupGetRequest) (*storagepb.Group, error) {
@@ -97,7 +187,10 @@ func (s *server) GroupGet(ctx context.Context, req *pb.GroupGetRequest) (*storag
 }
 
 func (s *server) GroupDelete(ctx context.Context, req *pb.GroupDeleteRequest) error {
-	return s.store.GroupDelete(req.Id)
+	if err := s.store.GroupDelete(req.Id, req.ResourceVersion); err != nil {
+		return err
+	}
+	return s.record(ctx, storage.KindGroup, req.Id, &storagepb.Revision{Deleted: true})
 }
 
 func (s *server) GroupList(ctx context.Context, req *pb.GroupListRequest) ([]*storagepb.Group, error) {
@@ -112,11 +205,25 @@ func (s *server) ProfilePut(ctx context.Context, req *pb.ProfilePutRequest) (*st
 	if err := req.Profile.AssertValid(); err != nil {
 		return nil, err
 	}
+	if err := s.checkDangling(storage.ProfileReferences(req.Profile)); err != nil {
+		return nil, err
+	}
+	if err := s.checkParents(req.Profile); err != nil {
+		return nil, err
+	}
 	err := s.store.ProfilePut(req.Profile)
 	if err != nil {
 		return nil, err
 	}
-	return req.Profile, nil
+	if err := s.record(ctx, storage.KindProfile, req.Profile.Id, &storagepb.Revision{Profile: req.Profile}); err != nil {
+		return nil, err
+	}
+	profile := req.Profile.Copy()
+	profile.ResourceVersion, err = storage.ProfileVersion(profile)
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
 }
 
 func (s *server) ProfileGet(ctx context.Context, req *pb.ProfileGetRequest) (*storagepb.Profile, error) {
@@ -127,11 +234,42 @@ func (s *server) ProfileGet(ctx context.Context, req *pb.ProfileGetRequest) (*st
 	if err := profile.AssertValid(); err != nil {
 		return nil, err
 	}
-	return profile, nil
+	return s.resolveProfile(profile)
+}
+
+// resolveProfile returns the Profile with fields inherited from its chain of
+// parent Profiles. Returns ErrProfileCycle if a Profile inherits from itself.
+func (s *server) resolveProfile(profile *storagepb.Profile) (*storagepb.Profile, error) {
+	chain := []*storagepb.Profile{profile}
+	seen := map[string]bool{profile.Id: true}
+	for parent := profile.Parent; parent != ""; {
+		if seen[parent] {
+			return nil, storagepb.ErrProfileCycle
+		}
+		seen[parent] = true
+		ancestor, err := s.store.ProfileGet(parent)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, ancestor)
+		parent = ancestor.Parent
+	}
+	// inherit from the root ancestor down to the Profile
+	resolved := chain[len(chain)-1].Resolve()
+	for i := len(chain) - 2; i >= 0; i-- {
+		resolved = chain[i].Inherit(resolved)
+	}
+	return resolved, nil
 }
 
 func (s *server) ProfileDelete(ctx context.Context, req *pb.ProfileDeleteRequest) error {
-	return s.store.ProfileDelete(req.Id)
+	if err := s.checkReferrers(req.Force, req.Id, storage.FieldProfile, storage.FieldParent); err != nil {
+		return err
+	}
+	if err := s.store.ProfileDelete(req.Id, req.ResourceVersion); err != nil {
+		return err
+	}
+	return s.record(ctx, storage.KindProfile, req.Id, &storagepb.Revision{Deleted: true})
 }
 
 func (s *server) ProfileList(ctx context.Context, req *pb.ProfileListRequest) ([]*storagepb.Profile, error) {
@@ -142,23 +280,37 @@ func (s *server) ProfileList(ctx context.Context, req *pb.ProfileListRequest) ([
 	return profiles, nil
 }
 
-// SelectGroup selects the Group whose selector matches the given labels.
-// Groups are evaluated in sorted order from most selectors to least, using
-// alphabetical order as a deterministic tie-breaker.
+// SelectGroup selects the Group matching the given labels. Groups are
+// evaluated from highest Priority to lowest, then from most requirements to
+// fewest (see storagepb.ByReqs). Returns an InvalidResourcesError if any
+// stored Groups can't be parsed, since one of them might have matched instead.
 func (s *server) SelectGroup(ctx context.Context, req *pb.SelectGroupRequest) (*storagepb.Group, error) {
-	groups, err := s.store.GroupList()
+	if err := s.checkGroupsValid(); err != nil {
+		return nil, err
+	}
+	index, err := s.groupIndex()
 	if err != nil {
 		return nil, err
 	}
-	sort.Sort(sort.Reverse(storagepb.ByReqs(groups)))
-	for _, group := range groups {
-		if group.Matches(req.Labels) {
-			return group, nil
-		}
+	if group := index.Select(req.Labels); group != nil {
+		return group, nil
 	}
 	return nil, ErrNoMatchingGroup
 }
 
+// groupIndex returns an index of all Groups in selection order, from the
+// Store if it maintains one.
+func (s *server) groupIndex() (*storagepb.GroupIndex, error) {
+	if indexer, ok := s.store.(storage.GroupIndexer); ok {
+		return indexer.GroupIndex()
+	}
+	groups, err := s.store.GroupList()
+	if err != nil {
+		return nil, err
+	}
+	return storagepb.NewGroupIndex(groups), nil
+}
+
 func (s *server) SelectProfile(ctx context.Context, req *pb.SelectProfileRequest) (*storagepb.Profile, error) {
 	group, err := s.SelectGroup(ctx, &pb.SelectGroupRequest{Labels: req.Labels})
 	if err == nil {
@@ -169,16 +321,61 @@ func (s *server) SelectProfile(ctx context.Context, req *pb.SelectProfileRequest
 		}
 		return nil, ErrNoMatchingProfile
 	}
+	if _, ok := err.(*InvalidResourcesError); ok {
+		return nil, err
+	}
 	return nil, ErrNoMatchingGroup
 }
 
-// IgnitionPut creates or updates an Ignition template by name.
+// Explain explains how each Group, in order of evaluation, is evaluated
+// against the given labels and which Group and Profile would be selected.
+func (s *server) Explain(ctx context.Context, req *pb.SelectExplainRequest) (*pb.SelectExplainResponse, error) {
+	index, err := s.groupIndex()
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.SelectExplainResponse{}
+	selected, err := s.SelectGroup(ctx, &pb.SelectGroupRequest{Labels: req.Labels})
+	switch err {
+	case nil:
+		resp.Group = selected
+		// a missing Profile is explained by its absence
+		resp.Profile, _ = s.ProfileGet(ctx, &pb.ProfileGetRequest{Id: selected.Profile})
+	case ErrNoMatchingGroup:
+	default:
+		return nil, err
+	}
+
+	for i, group := range index.Groups() {
+		resp.Groups = append(resp.Groups, &pb.GroupExplanation{
+			Group:             group,
+			Rank:              int32(i),
+			Matched:           group.Matches(req.Labels),
+			Selected:          selected != nil && group.Id == selected.Id,
+			FailedRequirement: group.FailedRequirement(req.Labels),
+		})
+	}
+	return resp, nil
+}
+
+// IgnitionPut validates and creates or updates an Ignition template by name.
+// Returns an InvalidTemplateError if the template is invalid. Dry run
+// requests are validated, but not stored.
 func (s *server) IgnitionPut(ctx context.Context, req *pb.IgnitionPutRequest) (string, error) {
-	err := s.store.IgnitionPut(req.Name, req.Config)
+	if err := s.validateIgnition(req.Name, req.Config, req.Labels); err != nil {
+		return "", err
+	}
+	if req.DryRun {
+		return string(req.Config), nil
+	}
+	err := s.store.IgnitionPut(req.Name, req.Config, req.ResourceVersion)
 	if err != nil {
 		return "", err
 	}
-	return string(req.Config), err
+	if err := s.record(ctx, storage.KindIgnition, req.Name, &storagepb.Revision{Contents: req.Config}); err != nil {
+		return "", err
+	}
+	return string(req.Config), nil
 }
 
 // IgnitionGet gets an Ignition template by name.
@@ -186,18 +383,37 @@ func (s *server) IgnitionGet(ctx context.Context, req *pb.IgnitionGetRequest) (s
 	return s.store.IgnitionGet(req.Name)
 }
 
-// IgnitionDelete deletes an Ignition template by name.
+// IgnitionDelete deletes an Ignition template by name. Returns a
+// ReferenceError if Profiles still reference the template, unless forced.
 func (s *server) IgnitionDelete(ctx context.Context, req *pb.IgnitionDeleteRequest) error {
-	return s.store.IgnitionDelete(req.Name)
+	if err := s.checkReferrers(req.Force, req.Name, storage.FieldIgnitionId); err != nil {
+		return err
+	}
+	if err := s.store.IgnitionDelete(req.Name, req.ResourceVersion); err != nil {
+		return err
+	}
+	return s.record(ctx, storage.KindIgnition, req.Name, &storagepb.Revision{Deleted: true})
+}
+
+// IgnitionList lists all Ignition templates.
+func (s *server) IgnitionList(ctx context.Context, req *pb.IgnitionListRequest) ([]*storagepb.TemplateInfo, error) {
+	return s.store.IgnitionList()
 }
 
-// GenericPut creates or updates an Generic template by name.
+// GenericPut creates or updates an Generic template by name. Returns an
+// InvalidTemplateError if the template can't be parsed.
 func (s *server) GenericPut(ctx context.Context, req *pb.GenericPutRequest) (string, error) {
-	err := s.store.GenericPut(req.Name, req.Config)
+	if err := s.validateTemplate(req.Name, req.Config); err != nil {
+		return "", err
+	}
+	err := s.store.GenericPut(req.Name, req.Config, req.ResourceVersion)
 	if err != nil {
 		return "", err
 	}
-	return string(req.Config), err
+	if err := s.record(ctx, storage.KindGeneric, req.Name, &storagepb.Revision{Contents: req.Config}); err != nil {
+		return "", err
+	}
+	return string(req.Config), nil
 }
 
 // GenericGet gets an Generic template by name.
@@ -205,12 +421,132 @@ func (s *server) GenericGet(ctx context.Context, req *pb.GenericGetRequest) (str
 	return s.store.GenericGet(req.Name)
 }
 
-// GenericDelete deletes an Generic template by name.
+// GenericDelete deletes an Generic template by name. Returns a
+// ReferenceError if Profiles still reference the template, unless forced.
 func (s *server) GenericDelete(ctx context.Context, req *pb.GenericDeleteRequest) error {
-	return s.store.GenericDelete(req.Name)
+	if err := s.checkReferrers(req.Force, req.Name, storage.FieldGenericId); err != nil {
+		return err
+	}
+	if err := s.store.GenericDelete(req.Name, req.ResourceVersion); err != nil {
+		return err
+	}
+	return s.record(ctx, storage.KindGeneric, req.Name, &storagepb.Revision{Deleted: true})
+}
+
+// GenericList lists all Generic templates.
+func (s *server) GenericList(ctx context.Context, req *pb.GenericListRequest) ([]*storagepb.TemplateInfo, error) {
+	return s.store.GenericList()
+}
+
+// CloudPut creates or updates a Cloud-Config template by name. Returns an
+// InvalidTemplateError if the template can't be parsed.
+func (s *server) CloudPut(ctx context.Context, req *pb.CloudPutRequest) (string, error) {
+	if err := s.validateTemplate(req.Name, req.Config); err != nil {
+		return "", err
+	}
+	err := s.store.CloudPut(req.Name, req.Config, req.ResourceVersion)
+	if err != nil {
+		return "", err
+	}
+	if err := s.record(ctx, storage.KindCloud, req.Name, &storagepb.Revision{Contents: req.Config}); err != nil {
+		return "", err
+	}
+	return string(req.Config), nil
 }
 
 // CloudGet gets a Cloud-Config template by name.
-func (s *server) CloudGet(ctx context.Context, name string) (string, error) {
-	return s.store.CloudGet(name)
+func (s *server) CloudGet(ctx context.Context, req *pb.CloudGetRequest) (string, error) {
+	return s.store.CloudGet(req.Name)
+}
+
+// CloudDelete deletes a Cloud-Config template by name. Returns a
+// ReferenceError if Profiles still reference the template, unless forced.
+func (s *server) CloudDelete(ctx context.Context, req *pb.CloudDeleteRequest) error {
+	if err := s.checkReferrers(req.Force, req.Name, storage.FieldCloudId); err != nil {
+		return err
+	}
+	if err := s.store.CloudDelete(req.Name, req.ResourceVersion); err != nil {
+		return err
+	}
+	return s.record(ctx, storage.KindCloud, req.Name, &storagepb.Revision{Deleted: true})
+}
+
+// CloudList lists all Cloud-Config templates.
+func (s *server) CloudList(ctx context.Context, req *pb.CloudListRequest) ([]*storagepb.TemplateInfo, error) {
+	return s.store.CloudList()
+}
+
+// PartialPut creates or updates a template partial by name. Returns an
+// InvalidTemplateError if the partial can't be parsed.
+func (s *server) PartialPut(ctx context.Context, req *pb.PartialPutRequest) (string, error) {
+	if err := validatePartial(req.Name, req.Config); err != nil {
+		return "", err
+	}
+	err := s.store.PartialPut(req.Name, req.Config, req.ResourceVersion)
+	if err != nil {
+		return "", err
+	}
+	if err := s.record(ctx, storage.KindPartial, req.Name, &storagepb.Revision{Contents: req.Config}); err != nil {
+		return "", err
+	}
+	return string(req.Config), nil
+}
+
+// PartialGet gets a template partial by name.
+func (s *server) PartialGet(ctx context.Context, req *pb.PartialGetRequest) (string, error) {
+	return s.store.PartialGet(req.Name)
+}
+
+// PartialDelete deletes a template partial by name.
+func (s *server) PartialDelete(ctx context.Context, req *pb.PartialDeleteRequest) error {
+	if err := s.store.PartialDelete(req.Name, req.ResourceVersion); err != nil {
+		return err
+	}
+	return s.record(ctx, storage.KindPartial, req.Name, &storagepb.Revision{Deleted: true})
+}
+
+// PartialList lists the names of all template partials.
+func (s *server) PartialList(ctx context.Context, req *pb.PartialListRequest) ([]string, error) {
+	return s.store.PartialList()
+}
+
+// Partials gets all template partials by name.
+func (s *server) Partials(ctx context.Context) (map[string]string, error) {
+	return storage.Partials(s.store)
+}
+
+// InstanceRecord records a request from the machine identified by the given
+// labels, along with the matched Group and Profile (if any). The first seen
+// time of previously observed machines is preserved.
+func (s *server) InstanceRecord(ctx context.Context, req *pb.InstanceRecordRequest) (*storagepb.Instance, error) {
+	now := time.Now().Unix()
+	instance := &storagepb.Instance{
+		Id:        storagepb.InstanceId(req.Labels),
+		Labels:    req.Labels,
+		Group:     req.Group,
+		Profile:   req.Profile,
+		Endpoint:  req.Endpoint,
+		FirstSeen: now,
+		LastSeen:  now,
+	}
+	if err := instance.AssertValid(); err != nil {
+		return nil, err
+	}
+	if prev, err := s.instances.InstanceGet(instance.Id); err == nil {
+		instance.FirstSeen = prev.FirstSeen
+	}
+	if err := s.instances.InstancePut(instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// InstanceGet gets an observed machine Instance by id.
+func (s *server) InstanceGet(ctx context.Context, req *pb.InstanceGetRequest) (*storagepb.Instance, error) {
+	return s.instances.InstanceGet(req.Id)
+}
+
+// InstanceList lists all observed machine Instances.
+func (s *server) InstanceList(ctx context.Context, req *pb.InstanceListRequest) ([]*storagepb.Instance, error) {
+	return s.instances.InstanceList()
 }
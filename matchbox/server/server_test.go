@@ -1,6 +1,10 @@
 package server
 
 import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"context"
@@ -29,7 +33,7 @@ func TestSelectGroup(t *testing.T) {
 		{&fake.EmptyStore{}, map[string]string{"a": "b"}, nil, ErrNoMatchingGroup},
 	}
 	for _, c := range cases {
-		srv := NewServer(&Config{c.store})
+		srv := NewServer(&Config{Store: c.store})
 		group, err := srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: c.labels})
 		if assert.Equal(t, c.err, err) {
 			assert.Equal(t, c.group, group)
@@ -37,6 +41,33 @@ func TestSelectGroup(t *testing.T) {
 	}
 }
 
+func TestSelect_InvalidGroups(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Groups[fake.Group.Id] = fake.Group
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	store.InvalidResources = []*storagepb.InvalidResource{
+		{Kind: "Group", Id: "truncated", Error: "unexpected end of JSON input"},
+	}
+	srv := NewServer(&Config{Store: store})
+	// assert that:
+	// - Groups aren't selected while any stored Group can't be parsed
+	// - unparsable resources are reported by StatusGet
+	expected := &InvalidResourcesError{Resources: store.InvalidResources}
+	_, err := srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: map[string]string{"uuid": "a1b2c3d4"}})
+	assert.Equal(t, expected, err)
+	_, err = srv.SelectProfile(context.Background(), &pb.SelectProfileRequest{Labels: map[string]string{"uuid": "a1b2c3d4"}})
+	assert.Equal(t, expected, err)
+	invalid, err := srv.StatusGet(context.Background(), &pb.StatusGetRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, store.InvalidResources, invalid)
+
+	// unparsable Profiles don't affect Group selection
+	store.InvalidResources[0].Kind = "Profile"
+	group, err := srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: map[string]string{"uuid": "a1b2c3d4"}})
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group, group)
+}
+
 func TestSelectProfile(t *testing.T) {
 	store := &fake.FixedStore{
 		Groups:   map[string]*storagepb.Group{fake.Group.Id: fake.Group},
@@ -60,7 +91,7 @@ func TestSelectProfile(t *testing.T) {
 		{&fake.EmptyStore{}, map[string]string{"a": "b"}, nil, ErrNoMatchingGroup},
 	}
 	for _, c := range cases {
-		srv := NewServer(&Config{c.store})
+		srv := NewServer(&Config{Store: c.store})
 		profile, err := srv.SelectProfile(context.Background(), &pb.SelectProfileRequest{Labels: c.labels})
 		if assert.Equal(t, c.err, err) {
 			assert.Equal(t, c.profile, profile)
@@ -68,8 +99,42 @@ func TestSelectProfile(t *testing.T) {
 	}
 }
 
+func TestExplain(t *testing.T) {
+	byRegion := &storagepb.Group{Id: "region", Profile: "missing", Selector: map[string]string{"region": "a"}}
+	store := &fake.FixedStore{
+		Groups:   map[string]*storagepb.Group{fake.Group.Id: fake.Group, byRegion.Id: byRegion},
+		Profiles: map[string]*storagepb.Profile{fake.Group.Profile: fake.Profile},
+	}
+	srv := NewServer(&Config{Store: store})
+	// assert that:
+	// - every Group is explained in order of evaluation
+	// - the selected Group and its Profile are reported
+	resp, err := srv.Explain(context.Background(), &pb.SelectExplainRequest{Labels: map[string]string{"uuid": "a1b2c3d4"}})
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group, resp.Group)
+	assert.Equal(t, fake.Profile, resp.Profile)
+	assert.Equal(t, []*pb.GroupExplanation{
+		{Group: fake.Group, Rank: 0, Matched: true, Selected: true},
+		{Group: byRegion, Rank: 1, FailedRequirement: "region=a"},
+	}, resp.Groups)
+
+	// assert that a selected Group with a missing Profile is explained
+	resp, err = srv.Explain(context.Background(), &pb.SelectExplainRequest{Labels: map[string]string{"region": "a"}})
+	assert.Nil(t, err)
+	assert.Equal(t, byRegion, resp.Group)
+	assert.Nil(t, resp.Profile)
+
+	// assert that an explanation is returned when no Group matches
+	resp, err = srv.Explain(context.Background(), &pb.SelectExplainRequest{})
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Group)
+	assert.Equal(t, 2, len(resp.Groups))
+}
+
 func TestGroupCRUD(t *testing.T) {
-	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	store := fake.NewFixedStore()
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	srv := NewServer(&Config{Store: store})
 	_, err := srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
 	// assert that:
 	// - Group creation is successful
@@ -94,11 +159,32 @@ func TestGroupCreate_Invalid(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGroupCreate_InvalidPriority(t *testing.T) {
+	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	invalid := &storagepb.Group{Id: "node1", Profile: "g1h2i3j4", Priority: -1}
+	_, err := srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: invalid})
+	assert.Equal(t, storagepb.ErrInvalidPriority, err)
+}
+
+func TestSelectGroup_Priority(t *testing.T) {
+	catchAll := &storagepb.Group{Id: "catch-all", Profile: "a", Priority: 1}
+	stale := &storagepb.Group{Id: "stale", Profile: "b", Selector: map[string]string{"region": "a", "zone": "z"}}
+	store := &fake.FixedStore{
+		Groups: map[string]*storagepb.Group{catchAll.Id: catchAll, stale.Id: stale},
+	}
+	srv := NewServer(&Config{Store: store})
+	// assert that a Group with a higher Priority is selected over a Group
+	// with more selectors
+	group, err := srv.SelectGroup(context.Background(), &pb.SelectGroupRequest{Labels: map[string]string{"region": "a", "zone": "z"}})
+	assert.Nil(t, err)
+	assert.Equal(t, catchAll, group)
+}
+
 func TestGroupList(t *testing.T) {
 	store := &fake.FixedStore{
 		Groups: map[string]*storagepb.Group{fake.Group.Id: fake.Group},
 	}
-	srv := NewServer(&Config{store})
+	srv := NewServer(&Config{Store: store})
 	groups, err := srv.GroupList(context.Background(), &pb.GroupListRequest{})
 	assert.Nil(t, err)
 	if assert.Equal(t, 1, len(groups)) {
@@ -107,7 +193,7 @@ func TestGroupList(t *testing.T) {
 }
 
 func TestGroup_BrokenStore(t *testing.T) {
-	srv := NewServer(&Config{&fake.BrokenStore{}})
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
 	_, err := srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
 	assert.Error(t, err)
 	_, err = srv.GroupGet(context.Background(), &pb.GroupGetRequest{Id: fake.Group.Id})
@@ -119,7 +205,11 @@ func TestGroup_BrokenStore(t *testing.T) {
 }
 
 func TestProfileCRUD(t *testing.T) {
-	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	store := fake.NewFixedStore()
+	store.IgnitionConfigs[fake.Profile.IgnitionId] = fake.IgnitionYAML
+	store.GenericConfigs[fake.Profile.GenericId] = fake.Generic
+	store.CloudConfigs[fake.Profile.CloudId] = "#cloud-config"
+	srv := NewServer(&Config{Store: store})
 	_, err := srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: fake.Profile})
 	// assert that:
 	// - Profile creation is successful
@@ -137,6 +227,110 @@ func TestProfileCRUD(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestPut_MissingReferences(t *testing.T) {
+	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	// assert that:
+	// - Groups must reference an existing Profile
+	// - Profiles must reference an existing parent Profile and templates
+	_, err := srv.GroupPut(context.Background(), &pb.GroupPutRequest{Group: fake.Group})
+	if assert.IsType(t, &ReferenceError{}, err) {
+		assert.Equal(t, "matchbox: references missing resources: Group test-group profile=g1h2i3j4", err.Error())
+	}
+	profile := &storagepb.Profile{Id: "child", Parent: "parent", IgnitionId: "missing.yaml"}
+	_, err = srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: profile})
+	if assert.IsType(t, &ReferenceError{}, err) {
+		assert.Equal(t, 2, len(err.(*ReferenceError).References))
+	}
+}
+
+func TestDelete_Referenced(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Groups[fake.Group.Id] = fake.Group
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	store.IgnitionConfigs[fake.Profile.IgnitionId] = fake.IgnitionYAML
+	store.GenericConfigs[fake.Profile.GenericId] = fake.Generic
+	store.CloudConfigs[fake.Profile.CloudId] = "#cloud-config"
+	srv := NewServer(&Config{Store: store})
+	ctx := context.Background()
+	// assert that:
+	// - referenced Profiles and templates can't be deleted
+	// - referenced Profiles and templates can be deleted by force
+	// - templates can be deleted once no longer referenced
+	err := srv.ProfileDelete(ctx, &pb.ProfileDeleteRequest{Id: fake.Profile.Id})
+	if assert.IsType(t, &ReferenceError{}, err) {
+		assert.Equal(t, "matchbox: g1h2i3j4 is still referenced: Group test-group profile=g1h2i3j4", err.Error())
+	}
+	err = srv.IgnitionDelete(ctx, &pb.IgnitionDeleteRequest{Name: fake.Profile.IgnitionId})
+	assert.IsType(t, &ReferenceError{}, err)
+	err = srv.GenericDelete(ctx, &pb.GenericDeleteRequest{Name: fake.Profile.GenericId})
+	assert.IsType(t, &ReferenceError{}, err)
+	err = srv.CloudDelete(ctx, &pb.CloudDeleteRequest{Name: fake.Profile.CloudId})
+	assert.IsType(t, &ReferenceError{}, err)
+	assert.Equal(t, 1, len(store.Profiles))
+	assert.Equal(t, 1, len(store.IgnitionConfigs))
+	assert.Equal(t, 1, len(store.GenericConfigs))
+	assert.Equal(t, 1, len(store.CloudConfigs))
+
+	err = srv.ProfileDelete(ctx, &pb.ProfileDeleteRequest{Id: fake.Profile.Id, Force: true})
+	assert.Nil(t, err)
+	err = srv.IgnitionDelete(ctx, &pb.IgnitionDeleteRequest{Name: fake.Profile.IgnitionId, Force: true})
+	assert.Nil(t, err)
+	err = srv.GenericDelete(ctx, &pb.GenericDeleteRequest{Name: fake.Profile.GenericId})
+	assert.Nil(t, err)
+	err = srv.CloudDelete(ctx, &pb.CloudDeleteRequest{Name: fake.Profile.CloudId})
+	assert.Nil(t, err)
+}
+
+func TestPut_VersionConflict(t *testing.T) {
+	dir, err := ioutil.TempDir("", "matchbox")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	for _, kind := range []string{"groups", "profiles"} {
+		assert.Nil(t, os.Mkdir(filepath.Join(dir, kind), 0755))
+	}
+	srv := NewServer(&Config{Store: storage.NewFileStore(&storage.Config{Root: dir})})
+	ctx := context.Background()
+	// assert that:
+	// - Gets return resource versions
+	// - Puts and Deletes which expect a stale version fail
+	// - Puts and Deletes which expect the current version succeed
+	// - Puts which expect no version don't overwrite existing resources
+	// - Puts return the version of the written resource
+	_, err = srv.GenericPut(ctx, &pb.GenericPutRequest{Name: fake.GenericName, Config: []byte("a")})
+	assert.Nil(t, err)
+	_, err = srv.GenericPut(ctx, &pb.GenericPutRequest{Name: fake.GenericName, Config: []byte("b"), ResourceVersion: storage.NoVersion})
+	assert.Equal(t, storage.ErrVersionConflict, err)
+	_, err = srv.GenericPut(ctx, &pb.GenericPutRequest{Name: fake.GenericName, Config: []byte("b"), ResourceVersion: storage.Version([]byte("b"))})
+	assert.Equal(t, storage.ErrVersionConflict, err)
+	_, err = srv.GenericPut(ctx, &pb.GenericPutRequest{Name: fake.GenericName, Config: []byte("b"), ResourceVersion: storage.Version([]byte("a"))})
+	assert.Nil(t, err)
+	err = srv.GenericDelete(ctx, &pb.GenericDeleteRequest{Name: fake.GenericName, ResourceVersion: storage.Version([]byte("a"))})
+	assert.Equal(t, storage.ErrVersionConflict, err)
+
+	put, err := srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: &storagepb.Profile{Id: "p1", ResourceVersion: storage.NoVersion}})
+	assert.Nil(t, err)
+	profile, err := srv.ProfileGet(ctx, &pb.ProfileGetRequest{Id: "p1"})
+	assert.Nil(t, err)
+	version := profile.ResourceVersion
+	assert.NotEmpty(t, version)
+	assert.Equal(t, version, put.ResourceVersion)
+	profile.Name = "updated"
+	put, err = srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: profile})
+	assert.Nil(t, err)
+	_, err = srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: profile})
+	assert.Equal(t, storage.ErrVersionConflict, err)
+	profile.Name = "chained"
+	profile.ResourceVersion = put.ResourceVersion
+	_, err = srv.ProfilePut(ctx, &pb.ProfilePutRequest{Profile: profile})
+	assert.Nil(t, err)
+	err = srv.ProfileDelete(ctx, &pb.ProfileDeleteRequest{Id: "p1", ResourceVersion: version})
+	assert.Equal(t, storage.ErrVersionConflict, err)
+	profile, err = srv.ProfileGet(ctx, &pb.ProfileGetRequest{Id: "p1"})
+	assert.Nil(t, err)
+	err = srv.ProfileDelete(ctx, &pb.ProfileDeleteRequest{Id: "p1", ResourceVersion: profile.ResourceVersion})
+	assert.Nil(t, err)
+}
+
 func TestProfileCreate_Invalid(t *testing.T) {
 	srv := NewServer(&Config{Store: fake.NewFixedStore()})
 	invalid := &storagepb.Profile{}
@@ -155,7 +349,7 @@ func TestProfileGet(t *testing.T) {
 	}{
 		{fake.Profile.Id, fake.Profile, nil},
 	}
-	srv := NewServer(&Config{store})
+	srv := NewServer(&Config{Store: store})
 	for _, c := range cases {
 		profile, err := srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: c.id})
 		assert.Equal(t, c.err, err)
@@ -163,11 +357,116 @@ func TestProfileGet(t *testing.T) {
 	}
 }
 
+func TestProfileGet_Inherit(t *testing.T) {
+	base := &storagepb.Profile{
+		Id:         "base",
+		IgnitionId: "base.yaml",
+		Boot: &storagepb.NetBoot{
+			Kernel: "/image/kernel",
+			Initrd: []string{"/image/initrd"},
+			Args:   []string{"console=tty0"},
+		},
+	}
+	middle := &storagepb.Profile{
+		Id:     "middle",
+		Parent: "base",
+		Boot:   &storagepb.NetBoot{AppendArgs: []string{"console=ttyS0"}},
+	}
+	child := &storagepb.Profile{
+		Id:         "child",
+		Parent:     "middle",
+		IgnitionId: "child.yaml",
+		Boot:       &storagepb.NetBoot{AppendArgs: []string{"autologin"}},
+	}
+	store := &fake.FixedStore{
+		Profiles: map[string]*storagepb.Profile{base.Id: base, middle.Id: middle, child.Id: child},
+	}
+	srv := NewServer(&Config{Store: store})
+	// assert that:
+	// - Profiles inherit from their chain of parent Profiles
+	// - Profiles without a parent are returned as is
+	profile, err := srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: "child"})
+	assert.Nil(t, err)
+	assert.Equal(t, &storagepb.Profile{
+		Id:         "child",
+		IgnitionId: "child.yaml",
+		Boot: &storagepb.NetBoot{
+			Kernel: "/image/kernel",
+			Initrd: []string{"/image/initrd"},
+			Args:   []string{"console=tty0", "console=ttyS0", "autologin"},
+		},
+	}, profile)
+	profile, err = srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: "base"})
+	assert.Nil(t, err)
+	assert.Equal(t, base, profile)
+}
+
+func TestProfileGet_RootAppendArgs(t *testing.T) {
+	root := &storagepb.Profile{
+		Id: "root",
+		Boot: &storagepb.NetBoot{
+			Kernel:     "/image/kernel",
+			Args:       []string{"console=tty0"},
+			AppendArgs: []string{"console=ttyS0"},
+		},
+	}
+	store := &fake.FixedStore{
+		Profiles: map[string]*storagepb.Profile{root.Id: root},
+	}
+	srv := NewServer(&Config{Store: store})
+	// assert that:
+	// - append args of a Profile without a parent are appended to its args
+	profile, err := srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: "root"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"console=tty0", "console=ttyS0"}, profile.Boot.Args)
+	assert.Empty(t, profile.Boot.AppendArgs)
+}
+
+func TestProfilePut_ParentCycle(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Profiles["a"] = &storagepb.Profile{Id: "a", Parent: "b"}
+	store.Profiles["b"] = &storagepb.Profile{Id: "b", Parent: "c"}
+	store.Profiles["c"] = &storagepb.Profile{Id: "c"}
+	srv := NewServer(&Config{Store: store})
+	// assert that:
+	// - writes which would make a Profile inherit from itself are rejected
+	// - batches which would make a Profile inherit from itself are rejected
+	// - other writes to the chain are allowed
+	_, err := srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: &storagepb.Profile{Id: "c", Parent: "a"}})
+	assert.Equal(t, storagepb.ErrProfileCycle, err)
+	assert.Equal(t, "", store.Profiles["c"].Parent)
+	err = srv.Apply(context.Background(), &pb.BatchApplyRequest{Batch: &storagepb.Batch{
+		Profiles: []*storagepb.Profile{{Id: "c", Parent: "d"}, {Id: "d", Parent: "a"}},
+	}})
+	assert.Equal(t, storagepb.ErrProfileCycle, err)
+	_, err = srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: &storagepb.Profile{Id: "d", Parent: "a"}})
+	assert.Nil(t, err)
+}
+
+func TestProfileGet_InheritErrors(t *testing.T) {
+	store := &fake.FixedStore{
+		Profiles: map[string]*storagepb.Profile{
+			"a":      {Id: "a", Parent: "b"},
+			"b":      {Id: "b", Parent: "c"},
+			"c":      {Id: "c", Parent: "a"},
+			"orphan": {Id: "orphan", Parent: "missing"},
+		},
+	}
+	srv := NewServer(&Config{Store: store})
+	// assert that:
+	// - inheritance cycles are detected
+	// - missing parent Profiles are errors
+	_, err := srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: "a"})
+	assert.Equal(t, storagepb.ErrProfileCycle, err)
+	_, err = srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: "orphan"})
+	assert.Error(t, err)
+}
+
 func TestProfileList(t *testing.T) {
 	store := &fake.FixedStore{
 		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
 	}
-	srv := NewServer(&Config{store})
+	srv := NewServer(&Config{Store: store})
 	profiles, err := srv.ProfileList(context.Background(), &pb.ProfileListRequest{})
 	assert.Nil(t, err)
 	if assert.Equal(t, 1, len(profiles)) {
@@ -176,14 +475,14 @@ func TestProfileList(t *testing.T) {
 }
 
 func TestProfileList_Empty(t *testing.T) {
-	srv := NewServer(&Config{&fake.EmptyStore{}})
+	srv := NewServer(&Config{Store: &fake.EmptyStore{}})
 	profiles, err := srv.ProfileList(context.Background(), &pb.ProfileListRequest{})
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(profiles))
 }
 
 func TestProfiles_BrokenStore(t *testing.T) {
-	srv := NewServer(&Config{&fake.BrokenStore{}})
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
 	_, err := srv.ProfilePut(context.Background(), &pb.ProfilePutRequest{Profile: fake.Profile})
 	assert.Error(t, err)
 	_, err = srv.ProfileGet(context.Background(), &pb.ProfileGetRequest{Id: fake.Profile.Id})
@@ -217,7 +516,7 @@ func TestIgnitionCRUD(t *testing.T) {
 }
 
 func TestIgnition_BrokenStore(t *testing.T) {
-	srv := NewServer(&Config{&fake.BrokenStore{}})
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
 	req := &pb.IgnitionPutRequest{
 		Name:   fake.IgnitionYAMLName,
 		Config: []byte(fake.IgnitionYAML),
@@ -230,6 +529,196 @@ func TestIgnition_BrokenStore(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTemplateList(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.IgnitionConfigs[fake.IgnitionYAMLName] = fake.IgnitionYAML
+	store.GenericConfigs[fake.GenericName] = fake.Generic
+	srv := NewServer(&Config{Store: store})
+	// assert that:
+	// - Ignition and Generic templates can be listed
+	templates, err := srv.IgnitionList(context.Background(), &pb.IgnitionListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.TemplateInfo{{Name: fake.IgnitionYAMLName, Size: int64(len(fake.IgnitionYAML))}}, templates)
+	templates, err = srv.GenericList(context.Background(), &pb.GenericListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.TemplateInfo{{Name: fake.GenericName, Size: int64(len(fake.Generic))}}, templates)
+
+	srv = NewServer(&Config{Store: &fake.BrokenStore{}})
+	_, err = srv.IgnitionList(context.Background(), &pb.IgnitionListRequest{})
+	assert.Error(t, err)
+	_, err = srv.GenericList(context.Background(), &pb.GenericListRequest{})
+	assert.Error(t, err)
+}
+
+func TestIgnitionPut_Validation(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Groups[fake.Group.Id] = fake.Group
+	store.Partials["unit"] = `- name: {{.service_name}}.service`
+	srv := NewServer(&Config{Store: store})
+	sample := map[string]string{"uuid": "a1b2c3d4"}
+	cases := []struct {
+		name   string
+		config string
+		labels map[string]string
+		valid  bool
+	}{
+		// raw Ignition validated against its declared spec version
+		{"raw.ign", `{"ignition":{"version":"3.1.0"}}`, nil, true},
+		{"raw.ign", `{"ignition":{"version":"3.1.0"},"systemd":{"units":[{"enabled":true}]}}`, nil, false},
+		{"raw.ign", `{"ignition":{"version":"9.0.0"}}`, nil, false},
+		// Container Linux Configs
+		{"clc.yaml", fake.IgnitionYAML, nil, true},
+		{"clc.yaml", "systemd: [", nil, false},
+		{"clc.yaml", "systemd:\n  units:\n    - enable: true\n", nil, false},
+		// Butane configs
+		{"fcos.bu", "variant: fcos\nversion: 1.1.0\n", nil, true},
+		{"fcos.bu", "variant: fcos\nversion: 9.0.0\n", nil, false},
+		// templates are trial rendered without variables, unless they need them
+		{"clc.yaml", "systemd:\n  units:\n    - name: {{.missing}}\n", nil, true},
+		{"clc.yaml", "systemd:\n  units:\n    - name: {{.missing\n", nil, false},
+		{"fcos.bu", "variant: fcos\nversion: 1.1.0\n{{ unknownFunc . }}\n", nil, false},
+		{"clc.yaml", "systemd:\n  units:\n    - enable: {{ \"true\" }}\n", nil, false},
+		{"fcos.bu", "variant: fcos\nversion: {{ \"9.0.0\" }}\n", nil, false},
+		{"fcos.bu", "variant: fcos\nversion: {{ \"1.1.0\" }}\n", nil, true},
+		// templates are trial rendered with sample labels and Group metadata
+		{"clc.yaml", "systemd:\n  units:\n    - name: {{.service_name}}.service\n", sample, true},
+		{"clc.yaml", "systemd:\n  units:\n    - name: {{.missing}}\n", sample, false},
+		{"fcos.bu", "variant: fcos\nversion: 1.1.0\nsystemd:\n  units:\n    {{template \"unit\" .}}\n", sample, true},
+		{"fcos.bu", "variant: fcos\nversion: 1.1.0\nsystemd:\n  units:\n    - enabled: {{.uuid}}\n", sample, false},
+	}
+	for _, c := range cases {
+		req := &pb.IgnitionPutRequest{Name: c.name, Config: []byte(c.config), Labels: c.labels}
+		_, err := srv.IgnitionPut(context.Background(), req)
+		if c.valid {
+			assert.Nil(t, err, c.config)
+		} else if assert.Error(t, err, c.config) {
+			assert.IsType(t, &InvalidTemplateError{}, err)
+		}
+	}
+}
+
+func TestIgnitionPut_DryRun(t *testing.T) {
+	store := fake.NewFixedStore()
+	srv := NewServer(&Config{Store: store})
+	req := &pb.IgnitionPutRequest{
+		Name:   fake.IgnitionYAMLName,
+		Config: []byte(fake.IgnitionYAML),
+		DryRun: true,
+	}
+	// assert that:
+	// - valid templates pass a dry run, but aren't stored
+	// - invalid templates fail a dry run
+	_, err := srv.IgnitionPut(context.Background(), req)
+	assert.Nil(t, err)
+	_, err = srv.IgnitionGet(context.Background(), &pb.IgnitionGetRequest{Name: fake.IgnitionYAMLName})
+	assert.Error(t, err)
+
+	req.Config = []byte("systemd: [")
+	_, err = srv.IgnitionPut(context.Background(), req)
+	assert.Error(t, err)
+}
+
+func TestApply(t *testing.T) {
+	profile := &storagepb.Profile{Id: "worker", IgnitionId: "worker.yaml"}
+	group := &storagepb.Group{Id: "workers", Profile: profile.Id}
+	ignition := &storagepb.Template{Name: "worker.yaml", Contents: []byte(fake.IgnitionYAML)}
+	cases := []struct {
+		batch *storagepb.Batch
+		force bool
+		err   interface{}
+	}{
+		// resources may reference resources in the same Batch
+		{&storagepb.Batch{
+			Groups:   []*storagepb.Group{group},
+			Profiles: []*storagepb.Profile{profile},
+			Ignition: []*storagepb.Template{ignition},
+		}, false, nil},
+		{&storagepb.Batch{Groups: []*storagepb.Group{group}}, false, &ReferenceError{}},
+		{&storagepb.Batch{
+			Profiles: []*storagepb.Profile{profile},
+			Ignition: []*storagepb.Template{{Name: "worker.yaml", Contents: []byte("systemd: [")}},
+		}, false, &InvalidTemplateError{}},
+		{&storagepb.Batch{Partials: []*storagepb.Template{{Name: "broken", Contents: []byte("{{.name")}}}, false, &InvalidTemplateError{}},
+		{&storagepb.Batch{Generic: []*storagepb.Template{{Name: "generic", Contents: []byte("{{.name")}}}, false, &InvalidTemplateError{}},
+		{&storagepb.Batch{Cloud: []*storagepb.Template{{Name: "cloud.yaml", Contents: []byte("{{end}}")}}}, false, &InvalidTemplateError{}},
+		// referenced resources may only be deleted with their referrers
+		{&storagepb.Batch{
+			Deletes: []*storagepb.Deletion{{Kind: storage.KindProfile, Name: fake.Profile.Id}},
+		}, false, &ReferenceError{}},
+		{&storagepb.Batch{
+			Deletes: []*storagepb.Deletion{{Kind: storage.KindProfile, Name: fake.Profile.Id}},
+		}, true, nil},
+		{&storagepb.Batch{
+			Deletes: []*storagepb.Deletion{
+				{Kind: storage.KindGroup, Name: fake.Group.Id},
+				{Kind: storage.KindProfile, Name: fake.Profile.Id},
+			},
+		}, false, nil},
+		{&storagepb.Batch{Groups: []*storagepb.Group{fake.Group, fake.Group}}, false, &storage.InvalidBatchError{}},
+	}
+	for _, c := range cases {
+		store := fake.NewFixedStore()
+		store.Groups[fake.Group.Id] = fake.Group
+		store.Profiles[fake.Profile.Id] = fake.Profile
+		srv := NewServer(&Config{Store: store})
+		// assert that:
+		// - valid Batches are applied
+		// - invalid Batches are rejected and nothing is changed
+		err := srv.Apply(context.Background(), &pb.BatchApplyRequest{Batch: c.batch, Force: c.force})
+		if c.err == nil {
+			assert.Nil(t, err)
+			for _, group := range c.batch.Groups {
+				assert.Equal(t, group, store.Groups[group.Id])
+			}
+		} else {
+			assert.IsType(t, c.err, err)
+			assert.Len(t, store.Groups, 1)
+			assert.Len(t, store.Profiles, 1)
+			assert.Empty(t, store.IgnitionConfigs)
+			assert.Empty(t, store.Partials)
+			assert.Empty(t, store.GenericConfigs)
+			assert.Empty(t, store.CloudConfigs)
+		}
+	}
+}
+
+func TestApply_DryRun(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	srv := NewServer(&Config{Store: store})
+	req := &pb.BatchApplyRequest{
+		Batch:  &storagepb.Batch{Groups: []*storagepb.Group{fake.Group}},
+		DryRun: true,
+	}
+	// assert that:
+	// - valid Batches pass a dry run, but aren't applied
+	err := srv.Apply(context.Background(), req)
+	assert.Nil(t, err)
+	assert.Empty(t, store.Groups)
+}
+
+func TestExportImport(t *testing.T) {
+	source := fake.NewFixedStore()
+	source.Groups[fake.Group.Id] = fake.Group
+	source.Profiles[fake.Profile.Id] = fake.Profile
+	target := fake.NewFixedStore()
+	// assert that:
+	// - exported archives can be imported into another Store
+	var buf bytes.Buffer
+	err := NewServer(&Config{Store: source}).Export(context.Background(), &buf)
+	assert.Nil(t, err)
+	err = NewServer(&Config{Store: target}).Import(context.Background(), &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group.Selector, target.Groups[fake.Group.Id].Selector)
+	assert.Equal(t, fake.Profile.IgnitionId, target.Profiles[fake.Profile.Id].IgnitionId)
+
+	// assert that:
+	// - archives aren't exported while stored resources can't be parsed
+	source.InvalidResources = []*storagepb.InvalidResource{{Kind: "Group", Id: "a", Error: "bad"}}
+	err = NewServer(&Config{Store: source}).Export(context.Background(), &buf)
+	assert.IsType(t, &InvalidResourcesError{}, err)
+}
+
 func TestGenericCRUD(t *testing.T) {
 	srv := NewServer(&Config{Store: fake.NewFixedStore()})
 	req := &pb.GenericPutRequest{
@@ -252,8 +741,29 @@ func TestGenericCRUD(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTemplatePut_Invalid(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Partials["broken"] = `{{.name`
+	srv := NewServer(&Config{Store: store})
+	ctx := context.Background()
+	// assert that:
+	// - Generic and Cloud-Config templates which can't be parsed are rejected
+	// - templates which include unparsable partials are rejected
+	// - templates which don't include unparsable partials are stored
+	_, err := srv.GenericPut(ctx, &pb.GenericPutRequest{Name: "generic", Config: []byte(`{{.name`)})
+	assert.IsType(t, &InvalidTemplateError{}, err)
+	_, err = srv.CloudPut(ctx, &pb.CloudPutRequest{Name: "cloud.yaml", Config: []byte(`{{if .name}}`)})
+	assert.IsType(t, &InvalidTemplateError{}, err)
+	_, err = srv.CloudPut(ctx, &pb.CloudPutRequest{Name: "cloud.yaml", Config: []byte(`{{template "broken" .}}`)})
+	assert.IsType(t, &InvalidTemplateError{}, err)
+	assert.Empty(t, store.GenericConfigs)
+	assert.Empty(t, store.CloudConfigs)
+	_, err = srv.GenericPut(ctx, &pb.GenericPutRequest{Name: "generic", Config: []byte(`{{.name}}`)})
+	assert.Nil(t, err)
+}
+
 func TestGeneric_BrokenStore(t *testing.T) {
-	srv := NewServer(&Config{&fake.BrokenStore{}})
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
 	req := &pb.GenericPutRequest{
 		Name:   fake.GenericName,
 		Config: []byte(fake.Generic),
@@ -266,3 +776,222 @@ func TestGeneric_BrokenStore(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestCloudCRUD(t *testing.T) {
+	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	req := &pb.CloudPutRequest{
+		Name:   "cloud.yaml",
+		Config: []byte("#cloud-config"),
+	}
+	_, err := srv.CloudPut(context.Background(), req)
+	// assert that:
+	// - Cloud-Config template creation is successful
+	// - Cloud-Config template can be retrieved by name
+	// - Cloud-Config templates can be listed
+	// - Cloud-Config template can be deleted by name
+	assert.Nil(t, err)
+	template, err := srv.CloudGet(context.Background(), &pb.CloudGetRequest{Name: "cloud.yaml"})
+	assert.Equal(t, "#cloud-config", template)
+	assert.Nil(t, err)
+	templates, err := srv.CloudList(context.Background(), &pb.CloudListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.TemplateInfo{{Name: "cloud.yaml", Size: 13}}, templates)
+
+	err = srv.CloudDelete(context.Background(), &pb.CloudDeleteRequest{Name: "cloud.yaml"})
+	assert.Nil(t, err)
+	_, err = srv.CloudGet(context.Background(), &pb.CloudGetRequest{Name: "cloud.yaml"})
+	assert.Error(t, err)
+}
+
+func TestCloud_BrokenStore(t *testing.T) {
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
+	req := &pb.CloudPutRequest{
+		Name:   "cloud.yaml",
+		Config: []byte("#cloud-config"),
+	}
+	_, err := srv.CloudPut(context.Background(), req)
+	assert.Error(t, err)
+	_, err = srv.CloudGet(context.Background(), &pb.CloudGetRequest{Name: "cloud.yaml"})
+	assert.Error(t, err)
+	err = srv.CloudDelete(context.Background(), &pb.CloudDeleteRequest{Name: "cloud.yaml"})
+	assert.Error(t, err)
+	_, err = srv.CloudList(context.Background(), &pb.CloudListRequest{})
+	assert.Error(t, err)
+}
+
+func TestPartialCRUD(t *testing.T) {
+	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	req := &pb.PartialPutRequest{
+		Name:   "sshkeys",
+		Config: []byte(`{{.ssh_authorized_key}}`),
+	}
+	_, err := srv.PartialPut(context.Background(), req)
+	// assert that:
+	// - template partial creation is successful
+	// - template partials can be listed and retrieved by name
+	// - template partials can be deleted by name
+	assert.Nil(t, err)
+	names, err := srv.PartialList(context.Background(), &pb.PartialListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"sshkeys"}, names)
+	template, err := srv.PartialGet(context.Background(), &pb.PartialGetRequest{Name: "sshkeys"})
+	assert.Equal(t, `{{.ssh_authorized_key}}`, template)
+	assert.Nil(t, err)
+
+	err = srv.PartialDelete(context.Background(), &pb.PartialDeleteRequest{Name: "sshkeys"})
+	assert.Nil(t, err)
+	_, err = srv.PartialGet(context.Background(), &pb.PartialGetRequest{Name: "sshkeys"})
+	assert.Error(t, err)
+}
+
+func TestPartialPut_Invalid(t *testing.T) {
+	store := fake.NewFixedStore()
+	srv := NewServer(&Config{Store: store})
+	// assert that:
+	// - unparsable partials are rejected and not stored
+	_, err := srv.PartialPut(context.Background(), &pb.PartialPutRequest{Name: "broken", Config: []byte(`{{.name`)})
+	assert.IsType(t, &InvalidTemplateError{}, err)
+	assert.Empty(t, store.Partials)
+}
+
+func TestPartial_BrokenStore(t *testing.T) {
+	srv := NewServer(&Config{Store: &fake.BrokenStore{}})
+	_, err := srv.PartialPut(context.Background(), &pb.PartialPutRequest{Name: "sshkeys"})
+	assert.Error(t, err)
+	_, err = srv.PartialGet(context.Background(), &pb.PartialGetRequest{Name: "sshkeys"})
+	assert.Error(t, err)
+	_, err = srv.PartialList(context.Background(), &pb.PartialListRequest{})
+	assert.Error(t, err)
+	err = srv.PartialDelete(context.Background(), &pb.PartialDeleteRequest{Name: "sshkeys"})
+	assert.Error(t, err)
+}
+
+func TestInstanceRecord(t *testing.T) {
+	srv := NewServer(&Config{Store: &fake.EmptyStore{}})
+	req := &pb.InstanceRecordRequest{
+		Labels:   map[string]string{"uuid": "a1b2c3d4", "mac": "52:da:00:89:d8:10"},
+		Group:    fake.Group.Id,
+		Profile:  fake.Group.Profile,
+		Endpoint: "/ipxe",
+	}
+	first, err := srv.InstanceRecord(context.Background(), req)
+	// assert that:
+	// - Instances are identified by their labels
+	// - the matched Group, Profile, and endpoint are recorded
+	// - later requests preserve the first seen time
+	assert.Nil(t, err)
+	assert.Equal(t, "a1b2c3d4", first.Id)
+	assert.Equal(t, fake.Group.Id, first.Group)
+	assert.Equal(t, "/ipxe", first.Endpoint)
+
+	req.Endpoint = "/ignition"
+	_, err = srv.InstanceRecord(context.Background(), req)
+	assert.Nil(t, err)
+	instance, err := srv.InstanceGet(context.Background(), &pb.InstanceGetRequest{Id: "a1b2c3d4"})
+	assert.Nil(t, err)
+	assert.Equal(t, "/ignition", instance.Endpoint)
+	assert.Equal(t, first.FirstSeen, instance.FirstSeen)
+	assert.True(t, instance.LastSeen >= first.LastSeen)
+
+	instances, err := srv.InstanceList(context.Background(), &pb.InstanceListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.Instance{instance}, instances)
+}
+
+func TestInstanceRecord_Unidentified(t *testing.T) {
+	srv := NewServer(&Config{Store: &fake.EmptyStore{}})
+	req := &pb.InstanceRecordRequest{
+		Labels:   map[string]string{"region": "us-west"},
+		Endpoint: "/ipxe",
+	}
+	_, err := srv.InstanceRecord(context.Background(), req)
+	assert.Equal(t, storagepb.ErrInstanceIdRequired, err)
+	instances, err := srv.InstanceList(context.Background(), &pb.InstanceListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(instances))
+}
+
+func TestHistory(t *testing.T) {
+	store := fake.NewFixedStore()
+	srv := NewServer(&Config{Store: store})
+	ctx := WithAuthor(context.Background(), "ci")
+	name := fake.IgnitionYAMLName
+	_, err := srv.IgnitionPut(ctx, &pb.IgnitionPutRequest{Name: name, Config: []byte(fake.IgnitionYAML)})
+	assert.Nil(t, err)
+	_, err = srv.IgnitionPut(context.Background(), &pb.IgnitionPutRequest{Name: name, Config: []byte("{}")})
+	assert.Nil(t, err)
+	assert.Nil(t, srv.IgnitionDelete(ctx, &pb.IgnitionDeleteRequest{Name: name}))
+	// assert that:
+	// - writes and deletes are recorded with their authors
+	revisions, err := srv.HistoryList(ctx, &pb.HistoryListRequest{Kind: storage.KindIgnition, Name: name})
+	assert.Nil(t, err)
+	if assert.Len(t, revisions, 3) {
+		assert.Equal(t, "ci", revisions[0].Author)
+		assert.Equal(t, []byte(fake.IgnitionYAML), revisions[0].Contents)
+		assert.Equal(t, "", revisions[1].Author)
+		assert.True(t, revisions[2].Deleted)
+		assert.NotZero(t, revisions[2].Timestamp)
+	}
+
+	// assert that:
+	// - rolling back restores the revision and records a new revision
+	err = srv.Rollback(ctx, &pb.HistoryRollbackRequest{Kind: storage.KindIgnition, Name: name, Revision: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, fake.IgnitionYAML, store.IgnitionConfigs[name])
+	revisions, err = srv.HistoryList(ctx, &pb.HistoryListRequest{Kind: storage.KindIgnition, Name: name})
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 4)
+	err = srv.Rollback(ctx, &pb.HistoryRollbackRequest{Kind: storage.KindIgnition, Name: name, Revision: 3})
+	assert.Nil(t, err)
+	_, present := store.IgnitionConfigs[name]
+	assert.False(t, present)
+	err = srv.Rollback(ctx, &pb.HistoryRollbackRequest{Kind: storage.KindIgnition, Name: name, Revision: 9})
+	assert.Equal(t, storage.ErrRevisionNotFound, err)
+
+	// assert that:
+	// - Group revisions are recorded without the replaced resource version
+	group := fake.Group.Copy()
+	group.ResourceVersion = "v1"
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	_, err = srv.GroupPut(ctx, &pb.GroupPutRequest{Group: group})
+	assert.Nil(t, err)
+	revisions, err = srv.HistoryList(ctx, &pb.HistoryListRequest{Kind: storage.KindGroup, Name: group.Id})
+	assert.Nil(t, err)
+	if assert.Len(t, revisions, 1) {
+		assert.Equal(t, "", revisions[0].Group.ResourceVersion)
+	}
+}
+
+func TestAuditList(t *testing.T) {
+	srv := NewServer(&Config{Store: fake.NewFixedStore()})
+	entries := []*storagepb.AuditEntry{
+		{Subject: "CN=ci", Method: "a", Changes: []*storagepb.AuditChange{{Kind: storage.KindIgnition, Name: "a.ign"}}},
+		{Subject: "CN=admin", Method: "b", Changes: []*storagepb.AuditChange{{Kind: storage.KindGroup, Name: "a"}}},
+		{Subject: "CN=ci", Method: "c", Changes: []*storagepb.AuditChange{{Kind: storage.KindIgnition, Name: "b.ign"}}},
+	}
+	for _, entry := range entries {
+		assert.Nil(t, srv.AuditRecord(context.Background(), entry))
+	}
+	cases := []struct {
+		req     *pb.AuditListRequest
+		methods []string
+	}{
+		{&pb.AuditListRequest{}, []string{"a", "b", "c"}},
+		{&pb.AuditListRequest{Subject: "CN=ci"}, []string{"a", "c"}},
+		{&pb.AuditListRequest{Kind: storage.KindIgnition}, []string{"a", "c"}},
+		{&pb.AuditListRequest{Name: "a"}, []string{"b"}},
+		{&pb.AuditListRequest{Kind: storage.KindGroup, Name: "a.ign"}, []string{}},
+		{&pb.AuditListRequest{Limit: 2}, []string{"b", "c"}},
+	}
+	for _, c := range cases {
+		listed, err := srv.AuditList(context.Background(), c.req)
+		// assert that:
+		// - entries matching the filters are listed, oldest first
+		assert.Nil(t, err)
+		methods := []string{}
+		for _, entry := range listed {
+			methods = append(methods, entry.Method)
+		}
+		assert.Equal(t, c.methods, methods)
+	}
+}
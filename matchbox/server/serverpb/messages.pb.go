@@ -177,6 +177,179 @@ func (m *SelectProfileResponse) GetProfile() *storagepb.Profile {
 	return nil
 }
 
+type SelectExplainRequest struct {
+	Labels               map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SelectExplainRequest) Reset()         { *m = SelectExplainRequest{} }
+func (m *SelectExplainRequest) String() string { return proto.CompactTextString(m) }
+func (*SelectExplainRequest) ProtoMessage()    {}
+func (*SelectExplainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{4}
+}
+
+func (m *SelectExplainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelectExplainRequest.Unmarshal(m, b)
+}
+func (m *SelectExplainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelectExplainRequest.Marshal(b, m, deterministic)
+}
+func (m *SelectExplainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelectExplainRequest.Merge(m, src)
+}
+func (m *SelectExplainRequest) XXX_Size() int {
+	return xxx_messageInfo_SelectExplainRequest.Size(m)
+}
+func (m *SelectExplainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelectExplainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelectExplainRequest proto.InternalMessageInfo
+
+func (m *SelectExplainRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type SelectExplainResponse struct {
+	// explanation of every Group, in order of evaluation
+	Groups []*GroupExplanation `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	// selected Group, if any
+	Group *storagepb.Group `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	// Profile of the selected Group, if any
+	Profile              *storagepb.Profile `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SelectExplainResponse) Reset()         { *m = SelectExplainResponse{} }
+func (m *SelectExplainResponse) String() string { return proto.CompactTextString(m) }
+func (*SelectExplainResponse) ProtoMessage()    {}
+func (*SelectExplainResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{5}
+}
+
+func (m *SelectExplainResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelectExplainResponse.Unmarshal(m, b)
+}
+func (m *SelectExplainResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelectExplainResponse.Marshal(b, m, deterministic)
+}
+func (m *SelectExplainResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelectExplainResponse.Merge(m, src)
+}
+func (m *SelectExplainResponse) XXX_Size() int {
+	return xxx_messageInfo_SelectExplainResponse.Size(m)
+}
+func (m *SelectExplainResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelectExplainResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelectExplainResponse proto.InternalMessageInfo
+
+func (m *SelectExplainResponse) GetGroups() []*GroupExplanation {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+func (m *SelectExplainResponse) GetGroup() *storagepb.Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+func (m *SelectExplainResponse) GetProfile() *storagepb.Profile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+// GroupExplanation explains whether a Group matches a machine's labels.
+type GroupExplanation struct {
+	Group *storagepb.Group `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	// position in order of evaluation, starting at 0
+	Rank int32 `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// whether the Group's selectors match the labels
+	Matched bool `protobuf:"varint,3,opt,name=matched,proto3" json:"matched,omitempty"`
+	// whether the Group is the selected Group
+	Selected bool `protobuf:"varint,4,opt,name=selected,proto3" json:"selected,omitempty"`
+	// first selector requirement the labels failed to satisfy
+	FailedRequirement    string   `protobuf:"bytes,5,opt,name=failed_requirement,json=failedRequirement,proto3" json:"failed_requirement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupExplanation) Reset()         { *m = GroupExplanation{} }
+func (m *GroupExplanation) String() string { return proto.CompactTextString(m) }
+func (*GroupExplanation) ProtoMessage()    {}
+func (*GroupExplanation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{6}
+}
+
+func (m *GroupExplanation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupExplanation.Unmarshal(m, b)
+}
+func (m *GroupExplanation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupExplanation.Marshal(b, m, deterministic)
+}
+func (m *GroupExplanation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupExplanation.Merge(m, src)
+}
+func (m *GroupExplanation) XXX_Size() int {
+	return xxx_messageInfo_GroupExplanation.Size(m)
+}
+func (m *GroupExplanation) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupExplanation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupExplanation proto.InternalMessageInfo
+
+func (m *GroupExplanation) GetGroup() *storagepb.Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+func (m *GroupExplanation) GetRank() int32 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+func (m *GroupExplanation) GetMatched() bool {
+	if m != nil {
+		return m.Matched
+	}
+	return false
+}
+
+func (m *GroupExplanation) GetSelected() bool {
+	if m != nil {
+		return m.Selected
+	}
+	return false
+}
+
+func (m *GroupExplanation) GetFailedRequirement() string {
+	if m != nil {
+		return m.FailedRequirement
+	}
+	return ""
+}
+
 type GroupPutRequest struct {
 	Group                *storagepb.Group `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -188,7 +361,7 @@ func (m *GroupPutRequest) Reset()         { *m = GroupPutRequest{} }
 func (m *GroupPutRequest) String() string { return proto.CompactTextString(m) }
 func (*GroupPutRequest) ProtoMessage()    {}
 func (*GroupPutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{4}
+	return fileDescriptor_ae62049dfcf497b5, []int{7}
 }
 
 func (m *GroupPutRequest) XXX_Unmarshal(b []byte) error {
@@ -217,6 +390,8 @@ func (m *GroupPutRequest) GetGroup() *storagepb.Group {
 }
 
 type GroupPutResponse struct {
+	// resource version of the written resource
+	ResourceVersion      string   `protobuf:"bytes,1,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -226,7 +401,7 @@ func (m *GroupPutResponse) Reset()         { *m = GroupPutResponse{} }
 func (m *GroupPutResponse) String() string { return proto.CompactTextString(m) }
 func (*GroupPutResponse) ProtoMessage()    {}
 func (*GroupPutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{5}
+	return fileDescriptor_ae62049dfcf497b5, []int{8}
 }
 
 func (m *GroupPutResponse) XXX_Unmarshal(b []byte) error {
@@ -247,6 +422,13 @@ func (m *GroupPutResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GroupPutResponse proto.InternalMessageInfo
 
+func (m *GroupPutResponse) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type GroupGetRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -258,7 +440,7 @@ func (m *GroupGetRequest) Reset()         { *m = GroupGetRequest{} }
 func (m *GroupGetRequest) String() string { return proto.CompactTextString(m) }
 func (*GroupGetRequest) ProtoMessage()    {}
 func (*GroupGetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{6}
+	return fileDescriptor_ae62049dfcf497b5, []int{9}
 }
 
 func (m *GroupGetRequest) XXX_Unmarshal(b []byte) error {
@@ -297,7 +479,7 @@ func (m *GroupGetResponse) Reset()         { *m = GroupGetResponse{} }
 func (m *GroupGetResponse) String() string { return proto.CompactTextString(m) }
 func (*GroupGetResponse) ProtoMessage()    {}
 func (*GroupGetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{7}
+	return fileDescriptor_ae62049dfcf497b5, []int{10}
 }
 
 func (m *GroupGetResponse) XXX_Unmarshal(b []byte) error {
@@ -326,7 +508,9 @@ func (m *GroupGetResponse) GetGroup() *storagepb.Group {
 }
 
 type GroupDeleteRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// expected resource version, if any
+	ResourceVersion      string   `protobuf:"bytes,2,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -336,7 +520,7 @@ func (m *GroupDeleteRequest) Reset()         { *m = GroupDeleteRequest{} }
 func (m *GroupDeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*GroupDeleteRequest) ProtoMessage()    {}
 func (*GroupDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{8}
+	return fileDescriptor_ae62049dfcf497b5, []int{11}
 }
 
 func (m *GroupDeleteRequest) XXX_Unmarshal(b []byte) error {
@@ -364,6 +548,13 @@ func (m *GroupDeleteRequest) GetId() string {
 	return ""
 }
 
+func (m *GroupDeleteRequest) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type GroupDeleteResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -374,7 +565,7 @@ func (m *GroupDeleteResponse) Reset()         { *m = GroupDeleteResponse{} }
 func (m *GroupDeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*GroupDeleteResponse) ProtoMessage()    {}
 func (*GroupDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{9}
+	return fileDescriptor_ae62049dfcf497b5, []int{12}
 }
 
 func (m *GroupDeleteResponse) XXX_Unmarshal(b []byte) error {
@@ -405,7 +596,7 @@ func (m *GroupListRequest) Reset()         { *m = GroupListRequest{} }
 func (m *GroupListRequest) String() string { return proto.CompactTextString(m) }
 func (*GroupListRequest) ProtoMessage()    {}
 func (*GroupListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{10}
+	return fileDescriptor_ae62049dfcf497b5, []int{13}
 }
 
 func (m *GroupListRequest) XXX_Unmarshal(b []byte) error {
@@ -437,7 +628,7 @@ func (m *GroupListResponse) Reset()         { *m = GroupListResponse{} }
 func (m *GroupListResponse) String() string { return proto.CompactTextString(m) }
 func (*GroupListResponse) ProtoMessage()    {}
 func (*GroupListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{11}
+	return fileDescriptor_ae62049dfcf497b5, []int{14}
 }
 
 func (m *GroupListResponse) XXX_Unmarshal(b []byte) error {
@@ -476,7 +667,7 @@ func (m *ProfilePutRequest) Reset()         { *m = ProfilePutRequest{} }
 func (m *ProfilePutRequest) String() string { return proto.CompactTextString(m) }
 func (*ProfilePutRequest) ProtoMessage()    {}
 func (*ProfilePutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{12}
+	return fileDescriptor_ae62049dfcf497b5, []int{15}
 }
 
 func (m *ProfilePutRequest) XXX_Unmarshal(b []byte) error {
@@ -505,6 +696,8 @@ func (m *ProfilePutRequest) GetProfile() *storagepb.Profile {
 }
 
 type ProfilePutResponse struct {
+	// resource version of the written resource
+	ResourceVersion      string   `protobuf:"bytes,1,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -514,7 +707,7 @@ func (m *ProfilePutResponse) Reset()         { *m = ProfilePutResponse{} }
 func (m *ProfilePutResponse) String() string { return proto.CompactTextString(m) }
 func (*ProfilePutResponse) ProtoMessage()    {}
 func (*ProfilePutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{13}
+	return fileDescriptor_ae62049dfcf497b5, []int{16}
 }
 
 func (m *ProfilePutResponse) XXX_Unmarshal(b []byte) error {
@@ -535,6 +728,13 @@ func (m *ProfilePutResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ProfilePutResponse proto.InternalMessageInfo
 
+func (m *ProfilePutResponse) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type ProfileGetRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -546,7 +746,7 @@ func (m *ProfileGetRequest) Reset()         { *m = ProfileGetRequest{} }
 func (m *ProfileGetRequest) String() string { return proto.CompactTextString(m) }
 func (*ProfileGetRequest) ProtoMessage()    {}
 func (*ProfileGetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{14}
+	return fileDescriptor_ae62049dfcf497b5, []int{17}
 }
 
 func (m *ProfileGetRequest) XXX_Unmarshal(b []byte) error {
@@ -585,7 +785,7 @@ func (m *ProfileGetResponse) Reset()         { *m = ProfileGetResponse{} }
 func (m *ProfileGetResponse) String() string { return proto.CompactTextString(m) }
 func (*ProfileGetResponse) ProtoMessage()    {}
 func (*ProfileGetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{15}
+	return fileDescriptor_ae62049dfcf497b5, []int{18}
 }
 
 func (m *ProfileGetResponse) XXX_Unmarshal(b []byte) error {
@@ -614,7 +814,11 @@ func (m *ProfileGetResponse) GetProfile() *storagepb.Profile {
 }
 
 type ProfileDeleteRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// delete even if Groups or Profiles still reference it
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// expected resource version, if any
+	ResourceVersion      string   `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -624,7 +828,7 @@ func (m *ProfileDeleteRequest) Reset()         { *m = ProfileDeleteRequest{} }
 func (m *ProfileDeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*ProfileDeleteRequest) ProtoMessage()    {}
 func (*ProfileDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{16}
+	return fileDescriptor_ae62049dfcf497b5, []int{19}
 }
 
 func (m *ProfileDeleteRequest) XXX_Unmarshal(b []byte) error {
@@ -652,6 +856,20 @@ func (m *ProfileDeleteRequest) GetId() string {
 	return ""
 }
 
+func (m *ProfileDeleteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *ProfileDeleteRequest) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type ProfileDeleteResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -662,7 +880,7 @@ func (m *ProfileDeleteResponse) Reset()         { *m = ProfileDeleteResponse{} }
 func (m *ProfileDeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*ProfileDeleteResponse) ProtoMessage()    {}
 func (*ProfileDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{17}
+	return fileDescriptor_ae62049dfcf497b5, []int{20}
 }
 
 func (m *ProfileDeleteResponse) XXX_Unmarshal(b []byte) error {
@@ -693,7 +911,7 @@ func (m *ProfileListRequest) Reset()         { *m = ProfileListRequest{} }
 func (m *ProfileListRequest) String() string { return proto.CompactTextString(m) }
 func (*ProfileListRequest) ProtoMessage()    {}
 func (*ProfileListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{18}
+	return fileDescriptor_ae62049dfcf497b5, []int{21}
 }
 
 func (m *ProfileListRequest) XXX_Unmarshal(b []byte) error {
@@ -725,7 +943,7 @@ func (m *ProfileListResponse) Reset()         { *m = ProfileListResponse{} }
 func (m *ProfileListResponse) String() string { return proto.CompactTextString(m) }
 func (*ProfileListResponse) ProtoMessage()    {}
 func (*ProfileListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{19}
+	return fileDescriptor_ae62049dfcf497b5, []int{22}
 }
 
 func (m *ProfileListResponse) XXX_Unmarshal(b []byte) error {
@@ -754,8 +972,15 @@ func (m *ProfileListResponse) GetProfiles() []*storagepb.Profile {
 }
 
 type IgnitionPutRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Config               []byte   `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Config []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// sample machine labels with which to trial render the template
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// validate the template without storing it
+	DryRun bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// expected resource version, if any, or "none" if the template must not
+	// exist
+	ResourceVersion      string   `protobuf:"bytes,5,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -765,7 +990,7 @@ func (m *IgnitionPutRequest) Reset()         { *m = IgnitionPutRequest{} }
 func (m *IgnitionPutRequest) String() string { return proto.CompactTextString(m) }
 func (*IgnitionPutRequest) ProtoMessage()    {}
 func (*IgnitionPutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{20}
+	return fileDescriptor_ae62049dfcf497b5, []int{23}
 }
 
 func (m *IgnitionPutRequest) XXX_Unmarshal(b []byte) error {
@@ -800,7 +1025,30 @@ func (m *IgnitionPutRequest) GetConfig() []byte {
 	return nil
 }
 
+func (m *IgnitionPutRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *IgnitionPutRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *IgnitionPutRequest) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type IgnitionPutResponse struct {
+	// resource version of the written resource
+	ResourceVersion      string   `protobuf:"bytes,1,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -810,7 +1058,7 @@ func (m *IgnitionPutResponse) Reset()         { *m = IgnitionPutResponse{} }
 func (m *IgnitionPutResponse) String() string { return proto.CompactTextString(m) }
 func (*IgnitionPutResponse) ProtoMessage()    {}
 func (*IgnitionPutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{21}
+	return fileDescriptor_ae62049dfcf497b5, []int{24}
 }
 
 func (m *IgnitionPutResponse) XXX_Unmarshal(b []byte) error {
@@ -831,6 +1079,13 @@ func (m *IgnitionPutResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_IgnitionPutResponse proto.InternalMessageInfo
 
+func (m *IgnitionPutResponse) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type IgnitionGetRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -842,7 +1097,7 @@ func (m *IgnitionGetRequest) Reset()         { *m = IgnitionGetRequest{} }
 func (m *IgnitionGetRequest) String() string { return proto.CompactTextString(m) }
 func (*IgnitionGetRequest) ProtoMessage()    {}
 func (*IgnitionGetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{22}
+	return fileDescriptor_ae62049dfcf497b5, []int{25}
 }
 
 func (m *IgnitionGetRequest) XXX_Unmarshal(b []byte) error {
@@ -872,6 +1127,7 @@ func (m *IgnitionGetRequest) GetName() string {
 
 type IgnitionGetResponse struct {
 	Config               []byte   `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ResourceVersion      string   `protobuf:"bytes,2,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -881,7 +1137,7 @@ func (m *IgnitionGetResponse) Reset()         { *m = IgnitionGetResponse{} }
 func (m *IgnitionGetResponse) String() string { return proto.CompactTextString(m) }
 func (*IgnitionGetResponse) ProtoMessage()    {}
 func (*IgnitionGetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{23}
+	return fileDescriptor_ae62049dfcf497b5, []int{26}
 }
 
 func (m *IgnitionGetResponse) XXX_Unmarshal(b []byte) error {
@@ -909,8 +1165,19 @@ func (m *IgnitionGetResponse) GetConfig() []byte {
 	return nil
 }
 
+func (m *IgnitionGetResponse) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type IgnitionDeleteRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// delete even if Groups or Profiles still reference it
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// expected resource version, if any
+	ResourceVersion      string   `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -920,7 +1187,7 @@ func (m *IgnitionDeleteRequest) Reset()         { *m = IgnitionDeleteRequest{} }
 func (m *IgnitionDeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*IgnitionDeleteRequest) ProtoMessage()    {}
 func (*IgnitionDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{24}
+	return fileDescriptor_ae62049dfcf497b5, []int{27}
 }
 
 func (m *IgnitionDeleteRequest) XXX_Unmarshal(b []byte) error {
@@ -948,6 +1215,20 @@ func (m *IgnitionDeleteRequest) GetName() string {
 	return ""
 }
 
+func (m *IgnitionDeleteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *IgnitionDeleteRequest) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type IgnitionDeleteResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -958,7 +1239,7 @@ func (m *IgnitionDeleteResponse) Reset()         { *m = IgnitionDeleteResponse{}
 func (m *IgnitionDeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*IgnitionDeleteResponse) ProtoMessage()    {}
 func (*IgnitionDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{25}
+	return fileDescriptor_ae62049dfcf497b5, []int{28}
 }
 
 func (m *IgnitionDeleteResponse) XXX_Unmarshal(b []byte) error {
@@ -979,9 +1260,82 @@ func (m *IgnitionDeleteResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_IgnitionDeleteResponse proto.InternalMessageInfo
 
+type IgnitionListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IgnitionListRequest) Reset()         { *m = IgnitionListRequest{} }
+func (m *IgnitionListRequest) String() string { return proto.CompactTextString(m) }
+func (*IgnitionListRequest) ProtoMessage()    {}
+func (*IgnitionListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{29}
+}
+
+func (m *IgnitionListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IgnitionListRequest.Unmarshal(m, b)
+}
+func (m *IgnitionListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IgnitionListRequest.Marshal(b, m, deterministic)
+}
+func (m *IgnitionListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IgnitionListRequest.Merge(m, src)
+}
+func (m *IgnitionListRequest) XXX_Size() int {
+	return xxx_messageInfo_IgnitionListRequest.Size(m)
+}
+func (m *IgnitionListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IgnitionListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IgnitionListRequest proto.InternalMessageInfo
+
+type IgnitionListResponse struct {
+	Templates            []*storagepb.TemplateInfo `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *IgnitionListResponse) Reset()         { *m = IgnitionListResponse{} }
+func (m *IgnitionListResponse) String() string { return proto.CompactTextString(m) }
+func (*IgnitionListResponse) ProtoMessage()    {}
+func (*IgnitionListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{30}
+}
+
+func (m *IgnitionListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IgnitionListResponse.Unmarshal(m, b)
+}
+func (m *IgnitionListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IgnitionListResponse.Marshal(b, m, deterministic)
+}
+func (m *IgnitionListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IgnitionListResponse.Merge(m, src)
+}
+func (m *IgnitionListResponse) XXX_Size() int {
+	return xxx_messageInfo_IgnitionListResponse.Size(m)
+}
+func (m *IgnitionListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IgnitionListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IgnitionListResponse proto.InternalMessageInfo
+
+func (m *IgnitionListResponse) GetTemplates() []*storagepb.TemplateInfo {
+	if m != nil {
+		return m.Templates
+	}
+	return nil
+}
+
 type GenericPutRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Config               []byte   `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Config []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// expected resource version, if any, or "none" if the template must not
+	// exist
+	ResourceVersion      string   `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -991,7 +1345,7 @@ func (m *GenericPutRequest) Reset()         { *m = GenericPutRequest{} }
 func (m *GenericPutRequest) String() string { return proto.CompactTextString(m) }
 func (*GenericPutRequest) ProtoMessage()    {}
 func (*GenericPutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{26}
+	return fileDescriptor_ae62049dfcf497b5, []int{31}
 }
 
 func (m *GenericPutRequest) XXX_Unmarshal(b []byte) error {
@@ -1026,7 +1380,16 @@ func (m *GenericPutRequest) GetConfig() []byte {
 	return nil
 }
 
+func (m *GenericPutRequest) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type GenericPutResponse struct {
+	// resource version of the written resource
+	ResourceVersion      string   `protobuf:"bytes,1,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1036,7 +1399,7 @@ func (m *GenericPutResponse) Reset()         { *m = GenericPutResponse{} }
 func (m *GenericPutResponse) String() string { return proto.CompactTextString(m) }
 func (*GenericPutResponse) ProtoMessage()    {}
 func (*GenericPutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{27}
+	return fileDescriptor_ae62049dfcf497b5, []int{32}
 }
 
 func (m *GenericPutResponse) XXX_Unmarshal(b []byte) error {
@@ -1057,6 +1420,13 @@ func (m *GenericPutResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GenericPutResponse proto.InternalMessageInfo
 
+func (m *GenericPutResponse) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type GenericGetRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1068,7 +1438,7 @@ func (m *GenericGetRequest) Reset()         { *m = GenericGetRequest{} }
 func (m *GenericGetRequest) String() string { return proto.CompactTextString(m) }
 func (*GenericGetRequest) ProtoMessage()    {}
 func (*GenericGetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{28}
+	return fileDescriptor_ae62049dfcf497b5, []int{33}
 }
 
 func (m *GenericGetRequest) XXX_Unmarshal(b []byte) error {
@@ -1098,6 +1468,7 @@ func (m *GenericGetRequest) GetName() string {
 
 type GenericGetResponse struct {
 	Config               []byte   `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ResourceVersion      string   `protobuf:"bytes,2,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1107,7 +1478,7 @@ func (m *GenericGetResponse) Reset()         { *m = GenericGetResponse{} }
 func (m *GenericGetResponse) String() string { return proto.CompactTextString(m) }
 func (*GenericGetResponse) ProtoMessage()    {}
 func (*GenericGetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{29}
+	return fileDescriptor_ae62049dfcf497b5, []int{34}
 }
 
 func (m *GenericGetResponse) XXX_Unmarshal(b []byte) error {
@@ -1135,8 +1506,19 @@ func (m *GenericGetResponse) GetConfig() []byte {
 	return nil
 }
 
+func (m *GenericGetResponse) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 type GenericDeleteRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// delete even if Groups or Profiles still reference it
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// expected resource version, if any
+	ResourceVersion      string   `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1146,7 +1528,7 @@ func (m *GenericDeleteRequest) Reset()         { *m = GenericDeleteRequest{} }
 func (m *GenericDeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*GenericDeleteRequest) ProtoMessage()    {}
 func (*GenericDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{30}
+	return fileDescriptor_ae62049dfcf497b5, []int{35}
 }
 
 func (m *GenericDeleteRequest) XXX_Unmarshal(b []byte) error {
@@ -1174,8 +1556,22 @@ func (m *GenericDeleteRequest) GetName() string {
 	return ""
 }
 
-type GenericDeleteResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+func (m *GenericDeleteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *GenericDeleteRequest) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+type GenericDeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
@@ -1184,7 +1580,7 @@ func (m *GenericDeleteResponse) Reset()         { *m = GenericDeleteResponse{} }
 func (m *GenericDeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*GenericDeleteResponse) ProtoMessage()    {}
 func (*GenericDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ae62049dfcf497b5, []int{31}
+	return fileDescriptor_ae62049dfcf497b5, []int{36}
 }
 
 func (m *GenericDeleteResponse) XXX_Unmarshal(b []byte) error {
@@ -1205,6 +1601,1599 @@ func (m *GenericDeleteResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GenericDeleteResponse proto.InternalMessageInfo
 
+type GenericListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenericListRequest) Reset()         { *m = GenericListRequest{} }
+func (m *GenericListRequest) String() string { return proto.CompactTextString(m) }
+func (*GenericListRequest) ProtoMessage()    {}
+func (*GenericListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{37}
+}
+
+func (m *GenericListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenericListRequest.Unmarshal(m, b)
+}
+func (m *GenericListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenericListRequest.Marshal(b, m, deterministic)
+}
+func (m *GenericListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenericListRequest.Merge(m, src)
+}
+func (m *GenericListRequest) XXX_Size() int {
+	return xxx_messageInfo_GenericListRequest.Size(m)
+}
+func (m *GenericListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenericListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenericListRequest proto.InternalMessageInfo
+
+type GenericListResponse struct {
+	Templates            []*storagepb.TemplateInfo `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GenericListResponse) Reset()         { *m = GenericListResponse{} }
+func (m *GenericListResponse) String() string { return proto.CompactTextString(m) }
+func (*GenericListResponse) ProtoMessage()    {}
+func (*GenericListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{38}
+}
+
+func (m *GenericListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenericListResponse.Unmarshal(m, b)
+}
+func (m *GenericListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenericListResponse.Marshal(b, m, deterministic)
+}
+func (m *GenericListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenericListResponse.Merge(m, src)
+}
+func (m *GenericListResponse) XXX_Size() int {
+	return xxx_messageInfo_GenericListResponse.Size(m)
+}
+func (m *GenericListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenericListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenericListResponse proto.InternalMessageInfo
+
+func (m *GenericListResponse) GetTemplates() []*storagepb.TemplateInfo {
+	if m != nil {
+		return m.Templates
+	}
+	return nil
+}
+
+type CloudPutRequest struct {
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Config []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// expected resource version, if any, or "none" if the template must not
+	// exist
+	ResourceVersion      string   `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloudPutRequest) Reset()         { *m = CloudPutRequest{} }
+func (m *CloudPutRequest) String() string { return proto.CompactTextString(m) }
+func (*CloudPutRequest) ProtoMessage()    {}
+func (*CloudPutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{39}
+}
+
+func (m *CloudPutRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloudPutRequest.Unmarshal(m, b)
+}
+func (m *CloudPutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloudPutRequest.Marshal(b, m, deterministic)
+}
+func (m *CloudPutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudPutRequest.Merge(m, src)
+}
+func (m *CloudPutRequest) XXX_Size() int {
+	return xxx_messageInfo_CloudPutRequest.Size(m)
+}
+func (m *CloudPutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudPutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudPutRequest proto.InternalMessageInfo
+
+func (m *CloudPutRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CloudPutRequest) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *CloudPutRequest) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+type CloudPutResponse struct {
+	// resource version of the written resource
+	ResourceVersion      string   `protobuf:"bytes,1,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloudPutResponse) Reset()         { *m = CloudPutResponse{} }
+func (m *CloudPutResponse) String() string { return proto.CompactTextString(m) }
+func (*CloudPutResponse) ProtoMessage()    {}
+func (*CloudPutResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{40}
+}
+
+func (m *CloudPutResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloudPutResponse.Unmarshal(m, b)
+}
+func (m *CloudPutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloudPutResponse.Marshal(b, m, deterministic)
+}
+func (m *CloudPutResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudPutResponse.Merge(m, src)
+}
+func (m *CloudPutResponse) XXX_Size() int {
+	return xxx_messageInfo_CloudPutResponse.Size(m)
+}
+func (m *CloudPutResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudPutResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudPutResponse proto.InternalMessageInfo
+
+func (m *CloudPutResponse) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+type CloudGetRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloudGetRequest) Reset()         { *m = CloudGetRequest{} }
+func (m *CloudGetRequest) String() string { return proto.CompactTextString(m) }
+func (*CloudGetRequest) ProtoMessage()    {}
+func (*CloudGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{41}
+}
+
+func (m *CloudGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloudGetRequest.Unmarshal(m, b)
+}
+func (m *CloudGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloudGetRequest.Marshal(b, m, deterministic)
+}
+func (m *CloudGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudGetRequest.Merge(m, src)
+}
+func (m *CloudGetRequest) XXX_Size() int {
+	return xxx_messageInfo_CloudGetRequest.Size(m)
+}
+func (m *CloudGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudGetRequest proto.InternalMessageInfo
+
+func (m *CloudGetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CloudGetResponse struct {
+	Config               []byte   `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ResourceVersion      string   `protobuf:"bytes,2,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloudGetResponse) Reset()         { *m = CloudGetResponse{} }
+func (m *CloudGetResponse) String() string { return proto.CompactTextString(m) }
+func (*CloudGetResponse) ProtoMessage()    {}
+func (*CloudGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{42}
+}
+
+func (m *CloudGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloudGetResponse.Unmarshal(m, b)
+}
+func (m *CloudGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloudGetResponse.Marshal(b, m, deterministic)
+}
+func (m *CloudGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudGetResponse.Merge(m, src)
+}
+func (m *CloudGetResponse) XXX_Size() int {
+	return xxx_messageInfo_CloudGetResponse.Size(m)
+}
+func (m *CloudGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudGetResponse proto.InternalMessageInfo
+
+func (m *CloudGetResponse) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *CloudGetResponse) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+type CloudDeleteRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// delete even if Groups or Profiles still reference it
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// expected resource version, if any
+	ResourceVersion      string   `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloudDeleteRequest) Reset()         { *m = CloudDeleteRequest{} }
+func (m *CloudDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*CloudDeleteRequest) ProtoMessage()    {}
+func (*CloudDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{43}
+}
+
+func (m *CloudDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloudDeleteRequest.Unmarshal(m, b)
+}
+func (m *CloudDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloudDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *CloudDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudDeleteRequest.Merge(m, src)
+}
+func (m *CloudDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_CloudDeleteRequest.Size(m)
+}
+func (m *CloudDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudDeleteRequest proto.InternalMessageInfo
+
+func (m *CloudDeleteRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CloudDeleteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *CloudDeleteRequest) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+type CloudDeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloudDeleteResponse) Reset()         { *m = CloudDeleteResponse{} }
+func (m *CloudDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*CloudDeleteResponse) ProtoMessage()    {}
+func (*CloudDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{44}
+}
+
+func (m *CloudDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloudDeleteResponse.Unmarshal(m, b)
+}
+func (m *CloudDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloudDeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *CloudDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudDeleteResponse.Merge(m, src)
+}
+func (m *CloudDeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_CloudDeleteResponse.Size(m)
+}
+func (m *CloudDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudDeleteResponse proto.InternalMessageInfo
+
+type CloudListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloudListRequest) Reset()         { *m = CloudListRequest{} }
+func (m *CloudListRequest) String() string { return proto.CompactTextString(m) }
+func (*CloudListRequest) ProtoMessage()    {}
+func (*CloudListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{45}
+}
+
+func (m *CloudListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloudListRequest.Unmarshal(m, b)
+}
+func (m *CloudListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloudListRequest.Marshal(b, m, deterministic)
+}
+func (m *CloudListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudListRequest.Merge(m, src)
+}
+func (m *CloudListRequest) XXX_Size() int {
+	return xxx_messageInfo_CloudListRequest.Size(m)
+}
+func (m *CloudListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudListRequest proto.InternalMessageInfo
+
+type CloudListResponse struct {
+	Templates            []*storagepb.TemplateInfo `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *CloudListResponse) Reset()         { *m = CloudListResponse{} }
+func (m *CloudListResponse) String() string { return proto.CompactTextString(m) }
+func (*CloudListResponse) ProtoMessage()    {}
+func (*CloudListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{46}
+}
+
+func (m *CloudListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloudListResponse.Unmarshal(m, b)
+}
+func (m *CloudListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloudListResponse.Marshal(b, m, deterministic)
+}
+func (m *CloudListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloudListResponse.Merge(m, src)
+}
+func (m *CloudListResponse) XXX_Size() int {
+	return xxx_messageInfo_CloudListResponse.Size(m)
+}
+func (m *CloudListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloudListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloudListResponse proto.InternalMessageInfo
+
+func (m *CloudListResponse) GetTemplates() []*storagepb.TemplateInfo {
+	if m != nil {
+		return m.Templates
+	}
+	return nil
+}
+
+type PartialPutRequest struct {
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Config []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// expected resource version, if any, or "none" if the template must not
+	// exist
+	ResourceVersion      string   `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartialPutRequest) Reset()         { *m = PartialPutRequest{} }
+func (m *PartialPutRequest) String() string { return proto.CompactTextString(m) }
+func (*PartialPutRequest) ProtoMessage()    {}
+func (*PartialPutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{47}
+}
+
+func (m *PartialPutRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialPutRequest.Unmarshal(m, b)
+}
+func (m *PartialPutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialPutRequest.Marshal(b, m, deterministic)
+}
+func (m *PartialPutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialPutRequest.Merge(m, src)
+}
+func (m *PartialPutRequest) XXX_Size() int {
+	return xxx_messageInfo_PartialPutRequest.Size(m)
+}
+func (m *PartialPutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialPutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialPutRequest proto.InternalMessageInfo
+
+func (m *PartialPutRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PartialPutRequest) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *PartialPutRequest) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+type PartialPutResponse struct {
+	// resource version of the written resource
+	ResourceVersion      string   `protobuf:"bytes,1,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartialPutResponse) Reset()         { *m = PartialPutResponse{} }
+func (m *PartialPutResponse) String() string { return proto.CompactTextString(m) }
+func (*PartialPutResponse) ProtoMessage()    {}
+func (*PartialPutResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{48}
+}
+
+func (m *PartialPutResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialPutResponse.Unmarshal(m, b)
+}
+func (m *PartialPutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialPutResponse.Marshal(b, m, deterministic)
+}
+func (m *PartialPutResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialPutResponse.Merge(m, src)
+}
+func (m *PartialPutResponse) XXX_Size() int {
+	return xxx_messageInfo_PartialPutResponse.Size(m)
+}
+func (m *PartialPutResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialPutResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialPutResponse proto.InternalMessageInfo
+
+func (m *PartialPutResponse) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+type PartialGetRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartialGetRequest) Reset()         { *m = PartialGetRequest{} }
+func (m *PartialGetRequest) String() string { return proto.CompactTextString(m) }
+func (*PartialGetRequest) ProtoMessage()    {}
+func (*PartialGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{49}
+}
+
+func (m *PartialGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialGetRequest.Unmarshal(m, b)
+}
+func (m *PartialGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialGetRequest.Marshal(b, m, deterministic)
+}
+func (m *PartialGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialGetRequest.Merge(m, src)
+}
+func (m *PartialGetRequest) XXX_Size() int {
+	return xxx_messageInfo_PartialGetRequest.Size(m)
+}
+func (m *PartialGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialGetRequest proto.InternalMessageInfo
+
+func (m *PartialGetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type PartialGetResponse struct {
+	Config               []byte   `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ResourceVersion      string   `protobuf:"bytes,2,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartialGetResponse) Reset()         { *m = PartialGetResponse{} }
+func (m *PartialGetResponse) String() string { return proto.CompactTextString(m) }
+func (*PartialGetResponse) ProtoMessage()    {}
+func (*PartialGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{50}
+}
+
+func (m *PartialGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialGetResponse.Unmarshal(m, b)
+}
+func (m *PartialGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialGetResponse.Marshal(b, m, deterministic)
+}
+func (m *PartialGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialGetResponse.Merge(m, src)
+}
+func (m *PartialGetResponse) XXX_Size() int {
+	return xxx_messageInfo_PartialGetResponse.Size(m)
+}
+func (m *PartialGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialGetResponse proto.InternalMessageInfo
+
+func (m *PartialGetResponse) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *PartialGetResponse) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+type PartialDeleteRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// expected resource version, if any
+	ResourceVersion      string   `protobuf:"bytes,2,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartialDeleteRequest) Reset()         { *m = PartialDeleteRequest{} }
+func (m *PartialDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*PartialDeleteRequest) ProtoMessage()    {}
+func (*PartialDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{51}
+}
+
+func (m *PartialDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialDeleteRequest.Unmarshal(m, b)
+}
+func (m *PartialDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *PartialDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialDeleteRequest.Merge(m, src)
+}
+func (m *PartialDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_PartialDeleteRequest.Size(m)
+}
+func (m *PartialDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialDeleteRequest proto.InternalMessageInfo
+
+func (m *PartialDeleteRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PartialDeleteRequest) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+type PartialDeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartialDeleteResponse) Reset()         { *m = PartialDeleteResponse{} }
+func (m *PartialDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*PartialDeleteResponse) ProtoMessage()    {}
+func (*PartialDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{52}
+}
+
+func (m *PartialDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialDeleteResponse.Unmarshal(m, b)
+}
+func (m *PartialDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialDeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *PartialDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialDeleteResponse.Merge(m, src)
+}
+func (m *PartialDeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_PartialDeleteResponse.Size(m)
+}
+func (m *PartialDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialDeleteResponse proto.InternalMessageInfo
+
+type PartialListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartialListRequest) Reset()         { *m = PartialListRequest{} }
+func (m *PartialListRequest) String() string { return proto.CompactTextString(m) }
+func (*PartialListRequest) ProtoMessage()    {}
+func (*PartialListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{53}
+}
+
+func (m *PartialListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialListRequest.Unmarshal(m, b)
+}
+func (m *PartialListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialListRequest.Marshal(b, m, deterministic)
+}
+func (m *PartialListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialListRequest.Merge(m, src)
+}
+func (m *PartialListRequest) XXX_Size() int {
+	return xxx_messageInfo_PartialListRequest.Size(m)
+}
+func (m *PartialListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialListRequest proto.InternalMessageInfo
+
+type PartialListResponse struct {
+	Names                []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartialListResponse) Reset()         { *m = PartialListResponse{} }
+func (m *PartialListResponse) String() string { return proto.CompactTextString(m) }
+func (*PartialListResponse) ProtoMessage()    {}
+func (*PartialListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{54}
+}
+
+func (m *PartialListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialListResponse.Unmarshal(m, b)
+}
+func (m *PartialListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialListResponse.Marshal(b, m, deterministic)
+}
+func (m *PartialListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialListResponse.Merge(m, src)
+}
+func (m *PartialListResponse) XXX_Size() int {
+	return xxx_messageInfo_PartialListResponse.Size(m)
+}
+func (m *PartialListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialListResponse proto.InternalMessageInfo
+
+func (m *PartialListResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+type InstanceRecordRequest struct {
+	Labels               map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Group                string            `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Profile              string            `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	Endpoint             string            `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *InstanceRecordRequest) Reset()         { *m = InstanceRecordRequest{} }
+func (m *InstanceRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*InstanceRecordRequest) ProtoMessage()    {}
+func (*InstanceRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{55}
+}
+
+func (m *InstanceRecordRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstanceRecordRequest.Unmarshal(m, b)
+}
+func (m *InstanceRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstanceRecordRequest.Marshal(b, m, deterministic)
+}
+func (m *InstanceRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstanceRecordRequest.Merge(m, src)
+}
+func (m *InstanceRecordRequest) XXX_Size() int {
+	return xxx_messageInfo_InstanceRecordRequest.Size(m)
+}
+func (m *InstanceRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstanceRecordRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstanceRecordRequest proto.InternalMessageInfo
+
+func (m *InstanceRecordRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *InstanceRecordRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *InstanceRecordRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *InstanceRecordRequest) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+type InstanceRecordResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InstanceRecordResponse) Reset()         { *m = InstanceRecordResponse{} }
+func (m *InstanceRecordResponse) String() string { return proto.CompactTextString(m) }
+func (*InstanceRecordResponse) ProtoMessage()    {}
+func (*InstanceRecordResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{56}
+}
+
+func (m *InstanceRecordResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstanceRecordResponse.Unmarshal(m, b)
+}
+func (m *InstanceRecordResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstanceRecordResponse.Marshal(b, m, deterministic)
+}
+func (m *InstanceRecordResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstanceRecordResponse.Merge(m, src)
+}
+func (m *InstanceRecordResponse) XXX_Size() int {
+	return xxx_messageInfo_InstanceRecordResponse.Size(m)
+}
+func (m *InstanceRecordResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstanceRecordResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstanceRecordResponse proto.InternalMessageInfo
+
+type InstanceGetRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InstanceGetRequest) Reset()         { *m = InstanceGetRequest{} }
+func (m *InstanceGetRequest) String() string { return proto.CompactTextString(m) }
+func (*InstanceGetRequest) ProtoMessage()    {}
+func (*InstanceGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{57}
+}
+
+func (m *InstanceGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstanceGetRequest.Unmarshal(m, b)
+}
+func (m *InstanceGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstanceGetRequest.Marshal(b, m, deterministic)
+}
+func (m *InstanceGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstanceGetRequest.Merge(m, src)
+}
+func (m *InstanceGetRequest) XXX_Size() int {
+	return xxx_messageInfo_InstanceGetRequest.Size(m)
+}
+func (m *InstanceGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstanceGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstanceGetRequest proto.InternalMessageInfo
+
+func (m *InstanceGetRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type InstanceGetResponse struct {
+	Instance             *storagepb.Instance `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *InstanceGetResponse) Reset()         { *m = InstanceGetResponse{} }
+func (m *InstanceGetResponse) String() string { return proto.CompactTextString(m) }
+func (*InstanceGetResponse) ProtoMessage()    {}
+func (*InstanceGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{58}
+}
+
+func (m *InstanceGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstanceGetResponse.Unmarshal(m, b)
+}
+func (m *InstanceGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstanceGetResponse.Marshal(b, m, deterministic)
+}
+func (m *InstanceGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstanceGetResponse.Merge(m, src)
+}
+func (m *InstanceGetResponse) XXX_Size() int {
+	return xxx_messageInfo_InstanceGetResponse.Size(m)
+}
+func (m *InstanceGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstanceGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstanceGetResponse proto.InternalMessageInfo
+
+func (m *InstanceGetResponse) GetInstance() *storagepb.Instance {
+	if m != nil {
+		return m.Instance
+	}
+	return nil
+}
+
+type InstanceListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InstanceListRequest) Reset()         { *m = InstanceListRequest{} }
+func (m *InstanceListRequest) String() string { return proto.CompactTextString(m) }
+func (*InstanceListRequest) ProtoMessage()    {}
+func (*InstanceListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{59}
+}
+
+func (m *InstanceListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstanceListRequest.Unmarshal(m, b)
+}
+func (m *InstanceListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstanceListRequest.Marshal(b, m, deterministic)
+}
+func (m *InstanceListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstanceListRequest.Merge(m, src)
+}
+func (m *InstanceListRequest) XXX_Size() int {
+	return xxx_messageInfo_InstanceListRequest.Size(m)
+}
+func (m *InstanceListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstanceListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstanceListRequest proto.InternalMessageInfo
+
+type InstanceListResponse struct {
+	Instances            []*storagepb.Instance `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *InstanceListResponse) Reset()         { *m = InstanceListResponse{} }
+func (m *InstanceListResponse) String() string { return proto.CompactTextString(m) }
+func (*InstanceListResponse) ProtoMessage()    {}
+func (*InstanceListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{60}
+}
+
+func (m *InstanceListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstanceListResponse.Unmarshal(m, b)
+}
+func (m *InstanceListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstanceListResponse.Marshal(b, m, deterministic)
+}
+func (m *InstanceListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstanceListResponse.Merge(m, src)
+}
+func (m *InstanceListResponse) XXX_Size() int {
+	return xxx_messageInfo_InstanceListResponse.Size(m)
+}
+func (m *InstanceListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstanceListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstanceListResponse proto.InternalMessageInfo
+
+func (m *InstanceListResponse) GetInstances() []*storagepb.Instance {
+	if m != nil {
+		return m.Instances
+	}
+	return nil
+}
+
+type StatusGetRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusGetRequest) Reset()         { *m = StatusGetRequest{} }
+func (m *StatusGetRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusGetRequest) ProtoMessage()    {}
+func (*StatusGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{61}
+}
+
+func (m *StatusGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusGetRequest.Unmarshal(m, b)
+}
+func (m *StatusGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusGetRequest.Marshal(b, m, deterministic)
+}
+func (m *StatusGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusGetRequest.Merge(m, src)
+}
+func (m *StatusGetRequest) XXX_Size() int {
+	return xxx_messageInfo_StatusGetRequest.Size(m)
+}
+func (m *StatusGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusGetRequest proto.InternalMessageInfo
+
+type StatusGetResponse struct {
+	// whether all stored resources can be parsed
+	Healthy bool `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// stored resources which can't be parsed
+	Invalid              []*storagepb.InvalidResource `protobuf:"bytes,2,rep,name=invalid,proto3" json:"invalid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *StatusGetResponse) Reset()         { *m = StatusGetResponse{} }
+func (m *StatusGetResponse) String() string { return proto.CompactTextString(m) }
+func (*StatusGetResponse) ProtoMessage()    {}
+func (*StatusGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{62}
+}
+
+func (m *StatusGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusGetResponse.Unmarshal(m, b)
+}
+func (m *StatusGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusGetResponse.Marshal(b, m, deterministic)
+}
+func (m *StatusGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusGetResponse.Merge(m, src)
+}
+func (m *StatusGetResponse) XXX_Size() int {
+	return xxx_messageInfo_StatusGetResponse.Size(m)
+}
+func (m *StatusGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusGetResponse proto.InternalMessageInfo
+
+func (m *StatusGetResponse) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *StatusGetResponse) GetInvalid() []*storagepb.InvalidResource {
+	if m != nil {
+		return m.Invalid
+	}
+	return nil
+}
+
+type BatchApplyRequest struct {
+	// resources to write and delete together
+	Batch *storagepb.Batch `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+	// delete resources even if Groups or Profiles still reference them
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// validate the batch without applying it
+	DryRun               bool     `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchApplyRequest) Reset()         { *m = BatchApplyRequest{} }
+func (m *BatchApplyRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchApplyRequest) ProtoMessage()    {}
+func (*BatchApplyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{63}
+}
+
+func (m *BatchApplyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchApplyRequest.Unmarshal(m, b)
+}
+func (m *BatchApplyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchApplyRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchApplyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchApplyRequest.Merge(m, src)
+}
+func (m *BatchApplyRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchApplyRequest.Size(m)
+}
+func (m *BatchApplyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchApplyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchApplyRequest proto.InternalMessageInfo
+
+func (m *BatchApplyRequest) GetBatch() *storagepb.Batch {
+	if m != nil {
+		return m.Batch
+	}
+	return nil
+}
+
+func (m *BatchApplyRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *BatchApplyRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type BatchApplyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchApplyResponse) Reset()         { *m = BatchApplyResponse{} }
+func (m *BatchApplyResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchApplyResponse) ProtoMessage()    {}
+func (*BatchApplyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{64}
+}
+
+func (m *BatchApplyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchApplyResponse.Unmarshal(m, b)
+}
+func (m *BatchApplyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchApplyResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchApplyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchApplyResponse.Merge(m, src)
+}
+func (m *BatchApplyResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchApplyResponse.Size(m)
+}
+func (m *BatchApplyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchApplyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchApplyResponse proto.InternalMessageInfo
+
+// Archives
+type ArchiveExportRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveExportRequest) Reset()         { *m = ArchiveExportRequest{} }
+func (m *ArchiveExportRequest) String() string { return proto.CompactTextString(m) }
+func (*ArchiveExportRequest) ProtoMessage()    {}
+func (*ArchiveExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{65}
+}
+
+func (m *ArchiveExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveExportRequest.Unmarshal(m, b)
+}
+func (m *ArchiveExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveExportRequest.Marshal(b, m, deterministic)
+}
+func (m *ArchiveExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveExportRequest.Merge(m, src)
+}
+func (m *ArchiveExportRequest) XXX_Size() int {
+	return xxx_messageInfo_ArchiveExportRequest.Size(m)
+}
+func (m *ArchiveExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveExportRequest proto.InternalMessageInfo
+
+type ArchiveExportResponse struct {
+	// next chunk of the archive
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveExportResponse) Reset()         { *m = ArchiveExportResponse{} }
+func (m *ArchiveExportResponse) String() string { return proto.CompactTextString(m) }
+func (*ArchiveExportResponse) ProtoMessage()    {}
+func (*ArchiveExportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{66}
+}
+
+func (m *ArchiveExportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveExportResponse.Unmarshal(m, b)
+}
+func (m *ArchiveExportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveExportResponse.Marshal(b, m, deterministic)
+}
+func (m *ArchiveExportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveExportResponse.Merge(m, src)
+}
+func (m *ArchiveExportResponse) XXX_Size() int {
+	return xxx_messageInfo_ArchiveExportResponse.Size(m)
+}
+func (m *ArchiveExportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveExportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveExportResponse proto.InternalMessageInfo
+
+func (m *ArchiveExportResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ArchiveImportRequest struct {
+	// next chunk of the archive
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveImportRequest) Reset()         { *m = ArchiveImportRequest{} }
+func (m *ArchiveImportRequest) String() string { return proto.CompactTextString(m) }
+func (*ArchiveImportRequest) ProtoMessage()    {}
+func (*ArchiveImportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{67}
+}
+
+func (m *ArchiveImportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveImportRequest.Unmarshal(m, b)
+}
+func (m *ArchiveImportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveImportRequest.Marshal(b, m, deterministic)
+}
+func (m *ArchiveImportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveImportRequest.Merge(m, src)
+}
+func (m *ArchiveImportRequest) XXX_Size() int {
+	return xxx_messageInfo_ArchiveImportRequest.Size(m)
+}
+func (m *ArchiveImportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveImportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveImportRequest proto.InternalMessageInfo
+
+func (m *ArchiveImportRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ArchiveImportResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveImportResponse) Reset()         { *m = ArchiveImportResponse{} }
+func (m *ArchiveImportResponse) String() string { return proto.CompactTextString(m) }
+func (*ArchiveImportResponse) ProtoMessage()    {}
+func (*ArchiveImportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{68}
+}
+
+func (m *ArchiveImportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveImportResponse.Unmarshal(m, b)
+}
+func (m *ArchiveImportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveImportResponse.Marshal(b, m, deterministic)
+}
+func (m *ArchiveImportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveImportResponse.Merge(m, src)
+}
+func (m *ArchiveImportResponse) XXX_Size() int {
+	return xxx_messageInfo_ArchiveImportResponse.Size(m)
+}
+func (m *ArchiveImportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveImportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveImportResponse proto.InternalMessageInfo
+
+// History
+type HistoryListRequest struct {
+	// resource kind (Group, Profile, Ignition, Generic, Cloud, or Partial)
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Group or Profile id or template name
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HistoryListRequest) Reset()         { *m = HistoryListRequest{} }
+func (m *HistoryListRequest) String() string { return proto.CompactTextString(m) }
+func (*HistoryListRequest) ProtoMessage()    {}
+func (*HistoryListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{69}
+}
+
+func (m *HistoryListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryListRequest.Unmarshal(m, b)
+}
+func (m *HistoryListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryListRequest.Marshal(b, m, deterministic)
+}
+func (m *HistoryListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryListRequest.Merge(m, src)
+}
+func (m *HistoryListRequest) XXX_Size() int {
+	return xxx_messageInfo_HistoryListRequest.Size(m)
+}
+func (m *HistoryListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryListRequest proto.InternalMessageInfo
+
+func (m *HistoryListRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *HistoryListRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type HistoryListResponse struct {
+	// kept revisions, oldest first
+	Revisions            []*storagepb.Revision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *HistoryListResponse) Reset()         { *m = HistoryListResponse{} }
+func (m *HistoryListResponse) String() string { return proto.CompactTextString(m) }
+func (*HistoryListResponse) ProtoMessage()    {}
+func (*HistoryListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{70}
+}
+
+func (m *HistoryListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryListResponse.Unmarshal(m, b)
+}
+func (m *HistoryListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryListResponse.Marshal(b, m, deterministic)
+}
+func (m *HistoryListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryListResponse.Merge(m, src)
+}
+func (m *HistoryListResponse) XXX_Size() int {
+	return xxx_messageInfo_HistoryListResponse.Size(m)
+}
+func (m *HistoryListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryListResponse proto.InternalMessageInfo
+
+func (m *HistoryListResponse) GetRevisions() []*storagepb.Revision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+type HistoryRollbackRequest struct {
+	// resource kind (Group, Profile, Ignition, Generic, Cloud, or Partial)
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Group or Profile id or template name
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// revision number to restore
+	Revision int64 `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	// delete the resource even if Groups or Profiles still reference it
+	Force                bool     `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HistoryRollbackRequest) Reset()         { *m = HistoryRollbackRequest{} }
+func (m *HistoryRollbackRequest) String() string { return proto.CompactTextString(m) }
+func (*HistoryRollbackRequest) ProtoMessage()    {}
+func (*HistoryRollbackRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{71}
+}
+
+func (m *HistoryRollbackRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryRollbackRequest.Unmarshal(m, b)
+}
+func (m *HistoryRollbackRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryRollbackRequest.Marshal(b, m, deterministic)
+}
+func (m *HistoryRollbackRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryRollbackRequest.Merge(m, src)
+}
+func (m *HistoryRollbackRequest) XXX_Size() int {
+	return xxx_messageInfo_HistoryRollbackRequest.Size(m)
+}
+func (m *HistoryRollbackRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryRollbackRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryRollbackRequest proto.InternalMessageInfo
+
+func (m *HistoryRollbackRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *HistoryRollbackRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HistoryRollbackRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *HistoryRollbackRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type HistoryRollbackResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HistoryRollbackResponse) Reset()         { *m = HistoryRollbackResponse{} }
+func (m *HistoryRollbackResponse) String() string { return proto.CompactTextString(m) }
+func (*HistoryRollbackResponse) ProtoMessage()    {}
+func (*HistoryRollbackResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{72}
+}
+
+func (m *HistoryRollbackResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryRollbackResponse.Unmarshal(m, b)
+}
+func (m *HistoryRollbackResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryRollbackResponse.Marshal(b, m, deterministic)
+}
+func (m *HistoryRollbackResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryRollbackResponse.Merge(m, src)
+}
+func (m *HistoryRollbackResponse) XXX_Size() int {
+	return xxx_messageInfo_HistoryRollbackResponse.Size(m)
+}
+func (m *HistoryRollbackResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryRollbackResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryRollbackResponse proto.InternalMessageInfo
+
+// Audit
+type AuditListRequest struct {
+	// only list calls by clients with this certificate subject
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// only list calls which changed resources of this kind
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// only list calls which changed resources with this name
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// list at most this many of the most recent calls (0 for all)
+	Limit                int32    `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditListRequest) Reset()         { *m = AuditListRequest{} }
+func (m *AuditListRequest) String() string { return proto.CompactTextString(m) }
+func (*AuditListRequest) ProtoMessage()    {}
+func (*AuditListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{73}
+}
+
+func (m *AuditListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditListRequest.Unmarshal(m, b)
+}
+func (m *AuditListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditListRequest.Marshal(b, m, deterministic)
+}
+func (m *AuditListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditListRequest.Merge(m, src)
+}
+func (m *AuditListRequest) XXX_Size() int {
+	return xxx_messageInfo_AuditListRequest.Size(m)
+}
+func (m *AuditListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditListRequest proto.InternalMessageInfo
+
+func (m *AuditListRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *AuditListRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *AuditListRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AuditListRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type AuditListResponse struct {
+	// recorded calls, oldest first
+	Entries              []*storagepb.AuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *AuditListResponse) Reset()         { *m = AuditListResponse{} }
+func (m *AuditListResponse) String() string { return proto.CompactTextString(m) }
+func (*AuditListResponse) ProtoMessage()    {}
+func (*AuditListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae62049dfcf497b5, []int{74}
+}
+
+func (m *AuditListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditListResponse.Unmarshal(m, b)
+}
+func (m *AuditListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditListResponse.Marshal(b, m, deterministic)
+}
+func (m *AuditListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditListResponse.Merge(m, src)
+}
+func (m *AuditListResponse) XXX_Size() int {
+	return xxx_messageInfo_AuditListResponse.Size(m)
+}
+func (m *AuditListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditListResponse proto.InternalMessageInfo
+
+func (m *AuditListResponse) GetEntries() []*storagepb.AuditEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SelectGroupRequest)(nil), "serverpb.SelectGroupRequest")
 	proto.RegisterMapType((map[string]string)(nil), "serverpb.SelectGroupRequest.LabelsEntry")
@@ -1212,6 +3201,10 @@ func init() {
 	proto.RegisterType((*SelectProfileRequest)(nil), "serverpb.SelectProfileRequest")
 	proto.RegisterMapType((map[string]string)(nil), "serverpb.SelectProfileRequest.LabelsEntry")
 	proto.RegisterType((*SelectProfileResponse)(nil), "serverpb.SelectProfileResponse")
+	proto.RegisterType((*SelectExplainRequest)(nil), "serverpb.SelectExplainRequest")
+	proto.RegisterMapType((map[string]string)(nil), "serverpb.SelectExplainRequest.LabelsEntry")
+	proto.RegisterType((*SelectExplainResponse)(nil), "serverpb.SelectExplainResponse")
+	proto.RegisterType((*GroupExplanation)(nil), "serverpb.GroupExplanation")
 	proto.RegisterType((*GroupPutRequest)(nil), "serverpb.GroupPutRequest")
 	proto.RegisterType((*GroupPutResponse)(nil), "serverpb.GroupPutResponse")
 	proto.RegisterType((*GroupGetRequest)(nil), "serverpb.GroupGetRequest")
@@ -1229,17 +3222,59 @@ func init() {
 	proto.RegisterType((*ProfileListRequest)(nil), "serverpb.ProfileListRequest")
 	proto.RegisterType((*ProfileListResponse)(nil), "serverpb.ProfileListResponse")
 	proto.RegisterType((*IgnitionPutRequest)(nil), "serverpb.IgnitionPutRequest")
+	proto.RegisterMapType((map[string]string)(nil), "serverpb.IgnitionPutRequest.LabelsEntry")
 	proto.RegisterType((*IgnitionPutResponse)(nil), "serverpb.IgnitionPutResponse")
 	proto.RegisterType((*IgnitionGetRequest)(nil), "serverpb.IgnitionGetRequest")
 	proto.RegisterType((*IgnitionGetResponse)(nil), "serverpb.IgnitionGetResponse")
 	proto.RegisterType((*IgnitionDeleteRequest)(nil), "serverpb.IgnitionDeleteRequest")
 	proto.RegisterType((*IgnitionDeleteResponse)(nil), "serverpb.IgnitionDeleteResponse")
+	proto.RegisterType((*IgnitionListRequest)(nil), "serverpb.IgnitionListRequest")
+	proto.RegisterType((*IgnitionListResponse)(nil), "serverpb.IgnitionListResponse")
 	proto.RegisterType((*GenericPutRequest)(nil), "serverpb.GenericPutRequest")
 	proto.RegisterType((*GenericPutResponse)(nil), "serverpb.GenericPutResponse")
 	proto.RegisterType((*GenericGetRequest)(nil), "serverpb.GenericGetRequest")
 	proto.RegisterType((*GenericGetResponse)(nil), "serverpb.GenericGetResponse")
 	proto.RegisterType((*GenericDeleteRequest)(nil), "serverpb.GenericDeleteRequest")
 	proto.RegisterType((*GenericDeleteResponse)(nil), "serverpb.GenericDeleteResponse")
+	proto.RegisterType((*GenericListRequest)(nil), "serverpb.GenericListRequest")
+	proto.RegisterType((*GenericListResponse)(nil), "serverpb.GenericListResponse")
+	proto.RegisterType((*CloudPutRequest)(nil), "serverpb.CloudPutRequest")
+	proto.RegisterType((*CloudPutResponse)(nil), "serverpb.CloudPutResponse")
+	proto.RegisterType((*CloudGetRequest)(nil), "serverpb.CloudGetRequest")
+	proto.RegisterType((*CloudGetResponse)(nil), "serverpb.CloudGetResponse")
+	proto.RegisterType((*CloudDeleteRequest)(nil), "serverpb.CloudDeleteRequest")
+	proto.RegisterType((*CloudDeleteResponse)(nil), "serverpb.CloudDeleteResponse")
+	proto.RegisterType((*CloudListRequest)(nil), "serverpb.CloudListRequest")
+	proto.RegisterType((*CloudListResponse)(nil), "serverpb.CloudListResponse")
+	proto.RegisterType((*PartialPutRequest)(nil), "serverpb.PartialPutRequest")
+	proto.RegisterType((*PartialPutResponse)(nil), "serverpb.PartialPutResponse")
+	proto.RegisterType((*PartialGetRequest)(nil), "serverpb.PartialGetRequest")
+	proto.RegisterType((*PartialGetResponse)(nil), "serverpb.PartialGetResponse")
+	proto.RegisterType((*PartialDeleteRequest)(nil), "serverpb.PartialDeleteRequest")
+	proto.RegisterType((*PartialDeleteResponse)(nil), "serverpb.PartialDeleteResponse")
+	proto.RegisterType((*PartialListRequest)(nil), "serverpb.PartialListRequest")
+	proto.RegisterType((*PartialListResponse)(nil), "serverpb.PartialListResponse")
+	proto.RegisterType((*InstanceRecordRequest)(nil), "serverpb.InstanceRecordRequest")
+	proto.RegisterMapType((map[string]string)(nil), "serverpb.InstanceRecordRequest.LabelsEntry")
+	proto.RegisterType((*InstanceRecordResponse)(nil), "serverpb.InstanceRecordResponse")
+	proto.RegisterType((*InstanceGetRequest)(nil), "serverpb.InstanceGetRequest")
+	proto.RegisterType((*InstanceGetResponse)(nil), "serverpb.InstanceGetResponse")
+	proto.RegisterType((*InstanceListRequest)(nil), "serverpb.InstanceListRequest")
+	proto.RegisterType((*InstanceListResponse)(nil), "serverpb.InstanceListResponse")
+	proto.RegisterType((*StatusGetRequest)(nil), "serverpb.StatusGetRequest")
+	proto.RegisterType((*StatusGetResponse)(nil), "serverpb.StatusGetResponse")
+	proto.RegisterType((*BatchApplyRequest)(nil), "serverpb.BatchApplyRequest")
+	proto.RegisterType((*BatchApplyResponse)(nil), "serverpb.BatchApplyResponse")
+	proto.RegisterType((*ArchiveExportRequest)(nil), "serverpb.ArchiveExportRequest")
+	proto.RegisterType((*ArchiveExportResponse)(nil), "serverpb.ArchiveExportResponse")
+	proto.RegisterType((*ArchiveImportRequest)(nil), "serverpb.ArchiveImportRequest")
+	proto.RegisterType((*ArchiveImportResponse)(nil), "serverpb.ArchiveImportResponse")
+	proto.RegisterType((*HistoryListRequest)(nil), "serverpb.HistoryListRequest")
+	proto.RegisterType((*HistoryListResponse)(nil), "serverpb.HistoryListResponse")
+	proto.RegisterType((*HistoryRollbackRequest)(nil), "serverpb.HistoryRollbackRequest")
+	proto.RegisterType((*HistoryRollbackResponse)(nil), "serverpb.HistoryRollbackResponse")
+	proto.RegisterType((*AuditListRequest)(nil), "serverpb.AuditListRequest")
+	proto.RegisterType((*AuditListResponse)(nil), "serverpb.AuditListResponse")
 }
 
 func init() {
@@ -1247,40 +3282,89 @@ func init() {
 }
 
 var fileDescriptor_ae62049dfcf497b5 = []byte{
-	// 557 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x95, 0x53, 0x1a, 0xca, 0x04, 0x41, 0xb2, 0x71, 0xda, 0xa8, 0xa7, 0x62, 0x50, 0x6b, 0x95,
-	0xe2, 0x48, 0x45, 0x08, 0x5a, 0xa9, 0xa2, 0x44, 0x44, 0x11, 0x52, 0x0f, 0x55, 0xb8, 0x71, 0xb3,
-	0x93, 0xa9, 0x6b, 0xe1, 0x78, 0x8d, 0xbd, 0xae, 0xe8, 0xcf, 0xe0, 0xc0, 0xff, 0x45, 0x59, 0xcf,
-	0xda, 0x6b, 0x37, 0xfd, 0x08, 0xea, 0xc9, 0xbb, 0xb3, 0x6f, 0xde, 0xbc, 0xf7, 0x36, 0xd1, 0xc2,
-	0xde, 0xdc, 0x15, 0xd3, 0x4b, 0x8f, 0xff, 0x1e, 0xa4, 0x98, 0x5c, 0x61, 0x42, 0x9f, 0xd8, 0x1b,
-	0xcc, 0x31, 0x4d, 0x5d, 0x1f, 0x53, 0x27, 0x4e, 0xb8, 0xe0, 0x6c, 0x43, 0x1d, 0x6c, 0xdb, 0x65,
-	0x8b, 0xe0, 0x89, 0xeb, 0xa3, 0xfa, 0xc6, 0x9e, 0x5a, 0xe5, 0x3d, 0xd6, 0x1f, 0x03, 0xd8, 0x77,
-	0x0c, 0x71, 0x2a, 0xc6, 0x09, 0xcf, 0xe2, 0x09, 0xfe, 0xca, 0x30, 0x15, 0xec, 0x14, 0x9a, 0xa1,
-	0xeb, 0x61, 0x98, 0xf6, 0x8d, 0x9d, 0x35, 0xbb, 0x75, 0x68, 0x3b, 0x8a, 0xdb, 0xb9, 0x89, 0x76,
-	0xce, 0x24, 0x74, 0x14, 0x89, 0xe4, 0x7a, 0x42, 0x7d, 0xdb, 0x47, 0xd0, 0xd2, 0xca, 0xac, 0x0d,
-	0x6b, 0x3f, 0xf1, 0xba, 0x6f, 0xec, 0x18, 0xf6, 0xb3, 0xc9, 0x62, 0xc9, 0x4c, 0x58, 0xbf, 0x72,
-	0xc3, 0x0c, 0xfb, 0x0d, 0x59, 0xcb, 0x37, 0xc7, 0x8d, 0x4f, 0x86, 0x75, 0x02, 0xdd, 0xca, 0x90,
-	0x34, 0xe6, 0x51, 0x8a, 0x6c, 0x17, 0xd6, 0xfd, 0x45, 0x41, 0x92, 0xb4, 0x0e, 0xdb, 0x4e, 0xe1,
-	0xc9, 0xc9, 0x81, 0xf9, 0xb1, 0xf5, 0xd7, 0x00, 0x33, 0xef, 0x3f, 0x4f, 0xf8, 0x45, 0x10, 0xa2,
-	0x32, 0x35, 0xac, 0x99, 0xda, 0xaf, 0x9b, 0xaa, 0xe2, 0x1f, 0xdb, 0xd6, 0x08, 0x7a, 0xb5, 0x31,
-	0x64, 0xec, 0x00, 0x9e, 0xc6, 0x79, 0x89, 0xac, 0x31, 0xcd, 0x9a, 0x02, 0x2b, 0x88, 0x75, 0x04,
-	0x2f, 0xa5, 0xdd, 0xf3, 0x4c, 0x28, 0x63, 0x0f, 0x4d, 0x86, 0x41, 0xbb, 0x6c, 0xcd, 0x87, 0x5b,
-	0xaf, 0x88, 0x6e, 0x8c, 0x05, 0xdd, 0x0b, 0x68, 0x04, 0x33, 0xf2, 0xd4, 0x08, 0x66, 0xd6, 0x31,
-	0xb5, 0x49, 0xc8, 0x8a, 0x97, 0xf1, 0x06, 0x98, 0xdc, 0x7f, 0xc5, 0x10, 0x05, 0xde, 0x36, 0xa1,
-	0x07, 0xdd, 0x0a, 0x8a, 0xb4, 0x29, 0xbd, 0x67, 0x41, 0xaa, 0xc4, 0x59, 0x27, 0xd0, 0xd1, 0x6a,
-	0xa4, 0xc6, 0x86, 0xa6, 0x1c, 0xa7, 0x6e, 0xf6, 0xa6, 0x1c, 0x3a, 0xb7, 0xbe, 0x40, 0x87, 0x12,
-	0xd5, 0xf2, 0x5b, 0xed, 0x02, 0x4c, 0x60, 0x3a, 0x05, 0x69, 0x7d, 0x5d, 0x10, 0xdf, 0x91, 0xe4,
-	0xb0, 0x68, 0xd5, 0xb3, 0x5c, 0x6d, 0xfc, 0x2e, 0x98, 0x54, 0xbb, 0x3b, 0xd3, 0x2d, 0xe8, 0xd5,
-	0x70, 0xa4, 0xb4, 0xd4, 0xaf, 0xe7, 0x3a, 0x82, 0x6e, 0xa5, 0x4a, 0xda, 0x1c, 0xd8, 0xa0, 0xc1,
-	0x2a, 0xdb, 0x65, 0xe2, 0x0a, 0x8c, 0x75, 0x0a, 0xec, 0x9b, 0x1f, 0x05, 0x22, 0xe0, 0x91, 0x16,
-	0x30, 0x83, 0x27, 0x91, 0x3b, 0x47, 0x52, 0x27, 0xd7, 0x6c, 0x13, 0x9a, 0x53, 0x1e, 0x5d, 0x04,
-	0xbe, 0xfc, 0xa7, 0x3c, 0x9f, 0xd0, 0x6e, 0xf1, 0x5b, 0xa8, 0x30, 0x90, 0x6a, 0xbb, 0x24, 0xd6,
-	0x02, 0x5e, 0x42, 0x6c, 0xbd, 0x2b, 0x09, 0xf4, 0x94, 0xcb, 0x79, 0x46, 0x65, 0xde, 0x5b, 0xe8,
-	0x29, 0x78, 0x35, 0xd0, 0x65, 0xdc, 0x7d, 0xd8, 0xac, 0x83, 0x49, 0xdf, 0x67, 0xe8, 0x8c, 0x31,
-	0xc2, 0x24, 0x98, 0xfe, 0xa7, 0x6f, 0x13, 0x98, 0x4e, 0x40, 0xb4, 0x7b, 0x05, 0xed, 0x3d, 0xae,
-	0x0f, 0x8a, 0xf6, 0x87, 0x98, 0xde, 0x07, 0x93, 0xd0, 0xf7, 0x7b, 0xde, 0x82, 0x5e, 0x0d, 0x9b,
-	0x93, 0x0f, 0x3f, 0xfe, 0xf8, 0xe0, 0x07, 0xe2, 0x32, 0xf3, 0x9c, 0x29, 0x9f, 0x0f, 0x62, 0x9e,
-	0x62, 0x30, 0xe3, 0xd1, 0xa0, 0x78, 0x7b, 0x6e, 0x7b, 0xb7, 0xbc, 0xa6, 0x7c, 0x7b, 0xde, 0xff,
-	0x0b, 0x00, 0x00, 0xff, 0xff, 0x5e, 0x45, 0xe3, 0xd0, 0xda, 0x06, 0x00, 0x00,
+	// 1335 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0x1e, 0xdb, 0x71, 0x6c, 0x9f, 0x32, 0xd4, 0x5e, 0x4b, 0xb1, 0xc9, 0x55, 0x11, 0x3f, 0x35,
+	0x2d, 0x38, 0x43, 0xa1, 0x03, 0xed, 0x90, 0x69, 0x93, 0x36, 0xa4, 0x61, 0xca, 0xd0, 0x51, 0x29,
+	0x30, 0xdc, 0x74, 0x64, 0x69, 0x63, 0x6f, 0x23, 0x4b, 0xea, 0x4a, 0xf2, 0xd4, 0x97, 0x3c, 0x02,
+	0x17, 0x3c, 0x00, 0xcf, 0xc0, 0x23, 0xf1, 0x22, 0x8c, 0x56, 0x67, 0xa5, 0x95, 0xa2, 0xfc, 0x38,
+	0x09, 0xb9, 0xf2, 0xee, 0xd9, 0x6f, 0xcf, 0xef, 0xb7, 0x47, 0xbb, 0x86, 0xdb, 0x73, 0x2b, 0xb2,
+	0x67, 0x13, 0xff, 0xdd, 0x56, 0x48, 0xf9, 0x82, 0x72, 0xfc, 0x09, 0x26, 0x5b, 0x73, 0x1a, 0x86,
+	0xd6, 0x94, 0x86, 0xe3, 0x80, 0xfb, 0x91, 0x4f, 0xda, 0x72, 0x61, 0x73, 0x94, 0x6f, 0x89, 0x7c,
+	0x6e, 0x4d, 0xa9, 0xfc, 0x0d, 0x26, 0x72, 0x94, 0xee, 0x31, 0xfe, 0xac, 0x01, 0x79, 0x49, 0x5d,
+	0x6a, 0x47, 0xfb, 0xdc, 0x8f, 0x03, 0x93, 0xbe, 0x8d, 0x69, 0x18, 0x91, 0xc7, 0xb0, 0xee, 0x5a,
+	0x13, 0xea, 0x86, 0xc3, 0xda, 0xad, 0xc6, 0xe8, 0xc6, 0xbd, 0xd1, 0x58, 0xea, 0x1e, 0x1f, 0x47,
+	0x8f, 0x9f, 0x0b, 0xe8, 0x9e, 0x17, 0xf1, 0xa5, 0x89, 0xfb, 0x36, 0x1f, 0xc0, 0x0d, 0x45, 0x4c,
+	0xba, 0xd0, 0x38, 0xa2, 0xcb, 0x61, 0xed, 0x56, 0x6d, 0xd4, 0x31, 0x93, 0x21, 0xd1, 0xa0, 0xb9,
+	0xb0, 0xdc, 0x98, 0x0e, 0xeb, 0x42, 0x96, 0x4e, 0x1e, 0xd6, 0xbf, 0xad, 0x19, 0xdb, 0xd0, 0x2f,
+	0x18, 0x09, 0x03, 0xdf, 0x0b, 0x29, 0xf9, 0x14, 0x9a, 0xd3, 0x44, 0x20, 0x94, 0xdc, 0xb8, 0xd7,
+	0x1d, 0x67, 0x31, 0x8d, 0x53, 0x60, 0xba, 0x6c, 0xfc, 0x55, 0x03, 0x2d, 0xdd, 0xff, 0x82, 0xfb,
+	0x87, 0xcc, 0xa5, 0x32, 0xa8, 0xdd, 0x52, 0x50, 0x77, 0xca, 0x41, 0x15, 0xf1, 0x57, 0x1d, 0xd6,
+	0x1e, 0xe8, 0x25, 0x33, 0x18, 0xd8, 0xe7, 0xd0, 0x0a, 0x52, 0x11, 0x86, 0x46, 0x94, 0xd0, 0x24,
+	0x58, 0x42, 0x94, 0xf0, 0xf6, 0xde, 0x05, 0xae, 0xc5, 0xbc, 0x73, 0x87, 0x57, 0xc4, 0x5f, 0x75,
+	0x78, 0x7f, 0xd7, 0x40, 0x2f, 0xd9, 0xc1, 0xf8, 0xee, 0xc1, 0xba, 0xa8, 0x8c, 0x74, 0x6c, 0x33,
+	0x77, 0x4c, 0x14, 0x4e, 0xe0, 0x3d, 0x2b, 0x62, 0xbe, 0x67, 0x22, 0x32, 0x2f, 0x76, 0xfd, 0xd4,
+	0x62, 0xab, 0xb9, 0x6b, 0x9c, 0x9d, 0xbb, 0x7f, 0x6a, 0xd0, 0x2d, 0x9b, 0x3c, 0x2f, 0xaf, 0x08,
+	0x81, 0x35, 0x6e, 0x79, 0x47, 0xc2, 0xa3, 0xa6, 0x29, 0xc6, 0x64, 0x08, 0x2d, 0x71, 0xd4, 0xa8,
+	0x23, 0xcc, 0xb7, 0x4d, 0x39, 0x25, 0x9b, 0xd0, 0x0e, 0x45, 0x36, 0xa8, 0x33, 0x5c, 0x13, 0x4b,
+	0xd9, 0x9c, 0x7c, 0x01, 0xe4, 0xd0, 0x62, 0x2e, 0x75, 0x5e, 0x73, 0xfa, 0x36, 0x66, 0x9c, 0xce,
+	0xa9, 0x17, 0x0d, 0x9b, 0x22, 0xa3, 0xbd, 0x74, 0xc5, 0xcc, 0x17, 0x8c, 0x07, 0x70, 0x53, 0x38,
+	0xf2, 0x22, 0x8e, 0x64, 0xad, 0xcf, 0x7b, 0x16, 0xb6, 0xa1, 0x9b, 0x6f, 0xc5, 0x72, 0x7c, 0x06,
+	0x5d, 0x4e, 0x43, 0x3f, 0xe6, 0x36, 0x7d, 0xbd, 0xa0, 0x3c, 0x64, 0xbe, 0x87, 0x15, 0xbe, 0x29,
+	0xe5, 0xbf, 0xa4, 0x62, 0xe3, 0x43, 0xb4, 0xbc, 0x4f, 0x33, 0xcb, 0xef, 0x43, 0x9d, 0x39, 0x88,
+	0xaf, 0x33, 0xc7, 0x78, 0x08, 0xdd, 0x1c, 0xb2, 0xe2, 0x49, 0xfd, 0x09, 0x88, 0x98, 0x3f, 0xa5,
+	0x2e, 0x8d, 0xe8, 0x09, 0x16, 0x2a, 0xfd, 0xad, 0x57, 0xfb, 0xab, 0x43, 0xbf, 0xa0, 0x30, 0xf5,
+	0xc7, 0x20, 0xe8, 0xe3, 0x73, 0x16, 0xca, 0x38, 0x8c, 0x6d, 0xe8, 0x29, 0x32, 0x74, 0x7c, 0x54,
+	0x62, 0xea, 0x71, 0xcf, 0x71, 0xdd, 0xd8, 0x81, 0x1e, 0xb2, 0x4b, 0xa9, 0xca, 0x6a, 0x07, 0xf9,
+	0x11, 0x10, 0x55, 0xc5, 0xea, 0xd5, 0xf9, 0x28, 0xf3, 0xe1, 0x94, 0xfa, 0xec, 0x02, 0x51, 0x41,
+	0x17, 0x6a, 0x39, 0x53, 0xd0, 0x50, 0x76, 0x7a, 0xa5, 0x34, 0x68, 0x1e, 0xfa, 0xdc, 0x4e, 0x9b,
+	0x43, 0xdb, 0x4c, 0x27, 0x95, 0x11, 0x35, 0xaa, 0x23, 0x1a, 0x80, 0x5e, 0x32, 0x84, 0x15, 0xd4,
+	0xb2, 0x28, 0xd4, 0x1a, 0xee, 0x41, 0xbf, 0x20, 0xc5, 0xe0, 0xc6, 0xd0, 0x46, 0xcf, 0x65, 0x1d,
+	0xab, 0xa2, 0xcb, 0x30, 0xc6, 0x1f, 0x75, 0x20, 0x07, 0x53, 0x8f, 0x25, 0xdd, 0x40, 0xa9, 0x26,
+	0x81, 0x35, 0xcf, 0x9a, 0x53, 0x8c, 0x4f, 0x8c, 0xc9, 0x06, 0xac, 0xdb, 0xbe, 0x77, 0xc8, 0xa6,
+	0x22, 0xc4, 0xf7, 0x4c, 0x9c, 0x29, 0xdf, 0xcb, 0x46, 0xf9, 0x7b, 0x79, 0x5c, 0x73, 0x55, 0xe7,
+	0x25, 0x03, 0x68, 0x39, 0x7c, 0xf9, 0x9a, 0xc7, 0x1e, 0xb6, 0x8b, 0x75, 0x87, 0x2f, 0xcd, 0xd8,
+	0xab, 0x4c, 0x5f, 0xb3, 0x32, 0x7d, 0x97, 0xe9, 0xde, 0x8f, 0xa1, 0x5f, 0x70, 0x74, 0x75, 0x36,
+	0x8e, 0xf2, 0x24, 0xee, 0xd3, 0xd3, 0x92, 0x68, 0xfc, 0x06, 0xfd, 0x02, 0x12, 0x6d, 0xe5, 0xb9,
+	0xad, 0x15, 0x72, 0xbb, 0xc2, 0xf9, 0x77, 0x41, 0x97, 0x9a, 0x8b, 0x4c, 0xad, 0xaa, 0xe5, 0xa5,
+	0xd9, 0x3a, 0x84, 0x8d, 0xb2, 0x35, 0xa4, 0xab, 0x9e, 0x47, 0xa8, 0xf2, 0xf5, 0x47, 0xd0, 0x8a,
+	0x62, 0x8c, 0xfc, 0x3e, 0x74, 0x22, 0x3a, 0x0f, 0x5c, 0x2b, 0xca, 0x18, 0x3b, 0x50, 0x18, 0xfb,
+	0x33, 0xae, 0x1d, 0x78, 0x87, 0xbe, 0x99, 0x23, 0x8d, 0x37, 0xd0, 0xdb, 0xa7, 0x1e, 0xe5, 0xcc,
+	0xbe, 0x20, 0x6b, 0x57, 0x88, 0xf5, 0x11, 0x10, 0xd5, 0xd6, 0xea, 0xf4, 0xb8, 0x9d, 0x39, 0x7b,
+	0x06, 0x3b, 0x7e, 0x05, 0xa2, 0x02, 0xaf, 0x8e, 0x1c, 0x47, 0xa0, 0xa1, 0xe2, 0x6b, 0xe0, 0xc6,
+	0x00, 0xf4, 0x92, 0xb1, 0xbc, 0x93, 0xe1, 0x82, 0xca, 0x8c, 0xe7, 0xd0, 0x2f, 0x48, 0x2f, 0x47,
+	0x8c, 0x19, 0xdc, 0x7c, 0xe2, 0xfa, 0xb1, 0xf3, 0xff, 0xd3, 0x62, 0x1b, 0xba, 0xb9, 0xa5, 0xd5,
+	0x49, 0xf1, 0x09, 0x3a, 0x7a, 0x06, 0x25, 0x5e, 0x41, 0x37, 0x87, 0x5d, 0x1d, 0x21, 0x18, 0x10,
+	0xa1, 0xf6, 0x1a, 0xe8, 0xa0, 0x43, 0xbf, 0x60, 0x2a, 0xbf, 0x98, 0x08, 0xb1, 0x4a, 0x85, 0x1f,
+	0xa0, 0xa7, 0xc8, 0x2e, 0xdd, 0x21, 0x5e, 0x58, 0x3c, 0x62, 0x96, 0x7b, 0x2d, 0x1d, 0x42, 0xb5,
+	0x75, 0xa1, 0x0e, 0x81, 0x0a, 0xce, 0xee, 0x10, 0x2a, 0xf0, 0xea, 0x08, 0xf1, 0x0a, 0x34, 0x54,
+	0x7c, 0x36, 0x25, 0x56, 0x50, 0x9b, 0xdc, 0x6a, 0x8a, 0x6a, 0x95, 0x5b, 0x4d, 0xba, 0xa0, 0x12,
+	0xe0, 0x2e, 0xf4, 0x0b, 0x52, 0x8c, 0x4f, 0x83, 0x66, 0x62, 0x38, 0x2d, 0x7f, 0xc7, 0x4c, 0x27,
+	0xc6, 0xbf, 0x35, 0xd0, 0x0f, 0xbc, 0x30, 0xb2, 0x3c, 0x9b, 0x9a, 0xd4, 0xf6, 0xb9, 0x23, 0x9d,
+	0x7e, 0x52, 0x7a, 0x0e, 0xde, 0x55, 0xae, 0x24, 0x55, 0x1b, 0x2a, 0x6f, 0x25, 0x9a, 0xfa, 0x0c,
+	0xeb, 0xc8, 0x97, 0xd0, 0xb0, 0xf8, 0xe8, 0xea, 0x64, 0x37, 0xc5, 0xe4, 0xd5, 0x43, 0x3d, 0x27,
+	0xf0, 0x99, 0x17, 0x89, 0x6b, 0x4c, 0xc7, 0xcc, 0xe6, 0x97, 0xb9, 0x9d, 0x24, 0x5f, 0xda, 0x92,
+	0xcf, 0x98, 0xc2, 0x8f, 0x81, 0xc8, 0x95, 0x53, 0x2e, 0xc1, 0xdf, 0x43, 0xbf, 0x80, 0xc2, 0x94,
+	0x6e, 0x41, 0x9b, 0xa1, 0x18, 0xaf, 0xc1, 0x7d, 0xe5, 0x50, 0x65, 0x16, 0x33, 0x90, 0xf8, 0xae,
+	0xe3, 0x58, 0xad, 0xd8, 0x01, 0x68, 0x45, 0x31, 0xea, 0xff, 0x12, 0x3a, 0x72, 0xab, 0xac, 0x42,
+	0xa5, 0x81, 0x1c, 0x95, 0x74, 0x84, 0x97, 0x91, 0x15, 0xc5, 0x61, 0x1e, 0x8d, 0x61, 0x43, 0x4f,
+	0x91, 0xa1, 0xee, 0x21, 0xb4, 0x66, 0xd4, 0x72, 0xa3, 0x59, 0x9a, 0xc2, 0xb6, 0x29, 0xa7, 0xe4,
+	0x6b, 0x68, 0x31, 0x6f, 0x61, 0xb9, 0xcc, 0x19, 0xd6, 0xe5, 0x7b, 0x5b, 0xb1, 0x29, 0x56, 0x4c,
+	0xa4, 0xa8, 0x29, 0xa1, 0x49, 0xab, 0xd8, 0x4d, 0x9e, 0xae, 0x3b, 0x41, 0xe0, 0x2e, 0x95, 0x67,
+	0xe6, 0x24, 0x11, 0x56, 0x3c, 0xe4, 0x04, 0xd8, 0x4c, 0x97, 0x4f, 0xe8, 0x8f, 0xca, 0x95, 0xb6,
+	0xa1, 0x5e, 0x69, 0x13, 0xde, 0xab, 0xb6, 0xb0, 0x94, 0x1b, 0xa0, 0xed, 0x70, 0x7b, 0xc6, 0x16,
+	0x74, 0xef, 0x5d, 0xe0, 0x73, 0xe5, 0x3c, 0xe8, 0x25, 0x39, 0xa6, 0x80, 0xc0, 0x9a, 0x63, 0x45,
+	0x16, 0x9e, 0x77, 0x31, 0x36, 0xee, 0x64, 0x4a, 0x0e, 0xe6, 0x8a, 0x92, 0x4a, 0xec, 0x00, 0xf4,
+	0x12, 0x16, 0x3d, 0xf9, 0x0e, 0xc8, 0x33, 0x96, 0x84, 0xba, 0x54, 0xaa, 0x9c, 0xa8, 0x38, 0x62,
+	0x9e, 0xa4, 0x95, 0x18, 0x67, 0x9d, 0xa1, 0xae, 0xb4, 0xa7, 0x67, 0xd0, 0x2f, 0xec, 0xce, 0xc9,
+	0xc0, 0xe9, 0x82, 0x25, 0x1d, 0xa1, 0x8a, 0x0c, 0x26, 0xae, 0x99, 0x39, 0xca, 0xe0, 0xb0, 0x81,
+	0x9a, 0x4c, 0xdf, 0x75, 0x27, 0x96, 0x7d, 0xb4, 0xa2, 0x2f, 0xc9, 0x79, 0x94, 0xea, 0x44, 0x0d,
+	0x1a, 0x66, 0x36, 0xcf, 0x8b, 0xb6, 0xa6, 0x14, 0xcd, 0xf8, 0x00, 0x06, 0xc7, 0x6c, 0x62, 0x5a,
+	0xde, 0x40, 0x77, 0x27, 0x76, 0x58, 0xa4, 0x26, 0x65, 0x08, 0xad, 0x30, 0x9e, 0xbc, 0xa1, 0x76,
+	0x84, 0xbe, 0xc8, 0x69, 0xe6, 0x62, 0xbd, 0xc2, 0xc5, 0x46, 0xf1, 0xdb, 0xea, 0xb2, 0x39, 0x4b,
+	0xfb, 0x45, 0xd3, 0x4c, 0x27, 0xc6, 0x53, 0xe8, 0x29, 0xb6, 0xb2, 0xf3, 0xda, 0xa2, 0x5e, 0xc4,
+	0x59, 0x76, 0x9a, 0x74, 0x25, 0x81, 0x02, 0x9e, 0x76, 0x2f, 0x89, 0xda, 0xfd, 0xe6, 0xf7, 0xfb,
+	0x53, 0x16, 0xcd, 0xe2, 0xc9, 0xd8, 0xf6, 0xe7, 0x5b, 0x81, 0x1f, 0x52, 0xe6, 0xf8, 0xde, 0x56,
+	0xf6, 0xef, 0xe8, 0x49, 0xff, 0xac, 0x4e, 0xd6, 0xc5, 0xbf, 0xa3, 0x5f, 0xfd, 0x37, 0x00, 0x7a,
+	0x32, 0x1e, 0x6c, 0x7c, 0x15, 0x00, 0x00,
 }
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "github.com/poseidon/matchbox/matchbox/server/serverpb"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// InvalidResourcesError is returned when stored resources which can't be
+// parsed prevent a request from being served safely.
+type InvalidResourcesError struct {
+	Resources []*storagepb.InvalidResource
+}
+
+func (e *InvalidResourcesError) Error() string {
+	resources := make([]string, len(e.Resources))
+	for i, r := range e.Resources {
+		resources[i] = fmt.Sprintf("%s %s (%s)", r.Kind, r.Id, r.Error)
+	}
+	return "matchbox: stored resources can't be parsed: " + strings.Join(resources, ", ")
+}
+
+// StatusGet lists stored resources which can't be parsed.
+func (s *server) StatusGet(ctx context.Context, req *pb.StatusGetRequest) ([]*storagepb.InvalidResource, error) {
+	return s.invalid()
+}
+
+// invalid lists stored resources which can't be parsed, if the Store
+// reports them.
+func (s *server) invalid() ([]*storagepb.InvalidResource, error) {
+	if validator, ok := s.store.(storage.Validator); ok {
+		return validator.Invalid()
+	}
+	return []*storagepb.InvalidResource{}, nil
+}
+
+// checkGroupsValid returns an InvalidResourcesError if any stored Groups
+// can't be parsed.
+func (s *server) checkGroupsValid() error {
+	invalid, err := s.invalid()
+	if err != nil {
+		return err
+	}
+	var groups []*storagepb.InvalidResource
+	for _, r := range invalid {
+		if r.Kind == "Group" {
+			groups = append(groups, r)
+		}
+	}
+	if len(groups) > 0 {
+		return &InvalidResourcesError{Resources: groups}
+	}
+	return nil
+}
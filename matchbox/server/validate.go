@@ -0,0 +1,110 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/poseidon/matchbox/matchbox/ignition"
+	"github.com/poseidon/matchbox/matchbox/render"
+	"github.com/poseidon/matchbox/matchbox/storage"
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// InvalidTemplateError is returned when a template fails validation.
+type InvalidTemplateError struct {
+	Name string
+	Err  error
+}
+
+func (e *InvalidTemplateError) Error() string {
+	return fmt.Sprintf("matchbox: invalid template %s: %v", e.Name, e.Err)
+}
+
+// validateIgnition validates a raw Ignition, Butane, or Container Linux
+// Config template. Templates are trial rendered with the variables a machine
+// with the sample labels would receive. Without sample labels, templates are
+// trial rendered without variables, and templates which need variables are
+// only parsed.
+func (s *server) validateIgnition(name string, config []byte, labels map[string]string) error {
+	if ignition.IsIgnition(name) {
+		if err := ignition.Validate(config); err != nil {
+			return &InvalidTemplateError{Name: name, Err: err}
+		}
+		return nil
+	}
+
+	partials, err := storage.Partials(s.store)
+	if err != nil {
+		return err
+	}
+	tmpl, err := render.Parse(partials, string(config))
+	if err != nil {
+		return &InvalidTemplateError{Name: name, Err: err}
+	}
+	var rendered bytes.Buffer
+	if len(labels) > 0 {
+		data, err := s.sampleVariables(labels)
+		if err != nil {
+			return err
+		}
+		if err := tmpl.Execute(&rendered, data); err != nil {
+			return &InvalidTemplateError{Name: name, Err: err}
+		}
+	} else {
+		data, err := render.Variables(&storagepb.Group{}, nil, "")
+		if err != nil {
+			return err
+		}
+		if err := tmpl.Execute(&rendered, data); err != nil {
+			// output depends on variables a machine would receive
+			return nil
+		}
+	}
+
+	if ignition.IsButane(name) {
+		_, err = ignition.Translate(rendered.Bytes())
+	} else {
+		_, err = ignition.Convert(rendered.Bytes())
+	}
+	if err != nil {
+		return &InvalidTemplateError{Name: name, Err: err}
+	}
+	return nil
+}
+
+// validateTemplate checks the syntax of a Generic or Cloud-Config template
+// and of the partials it includes. Templates aren't rendered, since their
+// output depends on the variables a machine would receive.
+func (s *server) validateTemplate(name string, config []byte) error {
+	partials, err := storage.Partials(s.store)
+	if err != nil {
+		return err
+	}
+	if _, err := render.Parse(partials, string(config)); err != nil {
+		return &InvalidTemplateError{Name: name, Err: err}
+	}
+	return nil
+}
+
+// validatePartial checks the syntax of a template partial, since any
+// template may include it.
+func validatePartial(name string, config []byte) error {
+	if err := render.ParsePartial(name, string(config)); err != nil {
+		return &InvalidTemplateError{Name: name, Err: err}
+	}
+	return nil
+}
+
+// sampleVariables returns the template variables a machine with the given
+// labels would receive from the Group it matches (if any).
+func (s *server) sampleVariables(labels map[string]string) (map[string]interface{}, error) {
+	index, err := s.groupIndex()
+	if err != nil {
+		return nil, err
+	}
+	group := index.Select(labels)
+	if group == nil {
+		group = &storagepb.Group{}
+	}
+	return render.Variables(group, labels, "")
+}
@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// ArchiveVersion is the version of the archive format written by
+// WriteArchive.
+const ArchiveVersion = 1
+
+// archiveManifest is the name of the first entry of an archive, which
+// identifies the archive format version.
+const archiveManifest = "matchbox-archive.json"
+
+var errMissingManifest = errors.New("storage: archive is missing its " + archiveManifest + " manifest")
+
+// manifest describes an archive.
+type manifest struct {
+	Version int `json:"version"`
+}
+
+// Snapshot returns a Batch which writes every Group, Profile, and template in
+// the Store. Resource versions are cleared so the Batch can be applied to any
+// Store.
+func Snapshot(store Store) (*storagepb.Batch, error) {
+	batch := new(storagepb.Batch)
+	groups, err := store.GroupList()
+	// a FileStore may not have every directory
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, group := range groups {
+		group = group.Copy()
+		group.ResourceVersion = ""
+		batch.Groups = append(batch.Groups, group)
+	}
+	profiles, err := store.ProfileList()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		profile = profile.Copy()
+		profile.ResourceVersion = ""
+		batch.Profiles = append(batch.Profiles, profile)
+	}
+
+	templates := []struct {
+		list   func() ([]*storagepb.TemplateInfo, error)
+		get    func(string) (string, error)
+		result *[]*storagepb.Template
+	}{
+		{store.IgnitionList, store.IgnitionGet, &batch.Ignition},
+		{store.GenericList, store.GenericGet, &batch.Generic},
+		{store.CloudList, store.CloudGet, &batch.Cloud},
+	}
+	for _, t := range templates {
+		infos, err := t.list()
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			contents, err := t.get(info.Name)
+			if err != nil {
+				return nil, err
+			}
+			*t.result = append(*t.result, &storagepb.Template{Name: info.Name, Contents: []byte(contents)})
+		}
+	}
+	names, err := store.PartialList()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		contents, err := store.PartialGet(name)
+		if err != nil {
+			return nil, err
+		}
+		batch.Partials = append(batch.Partials, &storagepb.Template{Name: name, Contents: []byte(contents)})
+	}
+	return batch, nil
+}
+
+// WriteArchive writes the resources of a Batch as a tar archive. The archive
+// starts with a manifest of the format version, followed by resources in the
+// same layout as a FileStore data directory. Deletions are not archived.
+func WriteArchive(w io.Writer, batch *storagepb.Batch) error {
+	ops, err := batchOps(batch)
+	if err != nil {
+		return err
+	}
+	tw := tar.NewWriter(w)
+	data, err := json.Marshal(&manifest{Version: ArchiveVersion})
+	if err != nil {
+		return err
+	}
+	if err := writeArchiveFile(tw, archiveManifest, data); err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if op.delete {
+			continue
+		}
+		if err := writeArchiveFile(tw, resourcePath(op.kind, op.name), op.data); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// writeArchiveFile writes a file entry to a tar archive.
+func writeArchiveFile(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name: name,
+		Mode: int64(defaultFileMode),
+		Size: int64(len(data)),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// ReadArchive reads a tar archive written by WriteArchive and returns a Batch
+// which writes its resources. Returns an error if the archive format version
+// isn't supported.
+func ReadArchive(r io.Reader) (*storagepb.Batch, error) {
+	tr := tar.NewReader(r)
+	batch := new(storagepb.Batch)
+	first := true
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			if hdr.Name != archiveManifest {
+				return nil, errMissingManifest
+			}
+			m := new(manifest)
+			if err := json.Unmarshal(data, m); err != nil {
+				return nil, err
+			}
+			if m.Version != ArchiveVersion {
+				return nil, fmt.Errorf("storage: unsupported archive version %d", m.Version)
+			}
+			first = false
+			continue
+		}
+		if err := addArchiveFile(batch, hdr.Name, data); err != nil {
+			return nil, err
+		}
+	}
+	if first {
+		return nil, errMissingManifest
+	}
+	return batch, nil
+}
+
+// addArchiveFile adds the resource in an archive file to the Batch.
+func addArchiveFile(batch *storagepb.Batch, name string, data []byte) error {
+	dir, base := path.Split(path.Clean(name))
+	if base == "" {
+		return fmt.Errorf("storage: unexpected archive entry %s", name)
+	}
+	switch strings.TrimSuffix(dir, "/") {
+	case "groups":
+		group, err := storagepb.ParseGroup(data)
+		if err != nil {
+			return fmt.Errorf("storage: archive entry %s: %v", name, err)
+		}
+		batch.Groups = append(batch.Groups, group)
+	case "profiles":
+		profile, err := storagepb.ParseProfile(data)
+		if err != nil {
+			return fmt.Errorf("storage: archive entry %s: %v", name, err)
+		}
+		batch.Profiles = append(batch.Profiles, profile)
+	case "ignition":
+		batch.Ignition = append(batch.Ignition, &storagepb.Template{Name: base, Contents: data})
+	case "generic":
+		batch.Generic = append(batch.Generic, &storagepb.Template{Name: base, Contents: data})
+	case "cloud":
+		batch.Cloud = append(batch.Cloud, &storagepb.Template{Name: base, Contents: data})
+	case "partials":
+		batch.Partials = append(batch.Partials, &storagepb.Template{Name: base, Contents: data})
+	default:
+		return fmt.Errorf("storage: unexpected archive entry %s", name)
+	}
+	return nil
+}
+
+// Export writes every Group, Profile, and template in the Store as an
+// archive.
+func Export(store Store, w io.Writer) error {
+	batch, err := Snapshot(store)
+	if err != nil {
+		return err
+	}
+	return WriteArchive(w, batch)
+}
+
+// Import writes every resource in an archive to the Store together. Stored
+// resources which aren't in the archive are kept.
+func Import(store Store, r io.Reader) error {
+	batch, err := ReadArchive(r)
+	if err != nil {
+		return err
+	}
+	return store.Apply(batch)
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+func TestArchive(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Groups[fake.Group.Id] = fake.Group
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	store.IgnitionConfigs[fake.IgnitionYAMLName] = fake.IgnitionYAML
+	store.GenericConfigs["generic.tmpl"] = fake.Generic
+	store.CloudConfigs["cloud.tmpl"] = "#cloud-config"
+	store.Partials["sshkeys"] = "keys"
+
+	var buf bytes.Buffer
+	assert.Nil(t, Export(store, &buf))
+	batch, err := ReadArchive(bytes.NewReader(buf.Bytes()))
+	// assert that:
+	// - every resource is exported and can be read back
+	assert.Nil(t, err)
+	expected, err := Snapshot(store)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, batch)
+
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	target := NewFileStore(&Config{Root: dir})
+	// assert that:
+	// - an archive can be imported into a different kind of Store
+	assert.Nil(t, Import(target, bytes.NewReader(buf.Bytes())))
+	group, err := target.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group.Selector, group.Selector)
+	assert.Equal(t, fake.Group.Metadata, group.Metadata)
+	profile, err := target.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Profile.IgnitionId, profile.IgnitionId)
+	template, err := target.CloudGet("cloud.tmpl")
+	assert.Nil(t, err)
+	assert.Equal(t, "#cloud-config", template)
+	partial, err := target.PartialGet("sshkeys")
+	assert.Nil(t, err)
+	assert.Equal(t, "keys", partial)
+}
+
+func TestReadArchive_Invalid(t *testing.T) {
+	archive := func(files ...string) []byte {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for i := 0; i < len(files); i += 2 {
+			assert.Nil(t, writeArchiveFile(tw, files[i], []byte(files[i+1])))
+		}
+		assert.Nil(t, tw.Close())
+		return buf.Bytes()
+	}
+	cases := []struct {
+		archive []byte
+		err     string
+	}{
+		{archive(), errMissingManifest.Error()},
+		{archive("groups/a.json", "{}"), errMissingManifest.Error()},
+		{archive(archiveManifest, `{"version":2}`), "storage: unsupported archive version 2"},
+		{archive(archiveManifest, `{"version":1}`, "machines/a", ""), "storage: unexpected archive entry machines/a"},
+		{archive(archiveManifest, `{"version":1}`, "groups/a.json", "{"), "storage: archive entry groups/a.json: unexpected end of JSON input"},
+	}
+	// assert that:
+	// - archives without a supported manifest or with unexpected entries
+	// are rejected
+	for _, c := range cases {
+		_, err := ReadArchive(bytes.NewReader(c.archive))
+		if assert.Error(t, err) {
+			assert.Equal(t, c.err, err.Error())
+		}
+	}
+
+	batch, err := ReadArchive(bytes.NewReader(archive(archiveManifest, `{"version":1}`)))
+	assert.Nil(t, err)
+	assert.Equal(t, &storagepb.Batch{}, batch)
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// An AuditStore is an append-only log of calls which wrote or deleted
+// resources.
+type AuditStore interface {
+	// AuditAppend appends an entry to the log.
+	AuditAppend(entry *storagepb.AuditEntry) error
+	// AuditList lists all entries, oldest first.
+	AuditList() ([]*storagepb.AuditEntry, error)
+}
+
+// memAuditStore implements the AuditStore interface in memory.
+type memAuditStore struct {
+	mu      sync.RWMutex
+	entries []*storagepb.AuditEntry
+}
+
+// NewMemAuditStore returns a new memory-backed AuditStore.
+func NewMemAuditStore() AuditStore {
+	return &memAuditStore{}
+}
+
+// AuditAppend appends an entry to the log.
+func (s *memAuditStore) AuditAppend(entry *storagepb.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+// AuditList lists all entries, oldest first.
+func (s *memAuditStore) AuditList() ([]*storagepb.AuditEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := make([]*storagepb.AuditEntry, len(s.entries))
+	copy(entries, s.entries)
+	return entries, nil
+}
+
+// fileAuditStore implements the AuditStore interface as a file of JSON
+// lines, which is only ever appended to.
+type fileAuditStore struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileAuditStore returns a new AuditStore which appends entries to the
+// file at the given path, as JSON lines.
+func NewFileAuditStore(path string) (AuditStore, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, defaultFileMode)
+	if err != nil {
+		return nil, err
+	}
+	return &fileAuditStore{
+		path: path,
+		file: file,
+	}, nil
+}
+
+// AuditAppend appends an entry to the log file and syncs it to disk.
+func (s *fileAuditStore) AuditAppend(entry *storagepb.AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// AuditList lists all entries in the log file, oldest first.
+func (s *fileAuditStore) AuditList() ([]*storagepb.AuditEntry, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	entries := []*storagepb.AuditEntry{}
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		// a trailing partial line is an entry still being written
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entry := new(storagepb.AuditEntry)
+		if err := json.Unmarshal(data, entry); err != nil {
+			return nil, fmt.Errorf("storage: audit log %s line %d: %v", s.path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
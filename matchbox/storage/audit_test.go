@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+func TestMemAuditStore(t *testing.T) {
+	testAudit(t, NewMemAuditStore())
+}
+
+func TestFileAuditStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "matchbox")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	store, err := NewFileAuditStore(path)
+	assert.Nil(t, err)
+	testAudit(t, store)
+
+	// assert that:
+	// - entries are kept when the log is reopened
+	// - an entry still being written is skipped
+	store, err = NewFileAuditStore(path)
+	assert.Nil(t, err)
+	assert.Nil(t, store.AuditAppend(&storagepb.AuditEntry{Method: "c"}))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	assert.Nil(t, err)
+	_, err = f.WriteString(`{"method":"d"`)
+	assert.Nil(t, err)
+	f.Close()
+	entries, err := store.AuditList()
+	assert.Nil(t, err)
+	assert.Len(t, entries, 3)
+
+	// assert that:
+	// - a corrupt entry is reported
+	assert.Nil(t, ioutil.WriteFile(path, []byte("junk\n"), 0644))
+	_, err = store.AuditList()
+	assert.NotNil(t, err)
+}
+
+// testAudit tests an empty AuditStore.
+func testAudit(t *testing.T, store AuditStore) {
+	entries, err := store.AuditList()
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+
+	first := &storagepb.AuditEntry{
+		Timestamp: 1,
+		Subject:   "CN=ci",
+		Method:    "a",
+		Code:      "OK",
+		Changes:   []*storagepb.AuditChange{{Kind: KindIgnition, Name: "a.ign", Diff: "+{}\n"}},
+	}
+	assert.Nil(t, store.AuditAppend(first))
+	assert.Nil(t, store.AuditAppend(&storagepb.AuditEntry{Method: "b"}))
+	// assert that:
+	// - entries are listed in the order they were appended
+	entries, err = store.AuditList()
+	assert.Nil(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, first, entries[0])
+		assert.Equal(t, "b", entries[1].Method)
+	}
+}
@@ -0,0 +1,437 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// Resource kinds
+const (
+	KindGroup    = "Group"
+	KindProfile  = "Profile"
+	KindIgnition = "Ignition"
+	KindGeneric  = "Generic"
+	KindCloud    = "Cloud"
+	KindPartial  = "Partial"
+)
+
+// kindDirs maps resource kinds to the directories (or key prefixes) where
+// Stores keep them.
+var kindDirs = map[string]string{
+	KindGroup:    "groups",
+	KindProfile:  "profiles",
+	KindIgnition: "ignition",
+	KindGeneric:  "generic",
+	KindCloud:    "cloud",
+	KindPartial:  "partials",
+}
+
+var errReadOnly = errors.New("storage: Store is read-only")
+
+// InvalidBatchError is returned when a Batch can't be applied as given (e.g.
+// it writes the same resource twice).
+type InvalidBatchError struct {
+	Reason string
+}
+
+func (e *InvalidBatchError) Error() string {
+	return "storage: invalid batch: " + e.Reason
+}
+
+// batchOp writes or deletes a single resource of a Batch.
+type batchOp struct {
+	kind string
+	name string
+	// encoded resource, nil for deletes
+	data    []byte
+	version string
+	delete  bool
+}
+
+// batchOps returns the operations of a Batch in the order they should be
+// applied. Templates are written before the Profiles and Groups which may
+// reference them, and Groups are deleted before the Profiles and templates
+// they may reference, so that a partially applied Batch never leaves new
+// references dangling. Returns an InvalidBatchError if the Batch writes or
+// deletes any resource more than once.
+func batchOps(batch *storagepb.Batch) ([]*batchOp, error) {
+	var writes, deletes []*batchOp
+	seen := map[string]map[string]bool{}
+	add := func(op *batchOp) error {
+		if op.name == "" {
+			return &InvalidBatchError{Reason: fmt.Sprintf("%s without a name", op.kind)}
+		}
+		if seen[op.kind] == nil {
+			seen[op.kind] = map[string]bool{}
+		}
+		if seen[op.kind][op.name] {
+			return &InvalidBatchError{Reason: fmt.Sprintf("%s %s is given more than once", op.kind, op.name)}
+		}
+		seen[op.kind][op.name] = true
+		if op.delete {
+			deletes = append(deletes, op)
+		} else {
+			writes = append(writes, op)
+		}
+		return nil
+	}
+
+	templates := []struct {
+		kind      string
+		templates []*storagepb.Template
+	}{
+		{KindPartial, batch.Partials},
+		{KindIgnition, batch.Ignition},
+		{KindGeneric, batch.Generic},
+		{KindCloud, batch.Cloud},
+	}
+	for _, t := range templates {
+		for _, template := range t.templates {
+			op := &batchOp{kind: t.kind, name: template.Name, data: template.Contents, version: template.ResourceVersion}
+			if err := add(op); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, profile := range batch.Profiles {
+		data, err := encodeProfile(profile)
+		if err != nil {
+			return nil, err
+		}
+		if err := add(&batchOp{kind: KindProfile, name: profile.Id, data: data, version: profile.ResourceVersion}); err != nil {
+			return nil, err
+		}
+	}
+	for _, group := range batch.Groups {
+		data, err := encodeGroup(group)
+		if err != nil {
+			return nil, err
+		}
+		if err := add(&batchOp{kind: KindGroup, name: group.Id, data: data, version: group.ResourceVersion}); err != nil {
+			return nil, err
+		}
+	}
+	for _, deletion := range batch.Deletes {
+		if _, ok := kindDirs[deletion.Kind]; !ok {
+			return nil, &InvalidBatchError{Reason: fmt.Sprintf("unknown kind %q", deletion.Kind)}
+		}
+		op := &batchOp{kind: deletion.Kind, name: deletion.Name, version: deletion.ResourceVersion, delete: true}
+		if err := add(op); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(deletes, func(i, j int) bool {
+		return deleteOrder(deletes[i].kind) < deleteOrder(deletes[j].kind)
+	})
+	return append(writes, deletes...), nil
+}
+
+// deleteOrder ranks resource kinds so referencing resources are deleted
+// before the resources they reference.
+func deleteOrder(kind string) int {
+	switch kind {
+	case KindGroup:
+		return 0
+	case KindProfile:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// encodeGroup encodes a Group as stored.
+func encodeGroup(group *storagepb.Group) ([]byte, error) {
+	richGroup, err := group.ToRichGroup()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(richGroup, "", "\t")
+}
+
+// encodeProfile encodes a Profile as stored. Resource versions are derived
+// from the stored data, not stored.
+func encodeProfile(profile *storagepb.Profile) ([]byte, error) {
+	stored := profile.Copy()
+	stored.ResourceVersion = ""
+	return json.MarshalIndent(stored, "", "\t")
+}
+
+// batchView is a read-only Store which shows the contents of a Store as if a
+// Batch had been applied.
+type batchView struct {
+	store    Store
+	groups   map[string]*storagepb.Group
+	profiles map[string]*storagepb.Profile
+	// templates and deleted resources by kind and name
+	templates map[string]map[string][]byte
+	deleted   map[string]map[string]bool
+}
+
+// NewBatchView returns a read-only Store which shows the contents of the
+// given Store as if the Batch had been applied, so a Batch can be validated
+// before it is applied. Returns an InvalidBatchError if the Batch is
+// malformed.
+func NewBatchView(store Store, batch *storagepb.Batch) (Store, error) {
+	if _, err := batchOps(batch); err != nil {
+		return nil, err
+	}
+	view := &batchView{
+		store:    store,
+		groups:   make(map[string]*storagepb.Group, len(batch.Groups)),
+		profiles: make(map[string]*storagepb.Profile, len(batch.Profiles)),
+		templates: map[string]map[string][]byte{
+			KindIgnition: {},
+			KindGeneric:  {},
+			KindCloud:    {},
+			KindPartial:  {},
+		},
+		deleted: map[string]map[string]bool{},
+	}
+	for _, group := range batch.Groups {
+		view.groups[group.Id] = group
+	}
+	for _, profile := range batch.Profiles {
+		view.profiles[profile.Id] = profile
+	}
+	for kind, templates := range map[string][]*storagepb.Template{
+		KindIgnition: batch.Ignition,
+		KindGeneric:  batch.Generic,
+		KindCloud:    batch.Cloud,
+		KindPartial:  batch.Partials,
+	} {
+		for _, template := range templates {
+			view.templates[kind][template.Name] = template.Contents
+		}
+	}
+	for _, deletion := range batch.Deletes {
+		if view.deleted[deletion.Kind] == nil {
+			view.deleted[deletion.Kind] = map[string]bool{}
+		}
+		view.deleted[deletion.Kind][deletion.Name] = true
+	}
+	return view, nil
+}
+
+// GroupPut returns an error since the view is read-only.
+func (v *batchView) GroupPut(group *storagepb.Group) error {
+	return errReadOnly
+}
+
+// GroupGet returns a machine Group by id.
+func (v *batchView) GroupGet(id string) (*storagepb.Group, error) {
+	if v.deleted[KindGroup][id] {
+		return nil, ErrGroupNotFound
+	}
+	if group, ok := v.groups[id]; ok {
+		return group, nil
+	}
+	return v.store.GroupGet(id)
+}
+
+// GroupDelete returns an error since the view is read-only.
+func (v *batchView) GroupDelete(id, version string) error {
+	return errReadOnly
+}
+
+// GroupList lists all machine Groups, sorted by id.
+func (v *batchView) GroupList() ([]*storagepb.Group, error) {
+	stored, err := v.store.GroupList()
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]*storagepb.Group, 0, len(stored)+len(v.groups))
+	for _, group := range stored {
+		if _, ok := v.groups[group.Id]; !ok && !v.deleted[KindGroup][group.Id] {
+			groups = append(groups, group)
+		}
+	}
+	for _, group := range v.groups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Id < groups[j].Id
+	})
+	return groups, nil
+}
+
+// ProfilePut returns an error since the view is read-only.
+func (v *batchView) ProfilePut(profile *storagepb.Profile) error {
+	return errReadOnly
+}
+
+// ProfileGet gets a profile by id.
+func (v *batchView) ProfileGet(id string) (*storagepb.Profile, error) {
+	if v.deleted[KindProfile][id] {
+		return nil, ErrProfileNotFound
+	}
+	if profile, ok := v.profiles[id]; ok {
+		return profile, nil
+	}
+	return v.store.ProfileGet(id)
+}
+
+// ProfileDelete returns an error since the view is read-only.
+func (v *batchView) ProfileDelete(id, version string) error {
+	return errReadOnly
+}
+
+// ProfileList lists all profiles, sorted by id.
+func (v *batchView) ProfileList() ([]*storagepb.Profile, error) {
+	stored, err := v.store.ProfileList()
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]*storagepb.Profile, 0, len(stored)+len(v.profiles))
+	for _, profile := range stored {
+		if _, ok := v.profiles[profile.Id]; !ok && !v.deleted[KindProfile][profile.Id] {
+			profiles = append(profiles, profile)
+		}
+	}
+	for _, profile := range v.profiles {
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Id < profiles[j].Id
+	})
+	return profiles, nil
+}
+
+// IgnitionPut returns an error since the view is read-only.
+func (v *batchView) IgnitionPut(name string, config []byte, version string) error {
+	return errReadOnly
+}
+
+// IgnitionGet gets an Ignition template by name.
+func (v *batchView) IgnitionGet(name string) (string, error) {
+	return v.templateGet(KindIgnition, name, v.store.IgnitionGet)
+}
+
+// IgnitionDelete returns an error since the view is read-only.
+func (v *batchView) IgnitionDelete(name, version string) error {
+	return errReadOnly
+}
+
+// IgnitionList lists all Ignition templates.
+func (v *batchView) IgnitionList() ([]*storagepb.TemplateInfo, error) {
+	return v.templateList(KindIgnition, v.store.IgnitionList)
+}
+
+// GenericPut returns an error since the view is read-only.
+func (v *batchView) GenericPut(name string, config []byte, version string) error {
+	return errReadOnly
+}
+
+// GenericGet gets a Generic template by name.
+func (v *batchView) GenericGet(name string) (string, error) {
+	return v.templateGet(KindGeneric, name, v.store.GenericGet)
+}
+
+// GenericDelete returns an error since the view is read-only.
+func (v *batchView) GenericDelete(name, version string) error {
+	return errReadOnly
+}
+
+// GenericList lists all Generic templates.
+func (v *batchView) GenericList() ([]*storagepb.TemplateInfo, error) {
+	return v.templateList(KindGeneric, v.store.GenericList)
+}
+
+// CloudPut returns an error since the view is read-only.
+func (v *batchView) CloudPut(name string, config []byte, version string) error {
+	return errReadOnly
+}
+
+// CloudGet gets a Cloud-Config template by name.
+func (v *batchView) CloudGet(name string) (string, error) {
+	return v.templateGet(KindCloud, name, v.store.CloudGet)
+}
+
+// CloudDelete returns an error since the view is read-only.
+func (v *batchView) CloudDelete(name, version string) error {
+	return errReadOnly
+}
+
+// CloudList lists all Cloud-Config templates.
+func (v *batchView) CloudList() ([]*storagepb.TemplateInfo, error) {
+	return v.templateList(KindCloud, v.store.CloudList)
+}
+
+// PartialPut returns an error since the view is read-only.
+func (v *batchView) PartialPut(name string, config []byte, version string) error {
+	return errReadOnly
+}
+
+// PartialGet gets a template partial by name.
+func (v *batchView) PartialGet(name string) (string, error) {
+	return v.templateGet(KindPartial, name, v.store.PartialGet)
+}
+
+// PartialDelete returns an error since the view is read-only.
+func (v *batchView) PartialDelete(name, version string) error {
+	return errReadOnly
+}
+
+// PartialList lists the names of all template partials.
+func (v *batchView) PartialList() ([]string, error) {
+	stored, err := v.store.PartialList()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(stored)+len(v.templates[KindPartial]))
+	for _, name := range stored {
+		if _, ok := v.templates[KindPartial][name]; !ok && !v.deleted[KindPartial][name] {
+			names = append(names, name)
+		}
+	}
+	for name := range v.templates[KindPartial] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Apply returns an error since the view is read-only.
+func (v *batchView) Apply(batch *storagepb.Batch) error {
+	return errReadOnly
+}
+
+// templateGet gets the named template of the given kind from the Batch or
+// from the Store.
+func (v *batchView) templateGet(kind, name string, get func(string) (string, error)) (string, error) {
+	if v.deleted[kind][name] {
+		return "", ErrTemplateNotFound
+	}
+	if contents, ok := v.templates[kind][name]; ok {
+		return string(contents), nil
+	}
+	return get(name)
+}
+
+// templateList lists the templates of the given kind in the Store and the
+// Batch, sorted by name.
+func (v *batchView) templateList(kind string, list func() ([]*storagepb.TemplateInfo, error)) ([]*storagepb.TemplateInfo, error) {
+	stored, err := list()
+	if err != nil {
+		return nil, err
+	}
+	templates := make([]*storagepb.TemplateInfo, 0, len(stored)+len(v.templates[kind]))
+	for _, info := range stored {
+		if _, ok := v.templates[kind][info.Name]; !ok && !v.deleted[kind][info.Name] {
+			templates = append(templates, info)
+		}
+	}
+	for name, contents := range v.templates[kind] {
+		templates = append(templates, &storagepb.TemplateInfo{
+			Name: name,
+			Size: int64(len(contents)),
+		})
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+func TestBatchOps(t *testing.T) {
+	batch := &storagepb.Batch{
+		Groups:   []*storagepb.Group{fake.Group},
+		Profiles: []*storagepb.Profile{fake.Profile},
+		Ignition: []*storagepb.Template{{Name: "a.ign", Contents: []byte("{}")}},
+		Partials: []*storagepb.Template{{Name: "sshkeys"}},
+		Deletes: []*storagepb.Deletion{
+			{Kind: KindIgnition, Name: "old.ign"},
+			{Kind: KindProfile, Name: "old"},
+			{Kind: KindGroup, Name: "old"},
+		},
+	}
+	ops, err := batchOps(batch)
+	// assert that:
+	// - templates are written before Profiles and Profiles before Groups
+	// - Groups are deleted before Profiles and Profiles before templates
+	assert.Nil(t, err)
+	var order []string
+	for _, op := range ops {
+		action := "put "
+		if op.delete {
+			action = "delete "
+		}
+		order = append(order, action+op.kind+" "+op.name)
+	}
+	expected := []string{
+		"put Partial sshkeys",
+		"put Ignition a.ign",
+		"put Profile " + fake.Profile.Id,
+		"put Group " + fake.Group.Id,
+		"delete Group old",
+		"delete Profile old",
+		"delete Ignition old.ign",
+	}
+	assert.Equal(t, expected, order)
+
+	cases := []struct {
+		batch  *storagepb.Batch
+		reason string
+	}{
+		{&storagepb.Batch{Groups: []*storagepb.Group{fake.Group, fake.Group}}, "Group test-group is given more than once"},
+		{&storagepb.Batch{
+			Groups:  []*storagepb.Group{fake.Group},
+			Deletes: []*storagepb.Deletion{{Kind: KindGroup, Name: fake.Group.Id}},
+		}, "Group test-group is given more than once"},
+		{&storagepb.Batch{Generic: []*storagepb.Template{{Contents: []byte("a")}}}, "Generic without a name"},
+		{&storagepb.Batch{Deletes: []*storagepb.Deletion{{Kind: "Machine", Name: "a"}}}, `unknown kind "Machine"`},
+	}
+	for _, c := range cases {
+		_, err := batchOps(c.batch)
+		assert.Equal(t, &InvalidBatchError{Reason: c.reason}, err)
+	}
+}
+
+func TestBatchView(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Groups[fake.Group.Id] = fake.Group
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	store.IgnitionConfigs["old.ign"] = "{}"
+	store.Partials["sshkeys"] = "keys"
+	updated := fake.Profile.Copy()
+	updated.Name = "updated"
+	batch := &storagepb.Batch{
+		Groups:   []*storagepb.Group{fake.GroupNoMetadata},
+		Profiles: []*storagepb.Profile{updated},
+		Ignition: []*storagepb.Template{{Name: "new.ign", Contents: []byte("{}")}},
+		Partials: []*storagepb.Template{{Name: "motd", Contents: []byte("hi")}},
+		Deletes: []*storagepb.Deletion{
+			{Kind: KindGroup, Name: fake.Group.Id},
+			{Kind: KindIgnition, Name: "old.ign"},
+		},
+	}
+	view, err := NewBatchView(store, batch)
+	assert.Nil(t, err)
+
+	// assert that:
+	// - the view shows the Store's resources with the Batch applied
+	// - the Store is unchanged and the view is read-only
+	_, err = view.GroupGet(fake.Group.Id)
+	assert.Equal(t, ErrGroupNotFound, err)
+	groups, err := view.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.Group{fake.GroupNoMetadata}, groups)
+	profile, err := view.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, "updated", profile.Name)
+	_, err = view.IgnitionGet("old.ign")
+	assert.Equal(t, ErrTemplateNotFound, err)
+	templates, err := view.IgnitionList()
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.TemplateInfo{{Name: "new.ign", Size: 2}}, templates)
+	partials, err := view.PartialList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"motd", "sshkeys"}, partials)
+
+	assert.Equal(t, fake.Group, store.Groups[fake.Group.Id])
+	assert.Equal(t, fake.Profile, store.Profiles[fake.Profile.Id])
+	assert.Equal(t, errReadOnly, view.GroupPut(fake.Group))
+	assert.Equal(t, errReadOnly, view.Apply(batch))
+}
+
+func TestFileStore_Apply(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	testApply(t, NewFileStore(&Config{Root: dir}))
+}
+
+func TestFileStore_ApplyRestores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "matchbox")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	// a file where the groups directory should be makes Group writes fail
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "groups"), []byte{}, 0644))
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "ignition"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "ignition", "a.ign"), []byte("old"), 0644))
+
+	store := NewFileStore(&Config{Root: dir})
+	batch := &storagepb.Batch{
+		Groups:   []*storagepb.Group{fake.Group},
+		Ignition: []*storagepb.Template{{Name: "a.ign", Contents: []byte("new")}, {Name: "b.ign", Contents: []byte("new")}},
+	}
+	// assert that:
+	// - a failed write fails the Batch
+	// - templates written before the failure are restored
+	assert.NotNil(t, store.Apply(batch))
+	template, err := store.IgnitionGet("a.ign")
+	assert.Nil(t, err)
+	assert.Equal(t, "old", template)
+	_, err = store.IgnitionGet("b.ign")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileStore_ApplyIsolation(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	store := NewFileStore(&Config{Root: dir})
+
+	batch := func(name string) *storagepb.Batch {
+		batch := new(storagepb.Batch)
+		for _, id := range []string{"a", "b", "c", "d"} {
+			batch.Groups = append(batch.Groups, &storagepb.Group{Id: id, Name: name})
+		}
+		return batch
+	}
+	assert.Nil(t, store.Apply(batch("0")))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= 50; i++ {
+			store.Apply(batch(strconv.Itoa(i)))
+		}
+	}()
+	// assert that:
+	// - listings during Batches see all or none of each Batch
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		groups, err := store.GroupList()
+		assert.Nil(t, err)
+		if assert.Len(t, groups, 4) {
+			for _, group := range groups {
+				if !assert.Equal(t, groups[0].Name, group.Name) {
+					<-done
+					return
+				}
+			}
+		}
+	}
+}
+
+// testApply tests a Store's application of Batches.
+func testApply(t *testing.T, store Store) {
+	assert.Nil(t, store.IgnitionPut("old.ign", []byte("old"), ""))
+	assert.Nil(t, store.ProfilePut(fake.Profile))
+	profile, err := store.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+
+	updated := fake.Profile.Copy()
+	updated.Name = "updated"
+	updated.ResourceVersion = profile.ResourceVersion
+	batch := &storagepb.Batch{
+		Groups:   []*storagepb.Group{fake.Group},
+		Profiles: []*storagepb.Profile{updated},
+		Ignition: []*storagepb.Template{{Name: "new.ign", Contents: []byte("new")}},
+		Deletes: []*storagepb.Deletion{
+			{Kind: KindIgnition, Name: "old.ign", ResourceVersion: Version([]byte("old"))},
+			{Kind: KindGeneric, Name: "missing"},
+		},
+	}
+	// assert that:
+	// - all resources of the Batch are written or deleted
+	// - deleting a missing resource is not an error
+	assert.Nil(t, store.Apply(batch))
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group.Selector, group.Selector)
+	profile, err = store.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, "updated", profile.Name)
+	template, err := store.IgnitionGet("new.ign")
+	assert.Nil(t, err)
+	assert.Equal(t, "new", template)
+	_, err = store.IgnitionGet("old.ign")
+	assert.NotNil(t, err)
+
+	// assert that:
+	// - a stale version fails the Batch and nothing is changed
+	stale := &storagepb.Batch{
+		Ignition: []*storagepb.Template{{Name: "new.ign", Contents: []byte("newer")}},
+		Profiles: []*storagepb.Profile{updated},
+	}
+	assert.Equal(t, ErrVersionConflict, store.Apply(stale))
+	template, err = store.IgnitionGet("new.ign")
+	assert.Nil(t, err)
+	assert.Equal(t, "new", template)
+
+	assert.Nil(t, store.Apply(&storagepb.Batch{
+		Deletes: []*storagepb.Deletion{
+			{Kind: KindGroup, Name: fake.Group.Id},
+			{Kind: KindProfile, Name: fake.Profile.Id},
+			{Kind: KindIgnition, Name: "new.ign"},
+		},
+	}))
+}
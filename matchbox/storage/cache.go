@@ -0,0 +1,293 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+var errNotWatchable = errors.New("storage: Store does not support watching for changes")
+
+// A Watcher notifies callers when Groups, Profiles, or template partials in a
+// Store change.
+type Watcher interface {
+	// Watch calls the onChange function whenever Groups, Profiles, or template
+	// partials may have changed, until the ctx is done. Returns an error if
+	// watching could not be started.
+	Watch(ctx context.Context, onChange func()) error
+}
+
+// A GroupIndexer provides a GroupIndex of all machine Groups.
+type GroupIndexer interface {
+	// GroupIndex returns an index of all machine Groups in selection order.
+	GroupIndex() (*storagepb.GroupIndex, error)
+}
+
+// A PartialsGetter provides all template partials.
+type PartialsGetter interface {
+	// Partials returns all template partials by name. The map must not be
+	// modified.
+	Partials() (map[string]string, error)
+}
+
+// Partials returns all template partials of a Store by name, from the Store
+// if it is a PartialsGetter. The map must not be modified.
+func Partials(s Store) (map[string]string, error) {
+	if getter, ok := s.(PartialsGetter); ok {
+		return getter.Partials()
+	}
+	return listPartials(s)
+}
+
+// listPartials reads all template partials of a Store by name.
+func listPartials(s Store) (map[string]string, error) {
+	names, err := s.PartialList()
+	if err != nil {
+		return nil, err
+	}
+	partials := make(map[string]string, len(names))
+	for _, name := range names {
+		partial, err := s.PartialGet(name)
+		if err != nil {
+			return nil, err
+		}
+		partials[name] = partial
+	}
+	return partials, nil
+}
+
+// CacheConfig initializes a Cache.
+type CacheConfig struct {
+	Store Store
+}
+
+// Cache is a Store which serves Groups, Profiles, and template partials from
+// memory. Cached data is invalidated by writes through the Cache and by
+// changes reported by the underlying Store while the Cache is watching.
+type Cache struct {
+	Store
+
+	mu sync.Mutex
+	// generation is incremented on every invalidation
+	generation uint64
+	index      *storagepb.GroupIndex
+	profiles   map[string]*storagepb.Profile
+	partials   map[string]string
+	invalid    []*storagepb.InvalidResource
+}
+
+// NewCache returns a new Cache in front of the given Store.
+func NewCache(config *CacheConfig) *Cache {
+	return &Cache{
+		Store: config.Store,
+	}
+}
+
+// Watch starts invalidating the Cache whenever the underlying Store reports
+// changes, until the ctx is done. Returns an error if the Store does not
+// support watching.
+func (c *Cache) Watch(ctx context.Context) error {
+	watcher, ok := c.Store.(Watcher)
+	if !ok {
+		return errNotWatchable
+	}
+	return watcher.Watch(ctx, c.Invalidate)
+}
+
+// Invalidate discards all cached data.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.index = nil
+	c.profiles = nil
+	c.partials = nil
+	c.invalid = nil
+}
+
+// GroupIndex returns an index of all machine Groups in selection order.
+func (c *Cache) GroupIndex() (*storagepb.GroupIndex, error) {
+	c.mu.Lock()
+	index, generation := c.index, c.generation
+	c.mu.Unlock()
+	if index != nil {
+		return index, nil
+	}
+
+	groups, err := c.Store.GroupList()
+	if err != nil {
+		return nil, err
+	}
+	index = storagepb.NewGroupIndex(groups)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// skip caching data read before a concurrent invalidation
+	if c.generation == generation {
+		c.index = index
+	}
+	return index, nil
+}
+
+// GroupPut writes the given Group and invalidates the Cache.
+func (c *Cache) GroupPut(group *storagepb.Group) error {
+	defer c.Invalidate()
+	return c.Store.GroupPut(group)
+}
+
+// GroupDelete deletes a machine Group by id and invalidates the Cache.
+func (c *Cache) GroupDelete(id, version string) error {
+	defer c.Invalidate()
+	return c.Store.GroupDelete(id, version)
+}
+
+// GroupList lists all machine Groups.
+func (c *Cache) GroupList() ([]*storagepb.Group, error) {
+	index, err := c.GroupIndex()
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]*storagepb.Group, len(index.Groups()))
+	copy(groups, index.Groups())
+	return groups, nil
+}
+
+// ProfilePut writes the given Profile and invalidates the Cache.
+func (c *Cache) ProfilePut(profile *storagepb.Profile) error {
+	defer c.Invalidate()
+	return c.Store.ProfilePut(profile)
+}
+
+// ProfileGet gets a profile by id.
+func (c *Cache) ProfileGet(id string) (*storagepb.Profile, error) {
+	profiles, err := c.profileMap()
+	if err != nil {
+		return nil, err
+	}
+	if profile, present := profiles[id]; present {
+		return profile, nil
+	}
+	// let the Store report why the Profile is missing
+	return c.Store.ProfileGet(id)
+}
+
+// ProfileDelete deletes a profile by id and invalidates the Cache.
+func (c *Cache) ProfileDelete(id, version string) error {
+	defer c.Invalidate()
+	return c.Store.ProfileDelete(id, version)
+}
+
+// ProfileList lists all profiles, sorted by id.
+func (c *Cache) ProfileList() ([]*storagepb.Profile, error) {
+	profiles, err := c.profileMap()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*storagepb.Profile, 0, len(profiles))
+	for _, profile := range profiles {
+		list = append(list, profile)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Id < list[j].Id
+	})
+	return list, nil
+}
+
+// profileMap returns all Profiles by id.
+func (c *Cache) profileMap() (map[string]*storagepb.Profile, error) {
+	c.mu.Lock()
+	profiles, generation := c.profiles, c.generation
+	c.mu.Unlock()
+	if profiles != nil {
+		return profiles, nil
+	}
+
+	list, err := c.Store.ProfileList()
+	if err != nil {
+		return nil, err
+	}
+	profiles = make(map[string]*storagepb.Profile, len(list))
+	for _, profile := range list {
+		profiles[profile.Id] = profile
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// skip caching data read before a concurrent invalidation
+	if c.generation == generation {
+		c.profiles = profiles
+	}
+	return profiles, nil
+}
+
+// PartialPut writes the given template partial and invalidates the Cache.
+func (c *Cache) PartialPut(name string, config []byte, version string) error {
+	defer c.Invalidate()
+	return c.Store.PartialPut(name, config, version)
+}
+
+// PartialDelete deletes a template partial by name and invalidates the Cache.
+func (c *Cache) PartialDelete(name, version string) error {
+	defer c.Invalidate()
+	return c.Store.PartialDelete(name, version)
+}
+
+// Partials returns all template partials by name.
+func (c *Cache) Partials() (map[string]string, error) {
+	c.mu.Lock()
+	partials, generation := c.partials, c.generation
+	c.mu.Unlock()
+	if partials != nil {
+		return partials, nil
+	}
+
+	partials, err := listPartials(c.Store)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// skip caching data read before a concurrent invalidation
+	if c.generation == generation {
+		c.partials = partials
+	}
+	return partials, nil
+}
+
+// Apply applies a Batch and invalidates the Cache.
+func (c *Cache) Apply(batch *storagepb.Batch) error {
+	defer c.Invalidate()
+	return c.Store.Apply(batch)
+}
+
+// Invalid lists Groups and Profiles which the underlying Store can't parse,
+// if the Store is a Validator.
+func (c *Cache) Invalid() ([]*storagepb.InvalidResource, error) {
+	validator, ok := c.Store.(Validator)
+	if !ok {
+		return []*storagepb.InvalidResource{}, nil
+	}
+	c.mu.Lock()
+	invalid, generation := c.invalid, c.generation
+	c.mu.Unlock()
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	invalid, err := validator.Invalid()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// skip caching data read before a concurrent invalidation
+	if c.generation == generation {
+		c.invalid = invalid
+	}
+	return invalid, nil
+}
@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+// countingStore counts GroupList, ProfileList, and PartialList calls.
+type countingStore struct {
+	*fake.FixedStore
+	groupLists   int
+	profileLists int
+	partialLists int
+}
+
+func (s *countingStore) GroupList() ([]*storagepb.Group, error) {
+	s.groupLists++
+	return s.FixedStore.GroupList()
+}
+
+func (s *countingStore) ProfileList() ([]*storagepb.Profile, error) {
+	s.profileLists++
+	return s.FixedStore.ProfileList()
+}
+
+func (s *countingStore) PartialList() ([]string, error) {
+	s.partialLists++
+	return s.FixedStore.PartialList()
+}
+
+func TestCache(t *testing.T) {
+	store := &countingStore{FixedStore: fake.NewFixedStore()}
+	store.Groups[fake.Group.Id] = fake.Group
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	cache := NewCache(&CacheConfig{Store: store})
+
+	// assert that:
+	// - Groups and Profiles are read from the Store once
+	// - writes through the Cache invalidate it
+	// - Invalidate discards cached data
+	for i := 0; i < 3; i++ {
+		groups, err := cache.GroupList()
+		assert.Nil(t, err)
+		assert.Equal(t, []*storagepb.Group{fake.Group}, groups)
+		profile, err := cache.ProfileGet(fake.Profile.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, fake.Profile, profile)
+	}
+	assert.Equal(t, 1, store.groupLists)
+	assert.Equal(t, 1, store.profileLists)
+
+	err := cache.GroupPut(fake.GroupNoMetadata)
+	assert.Nil(t, err)
+	groups, err := cache.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, 2, store.groupLists)
+
+	err = cache.ProfileDelete(fake.Profile.Id, "")
+	assert.Nil(t, err)
+	_, err = cache.ProfileGet(fake.Profile.Id)
+	assert.Error(t, err)
+
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	cache.Invalidate()
+	profiles, err := cache.ProfileList()
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.Profile{fake.Profile}, profiles)
+}
+
+func TestCachePartials(t *testing.T) {
+	store := &countingStore{FixedStore: fake.NewFixedStore()}
+	store.Partials["sshkeys"] = "ssh-rsa AAAA"
+	cache := NewCache(&CacheConfig{Store: store})
+
+	// assert that:
+	// - partials are read from the Store once
+	// - partial writes through the Cache invalidate it
+	// - Stores without a cache list partials directly
+	for i := 0; i < 3; i++ {
+		partials, err := Partials(cache)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"sshkeys": "ssh-rsa AAAA"}, partials)
+	}
+	assert.Equal(t, 1, store.partialLists)
+
+	err := cache.PartialPut("motd", []byte("hello"), "")
+	assert.Nil(t, err)
+	partials, err := Partials(cache)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"sshkeys": "ssh-rsa AAAA", "motd": "hello"}, partials)
+	err = cache.PartialDelete("sshkeys", "")
+	assert.Nil(t, err)
+	partials, err = Partials(cache)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"motd": "hello"}, partials)
+	assert.Equal(t, 3, store.partialLists)
+
+	partials, err = Partials(store)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"motd": "hello"}, partials)
+}
+
+func TestCacheGroupIndex(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Groups[fake.Group.Id] = fake.Group
+	cache := NewCache(&CacheConfig{Store: store})
+	index, err := cache.GroupIndex()
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group, index.Select(map[string]string{"uuid": "a1b2c3d4"}))
+	assert.Nil(t, index.Select(map[string]string{"uuid": "e5f6g7h8"}))
+}
+
+func TestCacheWatch_NotWatchable(t *testing.T) {
+	cache := NewCache(&CacheConfig{Store: fake.NewFixedStore()})
+	assert.Equal(t, errNotWatchable, cache.Watch(context.Background()))
+}
+
+func TestCacheWatch_FileStore(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{
+		Groups: map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+	})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "partials"), 0755))
+
+	cache := NewCache(&CacheConfig{Store: NewFileStore(&Config{Root: dir})})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Nil(t, cache.Watch(ctx))
+
+	groups, err := cache.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(groups))
+
+	// assert that Groups written out of band invalidate the cache
+	data, err := ioutil.ReadFile(filepath.Join(dir, "groups", fake.Group.Id+".json"))
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "groups", "other.json"), data, defaultFileMode)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		groups, err := cache.GroupList()
+		return err == nil && len(groups) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// assert that partials written out of band invalidate the cache
+	partials, err := cache.Partials()
+	assert.Nil(t, err)
+	assert.Empty(t, partials)
+	err = ioutil.WriteFile(filepath.Join(dir, "partials", "motd"), []byte("hello"), defaultFileMode)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		partials, err := cache.Partials()
+		return err == nil && partials["motd"] == "hello"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCache_Invalid(t *testing.T) {
+	store := fake.NewFixedStore()
+	invalid := []*storagepb.InvalidResource{{Kind: "Group", Id: "a", Error: "unexpected end of JSON input"}}
+	store.InvalidResources = invalid
+	cache := NewCache(&CacheConfig{Store: store})
+
+	// assert that:
+	// - invalid resources are reported by the Store
+	// - invalid resources are cached until the Cache is invalidated
+	resources, err := cache.Invalid()
+	assert.Nil(t, err)
+	assert.Equal(t, invalid, resources)
+	store.InvalidResources = nil
+	resources, err = cache.Invalid()
+	assert.Nil(t, err)
+	assert.Equal(t, invalid, resources)
+	cache.Invalidate()
+	resources, err = cache.Invalid()
+	assert.Nil(t, err)
+	assert.Empty(t, resources)
+}
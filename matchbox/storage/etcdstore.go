@@ -0,0 +1,678 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+const (
+	defaultEtcdRequestTimeout = 5 * time.Second
+	// etcd's default maximum number of operations per transaction
+	defaultEtcdMaxTxnOps = 128
+)
+
+var (
+	// ErrTemplateNotFound is returned when no template exists with a name.
+	ErrTemplateNotFound = errors.New("storage: No template found")
+	errInvalidKeyName   = errors.New("storage: invalid character in key name")
+)
+
+// EtcdConfig initializes an etcdStore.
+type EtcdConfig struct {
+	Client *clientv3.Client
+	// Key prefix under which resources are stored (e.g. /matchbox)
+	Prefix string
+	// RequestTimeout bounds each etcd request (defaults to 5s)
+	RequestTimeout time.Duration
+	// MaxTxnOps is the etcd cluster's maximum number of operations per
+	// transaction (defaults to 128, etcd's default --max-txn-ops)
+	MaxTxnOps int
+	// Revisions is the number of revisions kept of each resource (defaults
+	// to DefaultRevisions)
+	Revisions int
+	Logger    *logrus.Logger
+}
+
+// etcdStore implements the Store interface. Resources are stored as keys
+// beneath a prefix (e.g. /matchbox/groups/id) in an etcd v3 cluster. Template
+// modification times are stored beneath the modified key prefix (e.g.
+// /matchbox/modified/ignition/name).
+type etcdStore struct {
+	client    *clientv3.Client
+	prefix    string
+	timeout   time.Duration
+	maxTxnOps int
+	revisions int
+	logger    *logrus.Logger
+}
+
+// NewEtcdStore returns a new etcd-backed Store.
+func NewEtcdStore(config *EtcdConfig) Store {
+	timeout := config.RequestTimeout
+	if timeout == 0 {
+		timeout = defaultEtcdRequestTimeout
+	}
+	maxTxnOps := config.MaxTxnOps
+	if maxTxnOps == 0 {
+		maxTxnOps = defaultEtcdMaxTxnOps
+	}
+	return &etcdStore{
+		client:    config.Client,
+		prefix:    path.Join("/", config.Prefix),
+		timeout:   timeout,
+		maxTxnOps: maxTxnOps,
+		revisions: config.Revisions,
+		logger:    config.Logger,
+	}
+}
+
+// GroupPut writes the given Group.
+func (s *etcdStore) GroupPut(group *storagepb.Group) error {
+	data, err := encodeGroup(group)
+	if err != nil {
+		return err
+	}
+	return s.put("groups", group.Id, data, group.ResourceVersion)
+}
+
+// GroupGet returns a machine Group by id.
+func (s *etcdStore) GroupGet(id string) (*storagepb.Group, error) {
+	data, err := s.get("groups", id)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrGroupNotFound
+	}
+	return parseGroup(data)
+}
+
+// GroupDelete deletes a machine Group by id.
+func (s *etcdStore) GroupDelete(id, version string) error {
+	deleted, err := s.delete("groups", id, version)
+	if err == nil && !deleted {
+		return ErrGroupNotFound
+	}
+	return err
+}
+
+// GroupList lists all machine Groups.
+func (s *etcdStore) GroupList() ([]*storagepb.Group, error) {
+	groups, invalid, err := s.groupList()
+	logInvalid(s.logger, invalid)
+	return groups, err
+}
+
+// groupList lists all machine Groups and any Groups which can't be parsed.
+func (s *etcdStore) groupList() ([]*storagepb.Group, []*storagepb.InvalidResource, error) {
+	kvs, err := s.list("groups")
+	if err != nil {
+		return nil, nil, err
+	}
+	groups := make([]*storagepb.Group, 0, len(kvs))
+	var invalid []*storagepb.InvalidResource
+	for _, kv := range kvs {
+		group, err := parseGroup(kv.value)
+		if err == nil {
+			groups = append(groups, group)
+		} else {
+			invalid = append(invalid, invalidResource("Group", kv.name, err))
+		}
+	}
+	return groups, invalid, nil
+}
+
+// ProfilePut writes the given Profile.
+func (s *etcdStore) ProfilePut(profile *storagepb.Profile) error {
+	data, err := encodeProfile(profile)
+	if err != nil {
+		return err
+	}
+	return s.put("profiles", profile.Id, data, profile.ResourceVersion)
+}
+
+// ProfileGet gets a profile by id.
+func (s *etcdStore) ProfileGet(id string) (*storagepb.Profile, error) {
+	data, err := s.get("profiles", id)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrProfileNotFound
+	}
+	return parseProfile(data)
+}
+
+// ProfileDelete deletes a profile by id.
+func (s *etcdStore) ProfileDelete(id, version string) error {
+	deleted, err := s.delete("profiles", id, version)
+	if err == nil && !deleted {
+		return ErrProfileNotFound
+	}
+	return err
+}
+
+// ProfileList lists all profiles.
+func (s *etcdStore) ProfileList() ([]*storagepb.Profile, error) {
+	profiles, invalid, err := s.profileList()
+	logInvalid(s.logger, invalid)
+	return profiles, err
+}
+
+// profileList lists all profiles and any Profiles which can't be parsed.
+func (s *etcdStore) profileList() ([]*storagepb.Profile, []*storagepb.InvalidResource, error) {
+	kvs, err := s.list("profiles")
+	if err != nil {
+		return nil, nil, err
+	}
+	profiles := make([]*storagepb.Profile, 0, len(kvs))
+	var invalid []*storagepb.InvalidResource
+	for _, kv := range kvs {
+		profile, err := parseProfile(kv.value)
+		if err == nil {
+			profiles = append(profiles, profile)
+		} else {
+			invalid = append(invalid, invalidResource("Profile", kv.name, err))
+		}
+	}
+	return profiles, invalid, nil
+}
+
+// IgnitionPut creates or updates an Ignition template.
+func (s *etcdStore) IgnitionPut(name string, config []byte, version string) error {
+	return s.putTemplate("ignition", name, config, version)
+}
+
+// IgnitionGet gets an Ignition template by name.
+func (s *etcdStore) IgnitionGet(name string) (string, error) {
+	return s.getTemplate("ignition", name)
+}
+
+// IgnitionDelete deletes an Ignition template by name.
+func (s *etcdStore) IgnitionDelete(name, version string) error {
+	return s.deleteTemplate("ignition", name, version)
+}
+
+// IgnitionList lists all Ignition templates.
+func (s *etcdStore) IgnitionList() ([]*storagepb.TemplateInfo, error) {
+	return s.templateList("ignition")
+}
+
+// GenericPut creates or updates an Generic template.
+func (s *etcdStore) GenericPut(name string, config []byte, version string) error {
+	return s.putTemplate("generic", name, config, version)
+}
+
+// GenericGet gets an Generic template by name.
+func (s *etcdStore) GenericGet(name string) (string, error) {
+	return s.getTemplate("generic", name)
+}
+
+// GenericDelete deletes an Generic template by name.
+func (s *etcdStore) GenericDelete(name, version string) error {
+	return s.deleteTemplate("generic", name, version)
+}
+
+// GenericList lists all Generic templates.
+func (s *etcdStore) GenericList() ([]*storagepb.TemplateInfo, error) {
+	return s.templateList("generic")
+}
+
+// CloudPut creates or updates a Cloud-Config template.
+func (s *etcdStore) CloudPut(name string, config []byte, version string) error {
+	return s.putTemplate("cloud", name, config, version)
+}
+
+// CloudGet gets a Cloud-Config template by name.
+func (s *etcdStore) CloudGet(name string) (string, error) {
+	return s.getTemplate("cloud", name)
+}
+
+// CloudDelete deletes a Cloud-Config template by name.
+func (s *etcdStore) CloudDelete(name, version string) error {
+	return s.deleteTemplate("cloud", name, version)
+}
+
+// CloudList lists all Cloud-Config templates.
+func (s *etcdStore) CloudList() ([]*storagepb.TemplateInfo, error) {
+	return s.templateList("cloud")
+}
+
+// PartialPut creates or updates a template partial.
+func (s *etcdStore) PartialPut(name string, config []byte, version string) error {
+	return s.putTemplate("partials", name, config, version)
+}
+
+// PartialGet gets a template partial by name.
+func (s *etcdStore) PartialGet(name string) (string, error) {
+	return s.getTemplate("partials", name)
+}
+
+// PartialDelete deletes a template partial by name.
+func (s *etcdStore) PartialDelete(name, version string) error {
+	return s.deleteTemplate("partials", name, version)
+}
+
+// PartialList lists the names of all template partials.
+func (s *etcdStore) PartialList() ([]string, error) {
+	kvs, err := s.list("partials")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(kvs))
+	for _, kv := range kvs {
+		names = append(names, kv.name)
+	}
+	return names, nil
+}
+
+// Apply writes and deletes the resources of a Batch in a single etcd
+// transaction, so readers observe all of it or none of it. Returns an
+// InvalidBatchError if the Batch needs more comparisons or operations than
+// the etcd cluster allows per transaction (MaxTxnOps).
+func (s *etcdStore) Apply(batch *storagepb.Batch) error {
+	ops, err := batchOps(batch)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	var cmps []clientv3.Cmp
+	txnOps := make([]clientv3.Op, 0, len(ops))
+	for _, op := range ops {
+		key, err := s.key(kindDirs[op.kind], op.name)
+		if err != nil {
+			return err
+		}
+		if op.version != "" {
+			cmp, err := s.versionCmp(ctx, key, op.version)
+			if err != nil {
+				return err
+			}
+			cmps = append(cmps, cmp)
+		}
+		if op.delete {
+			txnOps = append(txnOps, clientv3.OpDelete(key))
+		} else {
+			txnOps = append(txnOps, clientv3.OpPut(key, string(op.data)))
+		}
+		if op.kind != KindGroup && op.kind != KindProfile {
+			modified, err := s.modifiedOp(kindDirs[op.kind], op.name, op.delete)
+			if err != nil {
+				return err
+			}
+			txnOps = append(txnOps, modified)
+		}
+	}
+	n := len(txnOps)
+	if len(cmps) > n {
+		n = len(cmps)
+	}
+	if n > s.maxTxnOps {
+		return &InvalidBatchError{Reason: fmt.Sprintf("needs %d operations in one etcd transaction, more than the maximum of %d (raise etcd's --max-txn-ops and matchbox's -etcd-max-txn-ops)", n, s.maxTxnOps)}
+	}
+	resp, err := s.client.Txn(ctx).If(cmps...).Then(txnOps...).Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// RevisionAdd records a revision of a resource beneath the history key
+// prefix (e.g. /matchbox/history/groups/id). Concurrent additions are retried.
+func (s *etcdStore) RevisionAdd(kind, name string, revision *storagepb.Revision) error {
+	if err := checkKind(kind); err != nil {
+		return err
+	}
+	key, err := s.key(path.Join("history", kindDirs[kind]), name)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	for {
+		resp, err := s.client.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		revisions := []*storagepb.Revision{}
+		var modRevision int64
+		if len(resp.Kvs) > 0 {
+			modRevision = resp.Kvs[0].ModRevision
+			if err := json.Unmarshal(resp.Kvs[0].Value, &revisions); err != nil {
+				return err
+			}
+		}
+		data, err := json.Marshal(appendRevision(revisions, revision, s.revisions))
+		if err != nil {
+			return err
+		}
+		txn, err := s.client.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", modRevision)).
+			Then(clientv3.OpPut(key, string(data))).
+			Commit()
+		if err != nil {
+			return err
+		}
+		if txn.Succeeded {
+			return nil
+		}
+	}
+}
+
+// RevisionList lists the kept revisions of a resource, oldest first.
+func (s *etcdStore) RevisionList(kind, name string) ([]*storagepb.Revision, error) {
+	if err := checkKind(kind); err != nil {
+		return nil, err
+	}
+	data, err := s.get(path.Join("history", kindDirs[kind]), name)
+	if err != nil {
+		return nil, err
+	}
+	revisions := []*storagepb.Revision{}
+	if data == nil {
+		return revisions, nil
+	}
+	if err := json.Unmarshal(data, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// Invalid lists stored Groups and Profiles which can't be parsed.
+func (s *etcdStore) Invalid() ([]*storagepb.InvalidResource, error) {
+	invalid := []*storagepb.InvalidResource{}
+	_, groups, err := s.groupList()
+	if err != nil {
+		return nil, err
+	}
+	_, profiles, err := s.profileList()
+	if err != nil {
+		return nil, err
+	}
+	invalid = append(invalid, groups...)
+	return append(invalid, profiles...), nil
+}
+
+// Watch calls the onChange function whenever keys beneath the prefix change,
+// until the ctx is done.
+func (s *etcdStore) Watch(ctx context.Context, onChange func()) error {
+	go func() {
+		for ctx.Err() == nil {
+			watchCh := s.client.Watch(clientv3.WithRequireLeader(ctx), strings.TrimSuffix(s.prefix, "/")+"/", clientv3.WithPrefix())
+			for resp := range watchCh {
+				if err := resp.Err(); err != nil && s.logger != nil {
+					s.logger.Warningf("error watching etcd prefix %s: %v", s.prefix, err)
+				}
+				onChange()
+			}
+			// watch channel closed (e.g. compaction or leader loss), changes
+			// may have been missed
+			onChange()
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}()
+	return nil
+}
+
+// key returns the etcd key for the named resource of the given kind.
+func (s *etcdStore) key(kind, name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, "/\x00") {
+		return "", errInvalidKeyName
+	}
+	return s.kindPrefix(kind) + name, nil
+}
+
+// kindPrefix returns the etcd key prefix for resources of the given kind.
+func (s *etcdStore) kindPrefix(kind string) string {
+	return path.Join(s.prefix, kind) + "/"
+}
+
+// put writes the value of the named resource, along with any extra
+// operations, if the existing value has the expected resource version.
+func (s *etcdStore) put(kind, name string, value []byte, version string, extra ...clientv3.Op) error {
+	key, err := s.key(kind, name)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if version == "" && len(extra) == 0 {
+		_, err = s.client.Put(ctx, key, string(value))
+		return err
+	}
+	var cmps []clientv3.Cmp
+	if version != "" {
+		cmp, err := s.versionCmp(ctx, key, version)
+		if err != nil {
+			return err
+		}
+		cmps = append(cmps, cmp)
+	}
+	ops := append([]clientv3.Op{clientv3.OpPut(key, string(value))}, extra...)
+	resp, err := s.client.Txn(ctx).If(cmps...).Then(ops...).Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// get returns the value of the named resource or nil if it does not exist.
+func (s *etcdStore) get(kind, name string) ([]byte, error) {
+	key, err := s.key(kind, name)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	resp, err := s.client.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	return resp.Kvs[0].Value, nil
+}
+
+// delete deletes the named resource, along with any extra operations, if it
+// has the expected resource version, and reports whether it existed.
+func (s *etcdStore) delete(kind, name, version string, extra ...clientv3.Op) (bool, error) {
+	key, err := s.key(kind, name)
+	if err != nil {
+		return false, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if version == "" && len(extra) == 0 {
+		resp, err := s.client.Delete(ctx, key)
+		if err != nil {
+			return false, err
+		}
+		return resp.Deleted > 0, nil
+	}
+	var cmps []clientv3.Cmp
+	if version != "" {
+		cmp, err := s.versionCmp(ctx, key, version)
+		if err != nil {
+			return false, err
+		}
+		cmps = append(cmps, cmp)
+	}
+	ops := append([]clientv3.Op{clientv3.OpDelete(key)}, extra...)
+	resp, err := s.client.Txn(ctx).If(cmps...).Then(ops...).Commit()
+	if err != nil {
+		return false, err
+	}
+	if !resp.Succeeded {
+		return false, ErrVersionConflict
+	}
+	return resp.Responses[0].GetResponseDeleteRange().Deleted > 0, nil
+}
+
+// versionCmp checks that the key's value has the expected resource version
+// and returns a comparison which holds as long as the key is unmodified.
+func (s *etcdStore) versionCmp(ctx context.Context, key, version string) (clientv3.Cmp, error) {
+	resp, err := s.client.Get(ctx, key)
+	if err != nil {
+		return clientv3.Cmp{}, err
+	}
+	if version == NoVersion {
+		if len(resp.Kvs) > 0 {
+			return clientv3.Cmp{}, ErrVersionConflict
+		}
+		return clientv3.Compare(clientv3.CreateRevision(key), "=", 0), nil
+	}
+	if len(resp.Kvs) == 0 {
+		return clientv3.Cmp{}, ErrVersionConflict
+	}
+	kv := resp.Kvs[0]
+	if err := checkVersion(kv.Value, true, version); err != nil {
+		return clientv3.Cmp{}, err
+	}
+	return clientv3.Compare(clientv3.ModRevision(key), "=", kv.ModRevision), nil
+}
+
+// keyValue is a named resource value.
+type keyValue struct {
+	name  string
+	value []byte
+}
+
+// list returns all resources of the given kind, sorted by name.
+func (s *etcdStore) list(kind string) ([]keyValue, error) {
+	prefix := s.kindPrefix(kind)
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	resp, err := s.client.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	kvs := make([]keyValue, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		kvs = append(kvs, keyValue{
+			name:  strings.TrimPrefix(string(kv.Key), prefix),
+			value: kv.Value,
+		})
+	}
+	return kvs, nil
+}
+
+// modifiedOp returns the operation which records the modification time of
+// the named template of the given kind, or deletes it.
+func (s *etcdStore) modifiedOp(kind, name string, delete bool) (clientv3.Op, error) {
+	key, err := s.key(path.Join("modified", kind), name)
+	if err != nil {
+		return clientv3.Op{}, err
+	}
+	if delete {
+		return clientv3.OpDelete(key), nil
+	}
+	return clientv3.OpPut(key, strconv.FormatInt(time.Now().Unix(), 10)), nil
+}
+
+// putTemplate writes the named template of the given kind and records its
+// modification time.
+func (s *etcdStore) putTemplate(kind, name string, value []byte, version string) error {
+	op, err := s.modifiedOp(kind, name, false)
+	if err != nil {
+		return err
+	}
+	return s.put(kind, name, value, version, op)
+}
+
+// getTemplate returns the named template of the given kind.
+func (s *etcdStore) getTemplate(kind, name string) (string, error) {
+	data, err := s.get(kind, name)
+	if err != nil {
+		return "", err
+	}
+	if data == nil {
+		return "", ErrTemplateNotFound
+	}
+	return string(data), nil
+}
+
+// templateList lists the templates of the given kind, sorted by name.
+func (s *etcdStore) templateList(kind string) ([]*storagepb.TemplateInfo, error) {
+	kvs, err := s.list(kind)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := s.list(path.Join("modified", kind))
+	if err != nil {
+		return nil, err
+	}
+	times := make(map[string]int64, len(modified))
+	for _, kv := range modified {
+		// templates written before times were recorded have no time
+		times[kv.name], _ = strconv.ParseInt(string(kv.value), 10, 64)
+	}
+	templates := make([]*storagepb.TemplateInfo, 0, len(kvs))
+	for _, kv := range kvs {
+		templates = append(templates, &storagepb.TemplateInfo{
+			Name:     kv.name,
+			Size:     int64(len(kv.value)),
+			Modified: times[kv.name],
+		})
+	}
+	return templates, nil
+}
+
+// deleteTemplate deletes the named template of the given kind and its
+// modification time.
+func (s *etcdStore) deleteTemplate(kind, name, version string) error {
+	op, err := s.modifiedOp(kind, name, true)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.delete(kind, name, version, op)
+	if err == nil && !deleted {
+		return ErrTemplateNotFound
+	}
+	return err
+}
+
+// parseGroup parses a stored Group and sets its resource version.
+func parseGroup(data []byte) (*storagepb.Group, error) {
+	group, err := storagepb.ParseGroup(data)
+	if err != nil {
+		return nil, err
+	}
+	group.ResourceVersion = Version(data)
+	return group, nil
+}
+
+// parseProfile parses and validates a stored Profile and sets its resource
+// version.
+func parseProfile(data []byte) (*storagepb.Profile, error) {
+	profile, err := storagepb.ParseProfile(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := profile.AssertValid(); err != nil {
+		return nil, err
+	}
+	profile.ResourceVersion = Version(data)
+	return profile, nil
+}
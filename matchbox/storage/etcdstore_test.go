@@ -0,0 +1,336 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+// setupEtcd starts an embedded etcd server and returns a client for it and
+// a function to stop the server and remove its data.
+func setupEtcd(t *testing.T) (*clientv3.Client, func()) {
+	dir, err := ioutil.TempDir("", "matchbox-etcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := embed.NewConfig()
+	cfg.Dir = dir
+	cfg.LogLevel = "error"
+	// allow tests to raise the maximum operations per transaction
+	cfg.MaxTxnOps = 512
+	clientURL, _ := url.Parse("http://127.0.0.1:0")
+	peerURL, _ := url.Parse("http://127.0.0.1:0")
+	cfg.LCUrls, cfg.ACUrls = []url.URL{*clientURL}, []url.URL{*clientURL}
+	cfg.LPUrls, cfg.APUrls = []url.URL{*peerURL}, []url.URL{*peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	etcd, err := embed.StartEtcd(cfg)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	select {
+	case <-etcd.Server.ReadyNotify():
+	case <-time.After(30 * time.Second):
+		etcd.Close()
+		os.RemoveAll(dir)
+		t.Fatal("embedded etcd took too long to start")
+	}
+
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{etcd.Clients[0].Addr().String()},
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		etcd.Close()
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return client, func() {
+		client.Close()
+		etcd.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestEtcdStore(t *testing.T) {
+	client, teardown := setupEtcd(t)
+	defer teardown()
+
+	store := NewEtcdStore(&EtcdConfig{Client: client, Prefix: "matchbox"})
+
+	t.Run("GroupCRUD", func(t *testing.T) {
+		// assert that:
+		// - Group creation was successful
+		// - Group can be retrieved by id and listed
+		// - Group can be deleted by id
+		err := store.GroupPut(fake.Group)
+		assert.Nil(t, err)
+		err = store.GroupPut(fake.GroupNoMetadata)
+		assert.Nil(t, err)
+
+		group, err := store.GroupGet(fake.Group.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, fake.Group, clearGroupVersions(group)[0])
+
+		groups, err := store.GroupList()
+		assert.Nil(t, err)
+		assert.Equal(t, []*storagepb.Group{fake.GroupNoMetadata, fake.Group}, clearGroupVersions(groups...))
+
+		err = store.GroupDelete(fake.Group.Id, "")
+		assert.Nil(t, err)
+		_, err = store.GroupGet(fake.Group.Id)
+		assert.Equal(t, ErrGroupNotFound, err)
+		err = store.GroupDelete(fake.Group.Id, "")
+		assert.Equal(t, ErrGroupNotFound, err)
+	})
+
+	t.Run("ProfileCRUD", func(t *testing.T) {
+		err := store.ProfilePut(fake.Profile)
+		assert.Nil(t, err)
+
+		profile, err := store.ProfileGet(fake.Profile.Id)
+		assert.Nil(t, err)
+		assert.Equal(t, fake.Profile, clearProfileVersions(profile)[0])
+
+		profiles, err := store.ProfileList()
+		assert.Nil(t, err)
+		assert.Equal(t, []*storagepb.Profile{fake.Profile}, clearProfileVersions(profiles...))
+
+		err = store.ProfileDelete(fake.Profile.Id, "")
+		assert.Nil(t, err)
+		_, err = store.ProfileGet(fake.Profile.Id)
+		assert.Equal(t, ErrProfileNotFound, err)
+	})
+
+	t.Run("TemplateCRUD", func(t *testing.T) {
+		err := store.IgnitionPut(fake.IgnitionYAMLName, []byte(fake.IgnitionYAML), "")
+		assert.Nil(t, err)
+		template, err := store.IgnitionGet(fake.IgnitionYAMLName)
+		assert.Nil(t, err)
+		assert.Equal(t, fake.IgnitionYAML, template)
+		templates, err := store.IgnitionList()
+		assert.Nil(t, err)
+		if assert.Len(t, templates, 1) {
+			assert.Equal(t, fake.IgnitionYAMLName, templates[0].Name)
+			assert.Equal(t, int64(len(fake.IgnitionYAML)), templates[0].Size)
+			assert.InDelta(t, time.Now().Unix(), templates[0].Modified, 5)
+		}
+		err = store.IgnitionDelete(fake.IgnitionYAMLName, "")
+		assert.Nil(t, err)
+		_, err = store.IgnitionGet(fake.IgnitionYAMLName)
+		assert.Equal(t, ErrTemplateNotFound, err)
+
+		err = store.GenericPut(fake.GenericName, []byte(fake.Generic), "")
+		assert.Nil(t, err)
+		template, err = store.GenericGet(fake.GenericName)
+		assert.Nil(t, err)
+		assert.Equal(t, fake.Generic, template)
+		templates, err = store.GenericList()
+		assert.Nil(t, err)
+		if assert.Len(t, templates, 1) {
+			assert.Equal(t, fake.GenericName, templates[0].Name)
+			assert.Equal(t, int64(len(fake.Generic)), templates[0].Size)
+			assert.InDelta(t, time.Now().Unix(), templates[0].Modified, 5)
+		}
+		err = store.GenericDelete(fake.GenericName, "")
+		assert.Nil(t, err)
+		err = store.GenericDelete(fake.GenericName, "")
+		assert.Equal(t, ErrTemplateNotFound, err)
+
+		_, err = store.CloudGet("cloud.yaml")
+		assert.Equal(t, ErrTemplateNotFound, err)
+		err = store.CloudPut("cloud.yaml", []byte("#cloud-config"), "")
+		assert.Nil(t, err)
+		template, err = store.CloudGet("cloud.yaml")
+		assert.Nil(t, err)
+		assert.Equal(t, "#cloud-config", template)
+		templates, err = store.CloudList()
+		assert.Nil(t, err)
+		if assert.Len(t, templates, 1) {
+			assert.Equal(t, "cloud.yaml", templates[0].Name)
+			assert.Equal(t, int64(13), templates[0].Size)
+			assert.InDelta(t, time.Now().Unix(), templates[0].Modified, 5)
+		}
+		err = store.CloudDelete("cloud.yaml", "")
+		assert.Nil(t, err)
+		err = store.CloudDelete("cloud.yaml", "")
+		assert.Equal(t, ErrTemplateNotFound, err)
+	})
+
+	t.Run("TemplateModified", func(t *testing.T) {
+		// assert that:
+		// - templates written by a Batch record modification times
+		// - templates written without a recorded time list a zero time
+		// - deleting a template deletes its modification time
+		batch := &storagepb.Batch{Ignition: []*storagepb.Template{{Name: "batch.yaml", Contents: []byte("batch")}}}
+		err := store.Apply(batch)
+		assert.Nil(t, err)
+		_, err = client.Put(context.Background(), "/matchbox/ignition/legacy.yaml", "legacy")
+		assert.Nil(t, err)
+		templates, err := store.IgnitionList()
+		assert.Nil(t, err)
+		if assert.Len(t, templates, 2) {
+			assert.Equal(t, "batch.yaml", templates[0].Name)
+			assert.InDelta(t, time.Now().Unix(), templates[0].Modified, 5)
+			assert.Equal(t, "legacy.yaml", templates[1].Name)
+			assert.Equal(t, int64(0), templates[1].Modified)
+		}
+		err = store.IgnitionDelete("batch.yaml", "")
+		assert.Nil(t, err)
+		err = store.IgnitionDelete("legacy.yaml", "")
+		assert.Nil(t, err)
+		resp, err := client.Get(context.Background(), "/matchbox/modified/", clientv3.WithPrefix())
+		assert.Nil(t, err)
+		assert.Empty(t, resp.Kvs)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		// assert that:
+		// - unparsable Groups are reported and skipped when listing
+		_, err := client.Put(context.Background(), "/matchbox/groups/truncated", `{"id": "trunc`)
+		assert.Nil(t, err)
+		defer client.Delete(context.Background(), "/matchbox/groups/truncated")
+		invalid, err := store.(Validator).Invalid()
+		assert.Nil(t, err)
+		expected := []*storagepb.InvalidResource{{Kind: "Group", Id: "truncated", Error: "unexpected end of JSON input"}}
+		assert.Equal(t, expected, invalid)
+		groups, err := store.GroupList()
+		assert.Nil(t, err)
+		for _, group := range groups {
+			assert.NotEqual(t, "truncated", group.Id)
+		}
+	})
+
+	t.Run("ResourceVersions", func(t *testing.T) {
+		testResourceVersions(t, store)
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		testApply(t, store)
+	})
+
+	t.Run("ApplyLimit", func(t *testing.T) {
+		// assert that:
+		// - Batches with more operations than MaxTxnOps are rejected and
+		//   nothing is written
+		// - Batches within a raised MaxTxnOps are applied
+		batch := new(storagepb.Batch)
+		for i := 0; i < 150; i++ {
+			batch.Groups = append(batch.Groups, &storagepb.Group{Id: fmt.Sprintf("group%03d", i)})
+		}
+		for i := 0; i < 50; i++ {
+			batch.Ignition = append(batch.Ignition, &storagepb.Template{Name: fmt.Sprintf("ignition%03d.yaml", i), Contents: []byte("{}")})
+		}
+		limited := NewEtcdStore(&EtcdConfig{Client: client, Prefix: "limit"})
+		err := limited.Apply(batch)
+		assert.IsType(t, &InvalidBatchError{}, err)
+		resp, err := client.Get(context.Background(), "/limit/", clientv3.WithPrefix(), clientv3.WithCountOnly())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), resp.Count)
+
+		raised := NewEtcdStore(&EtcdConfig{Client: client, Prefix: "limit", MaxTxnOps: 512})
+		assert.Nil(t, raised.Apply(batch))
+		groups, err := raised.GroupList()
+		assert.Nil(t, err)
+		assert.Len(t, groups, 150)
+		templates, err := raised.IgnitionList()
+		assert.Nil(t, err)
+		assert.Len(t, templates, 50)
+	})
+
+	t.Run("ApplyConflict", func(t *testing.T) {
+		// assert that:
+		// - a conflict on the last resource of a Batch leaves every key
+		//   unchanged
+		conflict := NewEtcdStore(&EtcdConfig{Client: client, Prefix: "conflict"})
+		assert.Nil(t, conflict.GroupPut(&storagepb.Group{Id: "a", Name: "old"}))
+		assert.Nil(t, conflict.GroupPut(&storagepb.Group{Id: "d", Name: "old"}))
+		before, err := client.Get(context.Background(), "/conflict/", clientv3.WithPrefix())
+		assert.Nil(t, err)
+		batch := &storagepb.Batch{
+			Ignition: []*storagepb.Template{{Name: "new.yaml", Contents: []byte("{}")}},
+			Groups: []*storagepb.Group{
+				{Id: "a", Name: "new"},
+				{Id: "b"},
+				{Id: "c"},
+				{Id: "d", Name: "new", ResourceVersion: Version([]byte("stale"))},
+			},
+		}
+		assert.Equal(t, ErrVersionConflict, conflict.Apply(batch))
+		after, err := client.Get(context.Background(), "/conflict/", clientv3.WithPrefix())
+		assert.Nil(t, err)
+		assert.Equal(t, before.Kvs, after.Kvs)
+	})
+
+	t.Run("History", func(t *testing.T) {
+		history := NewEtcdStore(&EtcdConfig{Client: client, Prefix: "matchbox", Revisions: 2})
+		testHistory(t, history.(HistoryStore))
+	})
+
+	t.Run("PartialCRUD", func(t *testing.T) {
+		names, err := store.PartialList()
+		assert.Nil(t, err)
+		assert.Empty(t, names)
+		err = store.PartialPut("sshkeys", []byte(`{{.ssh_authorized_key}}`), "")
+		assert.Nil(t, err)
+		err = store.PartialPut("ntp", []byte(`pool.ntp.org`), "")
+		assert.Nil(t, err)
+		names, err = store.PartialList()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ntp", "sshkeys"}, names)
+		template, err := store.PartialGet("sshkeys")
+		assert.Nil(t, err)
+		assert.Equal(t, `{{.ssh_authorized_key}}`, template)
+		assert.Nil(t, store.PartialDelete("sshkeys", ""))
+		assert.Nil(t, store.PartialDelete("ntp", ""))
+		_, err = store.PartialGet("sshkeys")
+		assert.Equal(t, ErrTemplateNotFound, err)
+	})
+
+	t.Run("InvalidNames", func(t *testing.T) {
+		// assert that names can't escape their resource prefix
+		err := store.IgnitionPut("../groups/evil", []byte("{}"), "")
+		assert.Equal(t, errInvalidKeyName, err)
+		_, err = store.GroupGet("")
+		assert.Equal(t, errInvalidKeyName, err)
+	})
+
+	t.Run("Prefix", func(t *testing.T) {
+		// assert that stores with distinct prefixes are isolated
+		other := NewEtcdStore(&EtcdConfig{Client: client, Prefix: "/other"})
+		err := other.GroupPut(fake.Group)
+		assert.Nil(t, err)
+		_, err = store.GroupGet(fake.Group.Id)
+		assert.Equal(t, ErrGroupNotFound, err)
+	})
+	t.Run("Watch", func(t *testing.T) {
+		// assert that writes by other replicas invalidate a watching Cache
+		cache := NewCache(&CacheConfig{Store: store})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		assert.Nil(t, cache.Watch(ctx))
+		groups, err := cache.GroupList()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(groups))
+
+		replica := NewEtcdStore(&EtcdConfig{Client: client, Prefix: "matchbox"})
+		err = replica.GroupPut(fake.Group)
+		assert.Nil(t, err)
+		assert.Eventually(t, func() bool {
+			groups, err := cache.GroupList()
+			return err == nil && len(groups) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
@@ -1,50 +1,64 @@
 package storage
 
 import (
+	"context"
 	"encoding/json"
+	"os"
 	"path/filepath"
-	"strings"
+	"sync"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 	"github.com/sirupsen/logrus"
 )
 
 // Config initializes a fileStore.
 type Config struct {
-	Root   string
-	Logger *logrus.Logger
+	Root string
+	// Revisions is the number of revisions kept of each resource (defaults
+	// to DefaultRevisions)
+	Revisions int
+	Logger    *logrus.Logger
 }
 
 // fileStore implements ths Store interface. Queries to the file system
 // are restricted to the specified directory tree.
 type fileStore struct {
-	root   string
-	logger *logrus.Logger
+	root      string
+	revisions int
+	logger    *logrus.Logger
+	// mu serializes version checked writes and Batches, which readers
+	// must not observe partially applied
+	mu sync.RWMutex
 }
 
 // NewFileStore returns a new memory-backed Store.
 func NewFileStore(config *Config) Store {
 	return &fileStore{
-		root:   config.Root,
-		logger: config.Logger,
+		root:      config.Root,
+		revisions: config.Revisions,
+		logger:    config.Logger,
 	}
 }
 
 // GroupPut writes the given Group.
 func (s *fileStore) GroupPut(group *storagepb.Group) error {
-	richGroup, err := group.ToRichGroup()
+	data, err := encodeGroup(group)
 	if err != nil {
 		return err
 	}
-	data, err := json.MarshalIndent(richGroup, "", "\t")
-	if err != nil {
-		return err
-	}
-	return Dir(s.root).writeFile(filepath.Join("groups", group.Id+".json"), data)
+	return s.writeFile(filepath.Join("groups", group.Id+".json"), data, group.ResourceVersion)
 }
 
 // GroupGet returns a machine Group by id.
 func (s *fileStore) GroupGet(id string) (*storagepb.Group, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.groupGet(id)
+}
+
+// groupGet returns a machine Group by id. Callers must hold s.mu.
+func (s *fileStore) groupGet(id string) (*storagepb.Group, error) {
 	data, err := Dir(s.root).readFile(filepath.Join("groups", id+".json"))
 	if err != nil {
 		return nil, err
@@ -53,44 +67,62 @@ func (s *fileStore) GroupGet(id string) (*storagepb.Group, error) {
 	if err != nil {
 		return nil, err
 	}
+	group.ResourceVersion = Version(data)
 	return group, err
 }
 
 // GroupDelete deletes a machine Group by id.
-func (s *fileStore) GroupDelete(id string) error {
-	return Dir(s.root).deleteFile(filepath.Join("groups", id+".json"))
+func (s *fileStore) GroupDelete(id, version string) error {
+	return s.deleteFile(filepath.Join("groups", id+".json"), version)
 }
 
 // GroupList lists all machine Groups.
 func (s *fileStore) GroupList() ([]*storagepb.Group, error) {
+	s.mu.RLock()
+	groups, invalid, err := s.groupList()
+	s.mu.RUnlock()
+	logInvalid(s.logger, invalid)
+	return groups, err
+}
+
+// groupList lists all machine Groups and any Groups which can't be parsed.
+// Callers must hold s.mu.
+func (s *fileStore) groupList() ([]*storagepb.Group, []*storagepb.InvalidResource, error) {
 	files, err := Dir(s.root).readDir("groups")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	groups := make([]*storagepb.Group, 0, len(files))
-	for _, finfo := range files {
-		name := strings.TrimSuffix(finfo.Name(), filepath.Ext(finfo.Name()))
-		group, err := s.GroupGet(name)
+	var invalid []*storagepb.InvalidResource
+	for _, name := range resourceNames(files) {
+		group, err := s.groupGet(name)
 		if err == nil {
 			groups = append(groups, group)
-		} else if s.logger != nil {
-			s.logger.Infof("Group %q: %v", name, err)
+		} else {
+			invalid = append(invalid, invalidResource("Group", name, err))
 		}
 	}
-	return groups, nil
+	return groups, invalid, nil
 }
 
 // ProfilePut writes the given Profile.
 func (s *fileStore) ProfilePut(profile *storagepb.Profile) error {
-	data, err := json.MarshalIndent(profile, "", "\t")
+	data, err := encodeProfile(profile)
 	if err != nil {
 		return err
 	}
-	return Dir(s.root).writeFile(filepath.Join("profiles", profile.Id+".json"), data)
+	return s.writeFile(filepath.Join("profiles", profile.Id+".json"), data, profile.ResourceVersion)
 }
 
 // ProfileGet gets a profile by id.
 func (s *fileStore) ProfileGet(id string) (*storagepb.Profile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.profileGet(id)
+}
+
+// profileGet gets a profile by id. Callers must hold s.mu.
+func (s *fileStore) profileGet(id string) (*storagepb.Profile, error) {
 	data, err := Dir(s.root).readFile(filepath.Join("profiles", id+".json"))
 	if err != nil {
 		return nil, err
@@ -103,67 +135,425 @@ func (s *fileStore) ProfileGet(id string) (*storagepb.Profile, error) {
 	if err := profile.AssertValid(); err != nil {
 		return nil, err
 	}
+	profile.ResourceVersion = Version(data)
 	return profile, err
 }
 
 // ProfileDelete deletes a profile by id.
-func (s *fileStore) ProfileDelete(id string) error {
-	return Dir(s.root).deleteFile(filepath.Join("profiles", id+".json"))
+func (s *fileStore) ProfileDelete(id, version string) error {
+	return s.deleteFile(filepath.Join("profiles", id+".json"), version)
 }
 
 // ProfileList lists all profiles.
 func (s *fileStore) ProfileList() ([]*storagepb.Profile, error) {
+	s.mu.RLock()
+	profiles, invalid, err := s.profileList()
+	s.mu.RUnlock()
+	logInvalid(s.logger, invalid)
+	return profiles, err
+}
+
+// profileList lists all profiles and any Profiles which can't be parsed.
+// Callers must hold s.mu.
+func (s *fileStore) profileList() ([]*storagepb.Profile, []*storagepb.InvalidResource, error) {
 	files, err := Dir(s.root).readDir("profiles")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	profiles := make([]*storagepb.Profile, 0, len(files))
-	for _, finfo := range files {
-		name := strings.TrimSuffix(finfo.Name(), filepath.Ext(finfo.Name()))
-		profile, err := s.ProfileGet(name)
+	var invalid []*storagepb.InvalidResource
+	for _, name := range resourceNames(files) {
+		profile, err := s.profileGet(name)
 		if err == nil {
 			profiles = append(profiles, profile)
-		} else if s.logger != nil {
-			s.logger.Infof("Profile %q: %v", name, err)
+		} else {
+			invalid = append(invalid, invalidResource("Profile", name, err))
 		}
 	}
-	return profiles, nil
+	return profiles, invalid, nil
 }
 
 // IgnitionPut creates or updates an Ignition template.
-func (s *fileStore) IgnitionPut(name string, config []byte) error {
-	return Dir(s.root).writeFile(filepath.Join("ignition", name), config)
+func (s *fileStore) IgnitionPut(name string, config []byte, version string) error {
+	return s.writeFile(filepath.Join("ignition", name), config, version)
 }
 
 // IgnitionGet gets an Ignition template by name.
 func (s *fileStore) IgnitionGet(name string) (string, error) {
-	data, err := Dir(s.root).readFile(filepath.Join("ignition", name))
+	data, err := s.readFile(filepath.Join("ignition", name))
 	return string(data), err
 }
 
 // IgnitionDelete deletes an Ignition template by name.
-func (s *fileStore) IgnitionDelete(name string) error {
-	return Dir(s.root).deleteFile(filepath.Join("ignition", name))
+func (s *fileStore) IgnitionDelete(name, version string) error {
+	return s.deleteFile(filepath.Join("ignition", name), version)
+}
+
+// IgnitionList lists all Ignition templates.
+func (s *fileStore) IgnitionList() ([]*storagepb.TemplateInfo, error) {
+	return s.templateList("ignition")
 }
 
 // GenericPut creates or updates an Generic template.
-func (s *fileStore) GenericPut(name string, config []byte) error {
-	return Dir(s.root).writeFile(filepath.Join("generic", name), config)
+func (s *fileStore) GenericPut(name string, config []byte, version string) error {
+	return s.writeFile(filepath.Join("generic", name), config, version)
 }
 
 // GenericGet gets an Generic template by name.
 func (s *fileStore) GenericGet(name string) (string, error) {
-	data, err := Dir(s.root).readFile(filepath.Join("generic", name))
+	data, err := s.readFile(filepath.Join("generic", name))
 	return string(data), err
 }
 
 // GenericDelete deletes an Generic template by name.
-func (s *fileStore) GenericDelete(name string) error {
-	return Dir(s.root).deleteFile(filepath.Join("generic", name))
+func (s *fileStore) GenericDelete(name, version string) error {
+	return s.deleteFile(filepath.Join("generic", name), version)
+}
+
+// GenericList lists all Generic templates.
+func (s *fileStore) GenericList() ([]*storagepb.TemplateInfo, error) {
+	return s.templateList("generic")
+}
+
+// CloudPut creates or updates a Cloud-Config template.
+func (s *fileStore) CloudPut(name string, config []byte, version string) error {
+	return s.writeFile(filepath.Join("cloud", name), config, version)
 }
 
 // CloudGet gets a Cloud-Config template by name.
 func (s *fileStore) CloudGet(name string) (string, error) {
-	data, err := Dir(s.root).readFile(filepath.Join("cloud", name))
+	data, err := s.readFile(filepath.Join("cloud", name))
 	return string(data), err
 }
+
+// CloudDelete deletes a Cloud-Config template by name.
+func (s *fileStore) CloudDelete(name, version string) error {
+	return s.deleteFile(filepath.Join("cloud", name), version)
+}
+
+// CloudList lists all Cloud-Config templates.
+func (s *fileStore) CloudList() ([]*storagepb.TemplateInfo, error) {
+	return s.templateList("cloud")
+}
+
+// PartialPut creates or updates a template partial.
+func (s *fileStore) PartialPut(name string, config []byte, version string) error {
+	return s.writeFile(filepath.Join("partials", name), config, version)
+}
+
+// PartialGet gets a template partial by name.
+func (s *fileStore) PartialGet(name string) (string, error) {
+	data, err := s.readFile(filepath.Join("partials", name))
+	return string(data), err
+}
+
+// PartialDelete deletes a template partial by name.
+func (s *fileStore) PartialDelete(name, version string) error {
+	return s.deleteFile(filepath.Join("partials", name), version)
+}
+
+// PartialList lists the names of all template partials.
+func (s *fileStore) PartialList() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	files, err := Dir(s.root).readDir("partials")
+	if os.IsNotExist(err) {
+		// partials are optional
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, finfo := range files {
+		if !finfo.IsDir() && !isHidden(finfo) {
+			names = append(names, finfo.Name())
+		}
+	}
+	return names, nil
+}
+
+// Apply writes and deletes the resources of a Batch. Resource versions are
+// checked before any file is changed. Each file is replaced atomically, in an
+// order which never leaves new references dangling, and files are restored
+// if a later write fails. Reads through the Store wait until the Batch is
+// applied, so they observe all of it or none of it.
+func (s *fileStore) Apply(batch *storagepb.Batch) error {
+	ops, err := batchOps(batch)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, op := range ops {
+		if err := s.checkVersion(resourcePath(op.kind, op.name), op.version); err != nil {
+			return err
+		}
+	}
+
+	var applied []*fileBackup
+	for _, op := range ops {
+		path := resourcePath(op.kind, op.name)
+		backup, err := s.backup(path)
+		if err == nil {
+			if op.delete {
+				err = Dir(s.root).deleteFile(path)
+				if os.IsNotExist(err) {
+					err = nil
+				}
+			} else {
+				err = Dir(s.root).writeFile(path, op.data)
+			}
+		}
+		if err != nil {
+			s.restore(applied)
+			return err
+		}
+		applied = append(applied, backup)
+	}
+	return nil
+}
+
+// fileBackup is the prior state of a file changed by Apply.
+type fileBackup struct {
+	path   string
+	data   []byte
+	exists bool
+}
+
+// backup reads the file at the given path so it can be restored.
+func (s *fileStore) backup(path string) (*fileBackup, error) {
+	data, err := Dir(s.root).readFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return &fileBackup{path: path, data: data, exists: err == nil}, nil
+}
+
+// restore restores backed up files in reverse order. Errors are logged since
+// the original failure is more relevant to callers.
+func (s *fileStore) restore(backups []*fileBackup) {
+	for i := len(backups) - 1; i >= 0; i-- {
+		backup := backups[i]
+		var err error
+		if backup.exists {
+			err = Dir(s.root).writeFile(backup.path, backup.data)
+		} else {
+			err = Dir(s.root).deleteFile(backup.path)
+			if os.IsNotExist(err) {
+				err = nil
+			}
+		}
+		if err != nil && s.logger != nil {
+			s.logger.Errorf("error restoring %s: %v", backup.path, err)
+		}
+	}
+}
+
+// resourcePath returns the path of the named resource of the given kind.
+func resourcePath(kind, name string) string {
+	path := filepath.Join(kindDirs[kind], name)
+	if kind == KindGroup || kind == KindProfile {
+		path += ".json"
+	}
+	return path
+}
+
+// historyPath returns the path of the file which keeps the revisions of a
+// resource, in the hidden .history directory.
+func historyPath(kind, name string) string {
+	return filepath.Join(".history", kindDirs[kind], name+".json")
+}
+
+// RevisionAdd records a revision of a resource.
+func (s *fileStore) RevisionAdd(kind, name string, revision *storagepb.Revision) error {
+	if err := checkKind(kind); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	revisions, err := s.revisionList(kind, name)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(appendRevision(revisions, revision, s.revisions))
+	if err != nil {
+		return err
+	}
+	return Dir(s.root).writeFile(historyPath(kind, name), data)
+}
+
+// RevisionList lists the kept revisions of a resource, oldest first.
+func (s *fileStore) RevisionList(kind, name string) ([]*storagepb.Revision, error) {
+	if err := checkKind(kind); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.revisionList(kind, name)
+}
+
+func (s *fileStore) revisionList(kind, name string) ([]*storagepb.Revision, error) {
+	data, err := Dir(s.root).readFile(historyPath(kind, name))
+	if os.IsNotExist(err) {
+		return []*storagepb.Revision{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	revisions := []*storagepb.Revision{}
+	if err := json.Unmarshal(data, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// Invalid lists stored Groups and Profiles which can't be parsed.
+func (s *fileStore) Invalid() ([]*storagepb.InvalidResource, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	invalid := []*storagepb.InvalidResource{}
+	_, groups, err := s.groupList()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	_, profiles, err := s.profileList()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	invalid = append(invalid, groups...)
+	return append(invalid, profiles...), nil
+}
+
+// templateList lists the templates in the given directory, sorted by name.
+func (s *fileStore) templateList(dirname string) ([]*storagepb.TemplateInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	files, err := Dir(s.root).readDir(dirname)
+	if os.IsNotExist(err) {
+		return []*storagepb.TemplateInfo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	templates := make([]*storagepb.TemplateInfo, 0, len(files))
+	for _, finfo := range files {
+		if !finfo.IsDir() && !isHidden(finfo) {
+			templates = append(templates, &storagepb.TemplateInfo{
+				Name:     finfo.Name(),
+				Size:     finfo.Size(),
+				Modified: finfo.ModTime().Unix(),
+			})
+		}
+	}
+	return templates, nil
+}
+
+// readFile reads the file at the given path.
+func (s *fileStore) readFile(path string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return Dir(s.root).readFile(path)
+}
+
+// writeFile writes the data as a file at the given path, if the existing file
+// has the expected resource version.
+func (s *fileStore) writeFile(path string, data []byte, version string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkVersion(path, version); err != nil {
+		return err
+	}
+	return Dir(s.root).writeFile(path, data)
+}
+
+// deleteFile removes the file at the given path, if it has the expected
+// resource version.
+func (s *fileStore) deleteFile(path, version string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkVersion(path, version); err != nil {
+		return err
+	}
+	return Dir(s.root).deleteFile(path)
+}
+
+// checkVersion returns ErrVersionConflict if the file at the given path
+// doesn't have the expected resource version.
+func (s *fileStore) checkVersion(path, version string) error {
+	if version == "" {
+		return nil
+	}
+	data, err := Dir(s.root).readFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return checkVersion(data, err == nil, version)
+}
+
+// Watch calls the onChange function whenever files in the groups or profiles
+// directories change (via inotify), until the ctx is done.
+func (s *fileStore) Watch(ctx context.Context, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// watch the root to notice groups, profiles, or partials directories being
+	// created
+	root, err := Dir(s.root).sanitize("")
+	if err != nil {
+		watcher.Close()
+		return err
+	}
+	if err := watcher.Add(root); err != nil {
+		watcher.Close()
+		return err
+	}
+	watched := map[string]bool{}
+	watchDirs := func() {
+		for _, name := range []string{"groups", "profiles", "partials"} {
+			path, err := Dir(s.root).sanitize(name)
+			if err != nil || watched[path] {
+				continue
+			}
+			if finfo, err := os.Stat(path); err == nil && finfo.IsDir() {
+				if err := watcher.Add(path); err == nil {
+					watched[path] = true
+				} else if s.logger != nil {
+					s.logger.Warningf("error watching %s: %v", path, err)
+				}
+			}
+		}
+	}
+	watchDirs()
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Dir(event.Name) == root {
+					// watched directories may have been (re)created
+					delete(watched, event.Name)
+					watchDirs()
+				}
+				onChange()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				if s.logger != nil {
+					s.logger.Warningf("error watching %s: %v", s.root, err)
+				}
+				// events may have been dropped
+				onChange()
+			}
+		}
+	}()
+	return nil
+}
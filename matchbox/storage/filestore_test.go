@@ -28,9 +28,9 @@ func TestGroupCRUD(t *testing.T) {
 
 	group, err := store.GroupGet(fake.Group.Id)
 	assert.Nil(t, err)
-	assert.Equal(t, fake.Group, group)
+	assert.Equal(t, fake.Group, clearGroupVersions(group)[0])
 
-	err = store.GroupDelete(fake.Group.Id)
+	err = store.GroupDelete(fake.Group.Id, "")
 	assert.Nil(t, err)
 	_, err = store.GroupGet(fake.Group.Id)
 	if assert.Error(t, err) {
@@ -53,10 +53,10 @@ func TestGroupGet(t *testing.T) {
 	// - Groups written to the store can be retrieved
 	group, err := store.GroupGet(fake.Group.Id)
 	assert.Nil(t, err)
-	assert.Equal(t, fake.Group, group)
+	assert.Equal(t, fake.Group, clearGroupVersions(group)[0])
 	group, err = store.GroupGet(fake.GroupNoMetadata.Id)
 	assert.Nil(t, err)
-	assert.Equal(t, fake.GroupNoMetadata, group)
+	assert.Equal(t, fake.GroupNoMetadata, clearGroupVersions(group)[0])
 }
 
 func TestGroupGet_NoGroup(t *testing.T) {
@@ -84,6 +84,7 @@ func TestGroupList(t *testing.T) {
 	store := NewFileStore(&Config{Root: dir})
 	groups, err := store.GroupList()
 	assert.Nil(t, err)
+	clearGroupVersions(groups...)
 	if assert.Equal(t, 2, len(groups)) {
 		assert.Contains(t, groups, fake.Group)
 		assert.Contains(t, groups, fake.GroupNoMetadata)
@@ -106,9 +107,9 @@ func TestProfileCRUD(t *testing.T) {
 
 	profile, err := store.ProfileGet(fake.Profile.Id)
 	assert.Nil(t, err)
-	assert.Equal(t, fake.Profile, profile)
+	assert.Equal(t, fake.Profile, clearProfileVersions(profile)[0])
 
-	err = store.ProfileDelete(fake.Profile.Id)
+	err = store.ProfileDelete(fake.Profile.Id, "")
 	assert.Nil(t, err)
 	_, err = store.ProfileGet(fake.Profile.Id)
 	if assert.Error(t, err) {
@@ -125,7 +126,7 @@ func TestProfileGet(t *testing.T) {
 
 	store := NewFileStore(&Config{Root: dir})
 	profile, err := store.ProfileGet(fake.Profile.Id)
-	assert.Equal(t, fake.Profile, profile)
+	assert.Equal(t, fake.Profile, clearProfileVersions(profile)[0])
 	assert.Nil(t, err)
 	_, err = store.ProfileGet("no-such-profile")
 	if assert.Error(t, err) {
@@ -144,7 +145,7 @@ func TestProfileList(t *testing.T) {
 	profiles, err := store.ProfileList()
 	assert.Nil(t, err)
 	if assert.Equal(t, 1, len(profiles)) {
-		assert.Equal(t, fake.Profile, profiles[0])
+		assert.Equal(t, fake.Profile, clearProfileVersions(profiles[0])[0])
 	}
 }
 
@@ -158,14 +159,14 @@ func TestIgnitionCRUD(t *testing.T) {
 	// - Ignition template creation was successful
 	// - Ignition template can be retrieved by name
 	// - Ignition template can be deleted by name
-	err = store.IgnitionPut(fake.IgnitionYAMLName, []byte(fake.IgnitionYAML))
+	err = store.IgnitionPut(fake.IgnitionYAMLName, []byte(fake.IgnitionYAML), "")
 	assert.Nil(t, err)
 
 	template, err := store.IgnitionGet(fake.IgnitionYAMLName)
 	assert.Nil(t, err)
 	assert.Equal(t, fake.IgnitionYAML, template)
 
-	err = store.IgnitionDelete(fake.IgnitionYAMLName)
+	err = store.IgnitionDelete(fake.IgnitionYAMLName, "")
 	assert.Nil(t, err)
 	_, err = store.IgnitionGet(fake.IgnitionYAMLName)
 	if assert.Error(t, err) {
@@ -197,14 +198,14 @@ func TestGenericCRUD(t *testing.T) {
 	// - Generic template creation was successful
 	// - Generic template can be retrieved by name
 	// - Generic template can be deleted by name
-	err = store.GenericPut(fake.GenericName, []byte(fake.Generic))
+	err = store.GenericPut(fake.GenericName, []byte(fake.Generic), "")
 	assert.Nil(t, err)
 
 	template, err := store.GenericGet(fake.GenericName)
 	assert.Nil(t, err)
 	assert.Equal(t, fake.Generic, template)
 
-	err = store.GenericDelete(fake.GenericName)
+	err = store.GenericDelete(fake.GenericName, "")
 	assert.Nil(t, err)
 	_, err = store.GenericGet(fake.GenericName)
 	if assert.Error(t, err) {
@@ -226,6 +227,103 @@ func TestGenericGet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestTemplateList(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{
+		IgnitionConfigs: map[string]string{"b.yaml": "bb", "a.ign": "a"},
+		GenericConfigs:  map[string]string{"generic": fake.Generic},
+	})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewFileStore(&Config{Root: dir})
+	// assert that:
+	// - templates are listed by name with sizes and modification times
+	templates, err := store.IgnitionList()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(templates)) {
+		assert.Equal(t, "a.ign", templates[0].Name)
+		assert.Equal(t, int64(1), templates[0].Size)
+		assert.Equal(t, "b.yaml", templates[1].Name)
+		assert.Equal(t, int64(2), templates[1].Size)
+		assert.NotZero(t, templates[1].Modified)
+	}
+	templates, err = store.GenericList()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(templates)) {
+		assert.Equal(t, "generic", templates[0].Name)
+		assert.Equal(t, int64(len(fake.Generic)), templates[0].Size)
+	}
+
+	// - missing template directories list no templates
+	empty, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(empty)
+	store = NewFileStore(&Config{Root: empty})
+	templates, err = store.IgnitionList()
+	assert.Nil(t, err)
+	assert.Empty(t, templates)
+}
+
+func TestPartialCRUD(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewFileStore(&Config{Root: dir})
+	// assert that:
+	// - template partials are optional
+	// - template partial creation was successful
+	// - template partials can be listed and retrieved by name
+	// - template partials can be deleted by name
+	names, err := store.PartialList()
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+
+	err = store.PartialPut("sshkeys", []byte(`{{.ssh_authorized_key}}`), "")
+	assert.Nil(t, err)
+	err = store.PartialPut("ntp", []byte(`pool.ntp.org`), "")
+	assert.Nil(t, err)
+
+	names, err = store.PartialList()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ntp", "sshkeys"}, names)
+	template, err := store.PartialGet("sshkeys")
+	assert.Nil(t, err)
+	assert.Equal(t, `{{.ssh_authorized_key}}`, template)
+
+	err = store.PartialDelete("sshkeys", "")
+	assert.Nil(t, err)
+	_, err = store.PartialGet("sshkeys")
+	if assert.Error(t, err) {
+		assert.IsType(t, err, &os.PathError{})
+	}
+}
+
+func TestCloudCRUD(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewFileStore(&Config{Root: dir})
+	// assert that:
+	// - Cloud-Config template creation was successful
+	// - Cloud-Config template can be retrieved by name
+	// - Cloud-Config template can be deleted by name
+	err = store.CloudPut("cloud.yaml", []byte("#cloud-config"), "")
+	assert.Nil(t, err)
+
+	template, err := store.CloudGet("cloud.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "#cloud-config", template)
+
+	err = store.CloudDelete("cloud.yaml", "")
+	assert.Nil(t, err)
+	_, err = store.CloudGet("cloud.yaml")
+	if assert.Error(t, err) {
+		assert.IsType(t, err, &os.PathError{})
+	}
+}
+
 func TestCloudGet(t *testing.T) {
 	contents := "#cloud-config"
 	dir, err := setup(&fake.FixedStore{
@@ -240,6 +338,77 @@ func TestCloudGet(t *testing.T) {
 	assert.Equal(t, contents, cfg)
 }
 
+func TestInvalid(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{
+		Groups:   map[string]*storagepb.Group{fake.Group.Id: fake.Group},
+		Profiles: map[string]*storagepb.Profile{fake.Profile.Id: fake.Profile},
+	})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	// a truncated Group, an invalid Profile, and files which aren't resources
+	files := map[string]string{
+		"groups/truncated.json": `{"id": "truncated", "profi`,
+		"groups/README.md":      "not a Group",
+		"groups/.new.json.tmp1": `{"id": "new"`,
+		"profiles/noid.json":    `{"name": "no id"}`,
+	}
+	for name, contents := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), defaultFileMode)
+		assert.Nil(t, err)
+	}
+
+	store := NewFileStore(&Config{Root: dir})
+	// assert that:
+	// - unparsable Groups and Profiles are reported
+	// - unparsable resources are skipped when listing
+	// - hidden and non-JSON files are ignored
+	invalid, err := store.(Validator).Invalid()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(invalid)) {
+		assert.Equal(t, "Group", invalid[0].Kind)
+		assert.Equal(t, "truncated", invalid[0].Id)
+		assert.Equal(t, "unexpected end of JSON input", invalid[0].Error)
+		assert.Equal(t, "Profile", invalid[1].Kind)
+		assert.Equal(t, "noid", invalid[1].Id)
+	}
+	groups, err := store.GroupList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(groups))
+	profiles, err := store.ProfileList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(profiles))
+
+	// missing directories have no invalid resources
+	empty, err := ioutil.TempDir("", "data")
+	assert.Nil(t, err)
+	defer os.RemoveAll(empty)
+	invalid, err = NewFileStore(&Config{Root: empty}).(Validator).Invalid()
+	assert.Nil(t, err)
+	assert.Empty(t, invalid)
+}
+
+// clearGroupVersions clears the resource versions of stored Groups so they
+// may be compared with fixtures.
+func clearGroupVersions(groups ...*storagepb.Group) []*storagepb.Group {
+	for _, group := range groups {
+		if group != nil {
+			group.ResourceVersion = ""
+		}
+	}
+	return groups
+}
+
+// clearProfileVersions clears the resource versions of stored Profiles so
+// they may be compared with fixtures.
+func clearProfileVersions(profiles ...*storagepb.Profile) []*storagepb.Profile {
+	for _, profile := range profiles {
+		if profile != nil {
+			profile.ResourceVersion = ""
+		}
+	}
+	return profiles
+}
+
 // setup creates a temp fileStore directory to mirror a given fixedStore
 // for testing. Returns the directory tree root. The caller must remove the
 // temp directory when finished.
@@ -42,17 +42,43 @@ func (d Dir) readDir(dirname string) ([]os.FileInfo, error) {
 }
 
 // writeFile writes the data as a file at given path, restricted to a specific
-// directory tree.
+// directory tree. Writes are atomic: data is written and synced to a hidden
+// temporary file which then replaces the file, so a crash or full disk never
+// leaves a partially written file.
 func (d Dir) writeFile(path string, data []byte) error {
 	// make parent directories as needed
 	path, err := d.sanitize(path)
 	if err != nil {
 		return err
 	}
-	if err := os.MkdirAll(filepath.Dir(path), defaultDirectoryMode); err != nil {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, defaultDirectoryMode); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	// no-op once the temporary file has been renamed
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), defaultFileMode); err != nil {
 		return err
 	}
-	return ioutil.WriteFile(path, data, defaultFileMode)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	return syncDir(dir)
 }
 
 // deleteFile removes the file at the given path, restricted to a specific
@@ -62,7 +88,26 @@ func (d Dir) deleteFile(path string) error {
 	if err != nil {
 		return err
 	}
-	return os.Remove(path)
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir syncs a directory so that renames and removals of its entries are
+// durable.
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Sync()
+}
+
+// isHidden returns true if the file is hidden (e.g. a temporary file).
+func isHidden(finfo os.FileInfo) bool {
+	return strings.HasPrefix(finfo.Name(), ".")
 }
 
 // Borrowed directly from net/http Dir.Open and FileServer.
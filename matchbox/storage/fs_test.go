@@ -55,6 +55,31 @@ func TestDir(t *testing.T) {
 	}
 }
 
+func TestDir_WriteFileAtomic(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "matchbox")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tdir)
+	dir := Dir(tdir)
+
+	// assert that:
+	// - files can be overwritten
+	// - written files have the default file mode
+	// - no temporary files are left behind
+	assert.Nil(t, dir.writeFile("groups/a.json", []byte(`{"id":"a"}`)))
+	assert.Nil(t, dir.writeFile("groups/a.json", []byte(`{"id":"b"}`)))
+	b, err := dir.readFile("groups/a.json")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":"b"}`, string(b))
+	finfo, err := os.Stat(filepath.Join(tdir, "groups/a.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, defaultFileMode, finfo.Mode())
+	files, err := dir.readDir("groups")
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(files)) {
+		assert.Equal(t, "a.json", files[0].Name())
+	}
+}
+
 func TestSanitizePath(t *testing.T) {
 	cases := []struct {
 		dir      Dir
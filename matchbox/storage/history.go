@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// DefaultRevisions is the number of revisions kept of each resource by
+// default.
+const DefaultRevisions = 10
+
+var (
+	// ErrRevisionNotFound is returned when no revision is kept with a number.
+	ErrRevisionNotFound = errors.New("storage: No revision found")
+	// ErrUnknownKind is returned for a resource kind other than Group,
+	// Profile, Ignition, Generic, Cloud, or Partial.
+	ErrUnknownKind = errors.New("storage: unknown resource kind")
+)
+
+// A HistoryStore keeps the recent revisions of each Group, Profile, and
+// template. Stores which keep revisions alongside resources implement it.
+type HistoryStore interface {
+	// RevisionAdd records a revision of a resource and assigns it the next
+	// revision number. Only the most recent revisions are kept.
+	RevisionAdd(kind, name string, revision *storagepb.Revision) error
+	// RevisionList lists the kept revisions of a resource, oldest first.
+	RevisionList(kind, name string) ([]*storagepb.Revision, error)
+}
+
+// appendRevision numbers a revision after the last of the revisions, appends
+// it, and drops the oldest revisions beyond the limit.
+func appendRevision(revisions []*storagepb.Revision, revision *storagepb.Revision, limit int) []*storagepb.Revision {
+	revision.Revision = 1
+	if n := len(revisions); n > 0 {
+		revision.Revision = revisions[n-1].Revision + 1
+	}
+	revisions = append(revisions, revision)
+	if limit <= 0 {
+		limit = DefaultRevisions
+	}
+	if len(revisions) > limit {
+		revisions = revisions[len(revisions)-limit:]
+	}
+	return revisions
+}
+
+// checkKind returns an error if kind isn't a known resource kind.
+func checkKind(kind string) error {
+	if _, ok := kindDirs[kind]; !ok {
+		return ErrUnknownKind
+	}
+	return nil
+}
+
+// memHistoryStore implements the HistoryStore interface in memory.
+type memHistoryStore struct {
+	mu        sync.RWMutex
+	limit     int
+	revisions map[string]map[string][]*storagepb.Revision
+}
+
+// NewMemHistoryStore returns a new memory-backed HistoryStore which keeps up
+// to limit revisions of each resource (defaults to DefaultRevisions).
+func NewMemHistoryStore(limit int) HistoryStore {
+	return &memHistoryStore{
+		limit:     limit,
+		revisions: make(map[string]map[string][]*storagepb.Revision),
+	}
+}
+
+// RevisionAdd records a revision of a resource.
+func (s *memHistoryStore) RevisionAdd(kind, name string, revision *storagepb.Revision) error {
+	if err := checkKind(kind); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.revisions[kind] == nil {
+		s.revisions[kind] = make(map[string][]*storagepb.Revision)
+	}
+	s.revisions[kind][name] = appendRevision(s.revisions[kind][name], revision, s.limit)
+	return nil
+}
+
+// RevisionList lists the kept revisions of a resource, oldest first.
+func (s *memHistoryStore) RevisionList(kind, name string) ([]*storagepb.Revision, error) {
+	if err := checkKind(kind); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	revisions := make([]*storagepb.Revision, len(s.revisions[kind][name]))
+	copy(revisions, s.revisions[kind][name])
+	return revisions, nil
+}
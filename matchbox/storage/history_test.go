@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+func TestAppendRevision(t *testing.T) {
+	var revisions []*storagepb.Revision
+	for i := 0; i < 5; i++ {
+		revisions = appendRevision(revisions, &storagepb.Revision{}, 3)
+	}
+	// assert that:
+	// - revisions are numbered in order of writes
+	// - only the most recent revisions are kept
+	numbers := []int64{}
+	for _, revision := range revisions {
+		numbers = append(numbers, revision.Revision)
+	}
+	assert.Equal(t, []int64{3, 4, 5}, numbers)
+}
+
+func TestMemHistoryStore(t *testing.T) {
+	testHistory(t, NewMemHistoryStore(2))
+}
+
+func TestFileStore_History(t *testing.T) {
+	dir, err := ioutil.TempDir("", "matchbox")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewFileStore(&Config{Root: dir, Revisions: 2})
+	testHistory(t, store.(HistoryStore))
+	// assert that:
+	// - revisions are hidden from template listings
+	assert.Nil(t, store.IgnitionPut("a.ign", []byte("{}"), ""))
+	templates, err := store.IgnitionList()
+	assert.Nil(t, err)
+	assert.Len(t, templates, 1)
+}
+
+// testHistory tests a HistoryStore which keeps 2 revisions of each resource.
+func testHistory(t *testing.T, store HistoryStore) {
+	revisions, err := store.RevisionList(KindGroup, fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Empty(t, revisions)
+
+	assert.Nil(t, store.RevisionAdd(KindGroup, fake.Group.Id, &storagepb.Revision{Group: fake.Group, Author: "a"}))
+	assert.Nil(t, store.RevisionAdd(KindGroup, fake.Group.Id, &storagepb.Revision{Deleted: true, Author: "b"}))
+	assert.Nil(t, store.RevisionAdd(KindGroup, fake.Group.Id, &storagepb.Revision{Group: fake.GroupNoMetadata, Author: "c"}))
+	assert.Nil(t, store.RevisionAdd(KindIgnition, "a.ign", &storagepb.Revision{Contents: []byte("{}")}))
+	// assert that:
+	// - the most recent revisions of each resource are listed, oldest first
+	revisions, err = store.RevisionList(KindGroup, fake.Group.Id)
+	assert.Nil(t, err)
+	if assert.Len(t, revisions, 2) {
+		assert.Equal(t, int64(2), revisions[0].Revision)
+		assert.True(t, revisions[0].Deleted)
+		assert.Equal(t, "b", revisions[0].Author)
+		assert.Equal(t, int64(3), revisions[1].Revision)
+		assert.Equal(t, fake.GroupNoMetadata.Selector, revisions[1].Group.Selector)
+	}
+	revisions, err = store.RevisionList(KindIgnition, "a.ign")
+	assert.Nil(t, err)
+	if assert.Len(t, revisions, 1) {
+		assert.Equal(t, int64(1), revisions[0].Revision)
+		assert.Equal(t, []byte("{}"), revisions[0].Contents)
+	}
+
+	// assert that:
+	// - unknown kinds are rejected
+	assert.Equal(t, ErrUnknownKind, store.RevisionAdd("Machine", "a", &storagepb.Revision{}))
+	_, err = store.RevisionList("Machine", "a")
+	assert.Equal(t, ErrUnknownKind, err)
+}
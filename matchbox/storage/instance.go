@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"container/list"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// DefaultInstances is the number of Instances kept in memory by default.
+const DefaultInstances = 10000
+
+// ErrInstanceNotFound is returned when no Instance has been observed with an id.
+var ErrInstanceNotFound = errors.New("storage: No Instance found")
+
+// An InstanceStore stores machine Instances observed by the server.
+type InstanceStore interface {
+	// InstancePut creates or updates an Instance.
+	InstancePut(instance *storagepb.Instance) error
+	// InstanceGet returns an Instance by id.
+	InstanceGet(id string) (*storagepb.Instance, error)
+	// InstanceList lists all Instances.
+	InstanceList() ([]*storagepb.Instance, error)
+}
+
+// memInstanceStore implements the InstanceStore interface in memory. Instances
+// are ordered from most to least recently recorded, so the least recently
+// seen machine can be dropped.
+type memInstanceStore struct {
+	mu        sync.RWMutex
+	limit     int
+	recent    *list.List
+	instances map[string]*list.Element
+}
+
+// NewMemInstanceStore returns a new memory-backed InstanceStore which keeps up
+// to limit Instances (defaults to DefaultInstances), dropping the least
+// recently recorded Instances beyond the limit.
+func NewMemInstanceStore(limit int) InstanceStore {
+	if limit <= 0 {
+		limit = DefaultInstances
+	}
+	return &memInstanceStore{
+		limit:     limit,
+		recent:    list.New(),
+		instances: make(map[string]*list.Element),
+	}
+}
+
+// InstancePut creates or updates an Instance.
+func (s *memInstanceStore) InstancePut(instance *storagepb.Instance) error {
+	if err := instance.AssertValid(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, present := s.instances[instance.Id]; present {
+		elem.Value = instance.Copy()
+		s.recent.MoveToFront(elem)
+		return nil
+	}
+	s.instances[instance.Id] = s.recent.PushFront(instance.Copy())
+	if s.recent.Len() > s.limit {
+		oldest := s.recent.Remove(s.recent.Back()).(*storagepb.Instance)
+		delete(s.instances, oldest.Id)
+	}
+	return nil
+}
+
+// InstanceGet returns an Instance by id.
+func (s *memInstanceStore) InstanceGet(id string) (*storagepb.Instance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if elem, present := s.instances[id]; present {
+		return elem.Value.(*storagepb.Instance).Copy(), nil
+	}
+	return nil, ErrInstanceNotFound
+}
+
+// InstanceList lists all Instances, sorted by id.
+func (s *memInstanceStore) InstanceList() ([]*storagepb.Instance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	instances := make([]*storagepb.Instance, 0, len(s.instances))
+	for _, elem := range s.instances {
+		instances = append(instances, elem.Value.(*storagepb.Instance).Copy())
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Id < instances[j].Id
+	})
+	return instances, nil
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+func TestInstanceCRUD(t *testing.T) {
+	store := NewMemInstanceStore(DefaultInstances)
+	instance := &storagepb.Instance{
+		Id:       "a1b2c3d4",
+		Labels:   map[string]string{"uuid": "a1b2c3d4"},
+		Group:    "test-group",
+		Profile:  "g1h2i3j4",
+		Endpoint: "/ipxe",
+	}
+	// assert that:
+	// - Instance creation was successful
+	// - Instance can be retrieved by id
+	// - stored Instances are not aliased by callers
+	err := store.InstancePut(instance)
+	assert.Nil(t, err)
+
+	got, err := store.InstanceGet(instance.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, instance, got)
+
+	got.Labels["mac"] = "52:da:00:89:d8:10"
+	got, err = store.InstanceGet(instance.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, instance, got)
+
+	_, err = store.InstanceGet("no-such-instance")
+	assert.Equal(t, ErrInstanceNotFound, err)
+}
+
+func TestInstancePut_Invalid(t *testing.T) {
+	store := NewMemInstanceStore(DefaultInstances)
+	err := store.InstancePut(&storagepb.Instance{})
+	assert.Equal(t, storagepb.ErrInstanceIdRequired, err)
+}
+
+func TestInstanceList(t *testing.T) {
+	store := NewMemInstanceStore(DefaultInstances)
+	a := &storagepb.Instance{Id: "a", Labels: map[string]string{"uuid": "a"}}
+	b := &storagepb.Instance{Id: "b", Labels: map[string]string{"uuid": "b"}}
+	assert.Nil(t, store.InstancePut(b))
+	assert.Nil(t, store.InstancePut(a))
+
+	instances, err := store.InstanceList()
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.Instance{a, b}, instances)
+}
+
+func TestInstancePut_Limit(t *testing.T) {
+	store := NewMemInstanceStore(2)
+	a := &storagepb.Instance{Id: "a", Labels: map[string]string{"uuid": "a"}}
+	b := &storagepb.Instance{Id: "b", Labels: map[string]string{"uuid": "b"}}
+	c := &storagepb.Instance{Id: "c", Labels: map[string]string{"uuid": "c"}}
+	// assert that:
+	// - Instances beyond the limit drop the least recently recorded Instance
+	// - recording an Instance again keeps it
+	assert.Nil(t, store.InstancePut(a))
+	assert.Nil(t, store.InstancePut(b))
+	assert.Nil(t, store.InstancePut(a))
+	assert.Nil(t, store.InstancePut(c))
+
+	instances, err := store.InstanceList()
+	assert.Nil(t, err)
+	assert.Equal(t, []*storagepb.Instance{a, c}, instances)
+	_, err = store.InstanceGet("b")
+	assert.Equal(t, ErrInstanceNotFound, err)
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// A Validator reports stored resources which can't be parsed. Lists skip
+// such resources, so callers which must not silently ignore them (e.g. Group
+// selection) should check for them.
+type Validator interface {
+	// Invalid lists stored Groups and Profiles which can't be parsed.
+	Invalid() ([]*storagepb.InvalidResource, error)
+}
+
+// invalidResource returns an InvalidResource for the resource of the given
+// kind and id which failed to parse with err.
+func invalidResource(kind, id string, err error) *storagepb.InvalidResource {
+	return &storagepb.InvalidResource{Kind: kind, Id: id, Error: err.Error()}
+}
+
+// logInvalid warns about resources skipped because they can't be parsed.
+func logInvalid(logger *logrus.Logger, invalid []*storagepb.InvalidResource) {
+	if logger == nil {
+		return
+	}
+	for _, r := range invalid {
+		logger.Warningf("%s %q can't be parsed and was skipped: %s", r.Kind, r.Id, r.Error)
+	}
+}
+
+// resourceNames returns the names of the JSON resource files among the given
+// files, without extensions. Directories and hidden files (e.g. temporary
+// files) are skipped.
+func resourceNames(files []os.FileInfo) []string {
+	names := make([]string, 0, len(files))
+	for _, finfo := range files {
+		if finfo.IsDir() || isHidden(finfo) || filepath.Ext(finfo.Name()) != ".json" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(finfo.Name(), ".json"))
+	}
+	return names
+}
@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+var operationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "matchbox",
+	Subsystem: "store",
+	Name:      "operation_duration_seconds",
+	Help:      "Latency of Store operations.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"operation"})
+
+func init() {
+	prometheus.MustRegister(operationDuration)
+}
+
+// observe records the latency of a Store operation which started at the
+// given time.
+func observe(operation string, start time.Time) {
+	operationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// instrumentedStore is a Store which records the latency of each operation
+// of the underlying Store.
+type instrumentedStore struct {
+	store Store
+}
+
+// NewInstrumentedStore returns a Store which records the latency of each
+// operation of the given Store as a Prometheus metric. Watching and listing
+// invalid resources are passed through, if the Store supports them.
+func NewInstrumentedStore(store Store) Store {
+	return &instrumentedStore{
+		store: store,
+	}
+}
+
+// Watch calls the onChange function whenever the underlying Store reports
+// changes, if it is a Watcher.
+func (s *instrumentedStore) Watch(ctx context.Context, onChange func()) error {
+	watcher, ok := s.store.(Watcher)
+	if !ok {
+		return errNotWatchable
+	}
+	return watcher.Watch(ctx, onChange)
+}
+
+// Invalid lists Groups and Profiles which the underlying Store can't parse,
+// if it is a Validator.
+func (s *instrumentedStore) Invalid() ([]*storagepb.InvalidResource, error) {
+	validator, ok := s.store.(Validator)
+	if !ok {
+		return []*storagepb.InvalidResource{}, nil
+	}
+	defer observe("Invalid", time.Now())
+	return validator.Invalid()
+}
+
+// GroupPut creates or updates a Group.
+func (s *instrumentedStore) GroupPut(group *storagepb.Group) error {
+	defer observe("GroupPut", time.Now())
+	return s.store.GroupPut(group)
+}
+
+// GroupGet returns a machine Group by id.
+func (s *instrumentedStore) GroupGet(id string) (*storagepb.Group, error) {
+	defer observe("GroupGet", time.Now())
+	return s.store.GroupGet(id)
+}
+
+// GroupDelete deletes a machine Group by id.
+func (s *instrumentedStore) GroupDelete(id, version string) error {
+	defer observe("GroupDelete", time.Now())
+	return s.store.GroupDelete(id, version)
+}
+
+// GroupList lists all machine Groups.
+func (s *instrumentedStore) GroupList() ([]*storagepb.Group, error) {
+	defer observe("GroupList", time.Now())
+	return s.store.GroupList()
+}
+
+// ProfilePut creates or updates a Profile.
+func (s *instrumentedStore) ProfilePut(profile *storagepb.Profile) error {
+	defer observe("ProfilePut", time.Now())
+	return s.store.ProfilePut(profile)
+}
+
+// ProfileGet gets a profile by id.
+func (s *instrumentedStore) ProfileGet(id string) (*storagepb.Profile, error) {
+	defer observe("ProfileGet", time.Now())
+	return s.store.ProfileGet(id)
+}
+
+// ProfileDelete deletes a profile by id.
+func (s *instrumentedStore) ProfileDelete(id, version string) error {
+	defer observe("ProfileDelete", time.Now())
+	return s.store.ProfileDelete(id, version)
+}
+
+// ProfileList lists all profiles.
+func (s *instrumentedStore) ProfileList() ([]*storagepb.Profile, error) {
+	defer observe("ProfileList", time.Now())
+	return s.store.ProfileList()
+}
+
+// IgnitionPut creates or updates an Ignition template.
+func (s *instrumentedStore) IgnitionPut(name string, config []byte, version string) error {
+	defer observe("IgnitionPut", time.Now())
+	return s.store.IgnitionPut(name, config, version)
+}
+
+// IgnitionGet gets an Ignition template by name.
+func (s *instrumentedStore) IgnitionGet(name string) (string, error) {
+	defer observe("IgnitionGet", time.Now())
+	return s.store.IgnitionGet(name)
+}
+
+// IgnitionDelete deletes an Ignition template by name.
+func (s *instrumentedStore) IgnitionDelete(name, version string) error {
+	defer observe("IgnitionDelete", time.Now())
+	return s.store.IgnitionDelete(name, version)
+}
+
+// IgnitionList lists all Ignition templates.
+func (s *instrumentedStore) IgnitionList() ([]*storagepb.TemplateInfo, error) {
+	defer observe("IgnitionList", time.Now())
+	return s.store.IgnitionList()
+}
+
+// GenericPut creates or updates a Generic template.
+func (s *instrumentedStore) GenericPut(name string, config []byte, version string) error {
+	defer observe("GenericPut", time.Now())
+	return s.store.GenericPut(name, config, version)
+}
+
+// GenericGet gets a Generic template by name.
+func (s *instrumentedStore) GenericGet(name string) (string, error) {
+	defer observe("GenericGet", time.Now())
+	return s.store.GenericGet(name)
+}
+
+// GenericDelete deletes a Generic template by name.
+func (s *instrumentedStore) GenericDelete(name, version string) error {
+	defer observe("GenericDelete", time.Now())
+	return s.store.GenericDelete(name, version)
+}
+
+// GenericList lists all Generic templates.
+func (s *instrumentedStore) GenericList() ([]*storagepb.TemplateInfo, error) {
+	defer observe("GenericList", time.Now())
+	return s.store.GenericList()
+}
+
+// CloudPut creates or updates a Cloud-Config template.
+func (s *instrumentedStore) CloudPut(name string, config []byte, version string) error {
+	defer observe("CloudPut", time.Now())
+	return s.store.CloudPut(name, config, version)
+}
+
+// CloudGet gets a Cloud-Config template by name.
+func (s *instrumentedStore) CloudGet(name string) (string, error) {
+	defer observe("CloudGet", time.Now())
+	return s.store.CloudGet(name)
+}
+
+// CloudDelete deletes a Cloud-Config template by name.
+func (s *instrumentedStore) CloudDelete(name, version string) error {
+	defer observe("CloudDelete", time.Now())
+	return s.store.CloudDelete(name, version)
+}
+
+// CloudList lists all Cloud-Config templates.
+func (s *instrumentedStore) CloudList() ([]*storagepb.TemplateInfo, error) {
+	defer observe("CloudList", time.Now())
+	return s.store.CloudList()
+}
+
+// PartialPut creates or updates a template partial.
+func (s *instrumentedStore) PartialPut(name string, config []byte, version string) error {
+	defer observe("PartialPut", time.Now())
+	return s.store.PartialPut(name, config, version)
+}
+
+// PartialGet gets a template partial by name.
+func (s *instrumentedStore) PartialGet(name string) (string, error) {
+	defer observe("PartialGet", time.Now())
+	return s.store.PartialGet(name)
+}
+
+// PartialDelete deletes a template partial by name.
+func (s *instrumentedStore) PartialDelete(name, version string) error {
+	defer observe("PartialDelete", time.Now())
+	return s.store.PartialDelete(name, version)
+}
+
+// PartialList lists the names of all template partials.
+func (s *instrumentedStore) PartialList() ([]string, error) {
+	defer observe("PartialList", time.Now())
+	return s.store.PartialList()
+}
+
+// Apply writes and deletes the resources of a Batch together.
+func (s *instrumentedStore) Apply(batch *storagepb.Batch) error {
+	defer observe("Apply", time.Now())
+	return s.store.Apply(batch)
+}
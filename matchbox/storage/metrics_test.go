@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+// operationCount returns the number of latencies recorded for an operation.
+func operationCount(t *testing.T, operation string) uint64 {
+	metric := new(dto.Metric)
+	err := operationDuration.WithLabelValues(operation).(prometheus.Histogram).Write(metric)
+	assert.Nil(t, err)
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestInstrumentedStore(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	store := NewInstrumentedStore(NewFileStore(&Config{Root: dir}))
+
+	// assert that:
+	// - operations are passed through and their latencies recorded
+	// - watching and validation are passed through
+	before := operationCount(t, "GroupGet")
+	assert.Nil(t, store.GroupPut(fake.Group))
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, fake.Group.Id, group.Id)
+	_, err = store.GroupGet("missing")
+	assert.NotNil(t, err)
+	assert.Equal(t, before+2, operationCount(t, "GroupGet"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Nil(t, store.(Watcher).Watch(ctx, func() {}))
+	invalid, err := store.(Validator).Invalid()
+	assert.Nil(t, err)
+	assert.Empty(t, invalid)
+
+	// a Store which can't be watched
+	store = NewInstrumentedStore(fake.NewFixedStore())
+	assert.Equal(t, errNotWatchable, store.(Watcher).Watch(ctx, func() {}))
+	invalid, err = store.(Validator).Invalid()
+	assert.Nil(t, err)
+	assert.Empty(t, invalid)
+}
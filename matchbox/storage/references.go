@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// Referencing fields of Groups and Profiles
+const (
+	FieldProfile    = "profile"
+	FieldParent     = "parent"
+	FieldIgnitionId = "ignition_id"
+	FieldGenericId  = "generic_id"
+	FieldCloudId    = "cloud_id"
+)
+
+// A Reference is a reference from a Group or Profile field to a Profile or
+// template.
+type Reference struct {
+	// Kind of the referencing resource ("Group" or "Profile")
+	Kind string
+	// Id of the referencing resource
+	Id string
+	// Field is the referencing field (e.g. "ignition_id")
+	Field string
+	// Target is the referenced Profile id or template name
+	Target string
+}
+
+func (r *Reference) String() string {
+	return fmt.Sprintf("%s %s %s=%s", r.Kind, r.Id, r.Field, r.Target)
+}
+
+// exists returns true if the referenced Profile or template is in the Store.
+// Errors other than a missing target are returned.
+func (r *Reference) exists(s Store) (bool, error) {
+	var err error
+	switch r.Field {
+	case FieldProfile, FieldParent:
+		_, err = s.ProfileGet(r.Target)
+	case FieldIgnitionId:
+		_, err = s.IgnitionGet(r.Target)
+	case FieldGenericId:
+		_, err = s.GenericGet(r.Target)
+	case FieldCloudId:
+		_, err = s.CloudGet(r.Target)
+	}
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GroupReferences returns the references a Group makes.
+func GroupReferences(group *storagepb.Group) []*Reference {
+	return []*Reference{
+		{Kind: "Group", Id: group.Id, Field: FieldProfile, Target: group.Profile},
+	}
+}
+
+// ProfileReferences returns the references a Profile makes.
+func ProfileReferences(profile *storagepb.Profile) []*Reference {
+	var refs []*Reference
+	fields := []struct {
+		field  string
+		target string
+	}{
+		{FieldParent, profile.Parent},
+		{FieldIgnitionId, profile.IgnitionId},
+		{FieldGenericId, profile.GenericId},
+		{FieldCloudId, profile.CloudId},
+	}
+	for _, f := range fields {
+		if f.target != "" {
+			refs = append(refs, &Reference{Kind: "Profile", Id: profile.Id, Field: f.field, Target: f.target})
+		}
+	}
+	return refs
+}
+
+// Dangling returns the references whose targets are not in the Store.
+func Dangling(s Store, refs []*Reference) ([]*Reference, error) {
+	var dangling []*Reference
+	for _, ref := range refs {
+		exists, err := ref.exists(s)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			dangling = append(dangling, ref)
+		}
+	}
+	return dangling, nil
+}
+
+// Referrers returns the references in the Store to the target of any of the
+// given fields (e.g. Groups and Profiles which reference a Profile by its
+// "profile" and "parent" fields).
+func Referrers(s Store, target string, fields ...string) ([]*Reference, error) {
+	refs, err := allReferences(s)
+	if err != nil {
+		return nil, err
+	}
+	var referrers []*Reference
+	for _, ref := range refs {
+		if ref.Target != target {
+			continue
+		}
+		for _, field := range fields {
+			if ref.Field == field {
+				referrers = append(referrers, ref)
+			}
+		}
+	}
+	return referrers, nil
+}
+
+// Check returns all dangling references between Groups, Profiles, and
+// templates in the Store.
+func Check(s Store) ([]*Reference, error) {
+	refs, err := allReferences(s)
+	if err != nil {
+		return nil, err
+	}
+	return Dangling(s, refs)
+}
+
+// allReferences returns the references of all Groups and Profiles.
+func allReferences(s Store) ([]*Reference, error) {
+	groups, err := s.GroupList()
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := s.ProfileList()
+	if err != nil {
+		return nil, err
+	}
+	var refs []*Reference
+	for _, group := range groups {
+		refs = append(refs, GroupReferences(group)...)
+	}
+	for _, profile := range profiles {
+		refs = append(refs, ProfileReferences(profile)...)
+	}
+	return refs, nil
+}
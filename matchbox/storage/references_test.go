@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+func TestProfileReferences(t *testing.T) {
+	profile := &storagepb.Profile{Id: "child", Parent: "parent", GenericId: "generic.tmpl"}
+	expected := []*Reference{
+		{Kind: "Profile", Id: "child", Field: FieldParent, Target: "parent"},
+		{Kind: "Profile", Id: "child", Field: FieldGenericId, Target: "generic.tmpl"},
+	}
+	assert.Equal(t, expected, ProfileReferences(profile))
+}
+
+func TestCheck(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Groups[fake.Group.Id] = fake.Group
+	store.Groups["orphan"] = &storagepb.Group{Id: "orphan", Profile: "missing"}
+	store.Profiles[fake.Profile.Id] = fake.Profile
+	store.IgnitionConfigs[fake.Profile.IgnitionId] = fake.IgnitionYAML
+	store.CloudConfigs[fake.Profile.CloudId] = "#cloud-config"
+
+	// assert that:
+	// - dangling Group and Profile references are reported
+	// - existing references are not reported
+	dangling, err := Check(store)
+	assert.Nil(t, err)
+	expected := []*Reference{
+		{Kind: "Group", Id: "orphan", Field: FieldProfile, Target: "missing"},
+		{Kind: "Profile", Id: fake.Profile.Id, Field: FieldGenericId, Target: fake.Profile.GenericId},
+	}
+	assert.ElementsMatch(t, expected, dangling)
+
+	_, err = Check(&fake.BrokenStore{})
+	assert.Error(t, err)
+}
+
+// unavailableStore fails to get Profiles, like an unreachable etcd.
+type unavailableStore struct {
+	*fake.FixedStore
+}
+
+func (s unavailableStore) ProfileGet(id string) (*storagepb.Profile, error) {
+	return nil, errors.New("context deadline exceeded")
+}
+
+func TestCheck_Unavailable(t *testing.T) {
+	store := unavailableStore{fake.NewFixedStore()}
+	store.Groups[fake.Group.Id] = fake.Group
+
+	// assert that:
+	// - errors reading a referenced target are returned, not reported as
+	// dangling references
+	dangling, err := Check(store)
+	assert.EqualError(t, err, "context deadline exceeded")
+	assert.Nil(t, dangling)
+}
+
+func TestIsNotFound(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// assert that:
+	// - missing files, fake resources, and storage sentinels are not found
+	// - other errors are not
+	_, err = NewFileStore(&Config{Root: dir}).ProfileGet("missing")
+	assert.True(t, IsNotFound(err))
+	_, err = fake.NewFixedStore().IgnitionGet("missing")
+	assert.True(t, IsNotFound(err))
+	assert.True(t, IsNotFound(ErrTemplateNotFound))
+	assert.False(t, IsNotFound(errors.New("context deadline exceeded")))
+}
+
+func TestReferrers(t *testing.T) {
+	store := fake.NewFixedStore()
+	store.Groups[fake.Group.Id] = fake.Group
+	store.Profiles["child"] = &storagepb.Profile{Id: "child", Parent: fake.Profile.Id}
+
+	referrers, err := Referrers(store, fake.Profile.Id, FieldProfile, FieldParent)
+	assert.Nil(t, err)
+	expected := []*Reference{
+		{Kind: "Group", Id: fake.Group.Id, Field: FieldProfile, Target: fake.Profile.Id},
+		{Kind: "Profile", Id: "child", Field: FieldParent, Target: fake.Profile.Id},
+	}
+	assert.Equal(t, expected, referrers)
+
+	referrers, err = Referrers(store, fake.Profile.Id, FieldIgnitionId)
+	assert.Nil(t, err)
+	assert.Empty(t, referrers)
+}
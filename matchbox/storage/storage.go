@@ -2,6 +2,7 @@ package storage
 
 import (
 	"errors"
+	"os"
 
 	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
@@ -12,14 +13,30 @@ var (
 	ErrProfileNotFound = errors.New("storage: No Profile found")
 )
 
+// IsNotFound returns true if the error reports a missing Group, Profile, or
+// template, rather than a failure to read it.
+func IsNotFound(err error) bool {
+	switch err {
+	case ErrGroupNotFound, ErrProfileNotFound, ErrTemplateNotFound:
+		return true
+	}
+	return errors.Is(err, os.ErrNotExist)
+}
+
 // A Store stores machine Groups, Profiles, and Configs.
+//
+// Stores set the resource version (see Version) of Groups and Profiles they
+// return, a hash of their stored contents. Puts and Deletes may expect a resource version, given by the Group
+// or Profile or by the version argument, and fail with ErrVersionConflict if
+// the stored resource doesn't have it. An empty version matches anything and
+// NoVersion only matches resources which don't exist.
 type Store interface {
 	// GroupPut creates or updates a Group.
 	GroupPut(group *storagepb.Group) error
 	// GroupGet returns a machine Group by id.
 	GroupGet(id string) (*storagepb.Group, error)
 	// GroupDelete deletes a machine Group by id.
-	GroupDelete(id string) error
+	GroupDelete(id, version string) error
 	// GroupList lists all machine Groups.
 	GroupList() ([]*storagepb.Group, error)
 
@@ -28,24 +45,48 @@ type Store interface {
 	// ProfileGet gets a profile by id.
 	ProfileGet(id string) (*storagepb.Profile, error)
 	// ProfileDelete deletes a profile by id.
-	ProfileDelete(id string) error
+	ProfileDelete(id, version string) error
 	// ProfileList lists all profiles.
 	ProfileList() ([]*storagepb.Profile, error)
 
 	// IgnitionPut creates or updates an Ignition template.
-	IgnitionPut(name string, config []byte) error
+	IgnitionPut(name string, config []byte, version string) error
 	// IgnitionGet gets an Ignition template by name.
 	IgnitionGet(name string) (string, error)
 	// IgnitionDelete deletes an Ignition template by name.
-	IgnitionDelete(name string) error
+	IgnitionDelete(name, version string) error
+	// IgnitionList lists all Ignition templates.
+	IgnitionList() ([]*storagepb.TemplateInfo, error)
 
 	// GenericPut creates or updates a Generic template.
-	GenericPut(name string, config []byte) error
+	GenericPut(name string, config []byte, version string) error
 	// GenericGet gets a Generic template by name.
 	GenericGet(name string) (string, error)
 	// GenericDelete deletes a Generic template by name.
-	GenericDelete(name string) error
+	GenericDelete(name, version string) error
+	// GenericList lists all Generic templates.
+	GenericList() ([]*storagepb.TemplateInfo, error)
 
+	// CloudPut creates or updates a Cloud-Config template.
+	CloudPut(name string, config []byte, version string) error
 	// CloudGet gets a Cloud-Config template by name.
 	CloudGet(name string) (string, error)
+	// CloudDelete deletes a Cloud-Config template by name.
+	CloudDelete(name, version string) error
+	// CloudList lists all Cloud-Config templates.
+	CloudList() ([]*storagepb.TemplateInfo, error)
+
+	// PartialPut creates or updates a template partial.
+	PartialPut(name string, config []byte, version string) error
+	// PartialGet gets a template partial by name.
+	PartialGet(name string) (string, error)
+	// PartialDelete deletes a template partial by name.
+	PartialDelete(name, version string) error
+	// PartialList lists the names of all template partials.
+	PartialList() ([]string, error)
+
+	// Apply writes and deletes the resources of a Batch together. If any
+	// resource doesn't have its expected version, nothing is changed.
+	// Deleting a resource which doesn't exist is not an error.
+	Apply(batch *storagepb.Batch) error
 }
@@ -10,6 +10,7 @@ import (
 
 var (
 	ErrProfileRequired = errors.New("Group requires a Profile")
+	ErrInvalidPriority = errors.New("Group priority must not be negative")
 )
 
 // ParseGroup parses bytes into a Group.
@@ -34,29 +35,89 @@ func (g *Group) Copy() *Group {
 	for k, v := range g.Selector {
 		selectors[k] = v
 	}
+	var expressions []*SelectorRequirement
+	for _, expr := range g.MatchExpressions {
+		expressions = append(expressions, expr.Copy())
+	}
 	return &Group{
-		Id:       g.Id,
-		Name:     g.Name,
-		Profile:  g.Profile,
-		Selector: selectors,
-		Metadata: g.Metadata,
+		Id:               g.Id,
+		Name:             g.Name,
+		Profile:          g.Profile,
+		Selector:         selectors,
+		Metadata:         g.Metadata,
+		MatchExpressions: expressions,
+		Priority:         g.Priority,
+		ResourceVersion:  g.ResourceVersion,
 	}
 }
 
 // Matches returns true if the given labels satisfy all the selector
-// requirements, false otherwise.
+// requirements and selector expressions, false otherwise.
 func (g *Group) Matches(labels map[string]string) bool {
 	for key, val := range g.Selector {
 		if labels == nil || labels[key] != val {
 			return false
 		}
 	}
+	for _, expr := range g.MatchExpressions {
+		if !expr.Satisfied(labels) {
+			return false
+		}
+	}
 	return true
 }
 
+// selects returns true if the Group's selectors or selector expressions use
+// the label key. A nil Group selects no labels.
+func (g *Group) selects(key string) bool {
+	if g == nil {
+		return false
+	}
+	if _, ok := g.Selector[key]; ok {
+		return true
+	}
+	for _, expr := range g.MatchExpressions {
+		if expr.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// FailedRequirement returns the first selector (in key order) or selector
+// expression which the given labels fail to satisfy, or the empty string if
+// the Group matches the labels.
+func (g *Group) FailedRequirement(labels map[string]string) string {
+	keys := make([]string, 0, len(g.Selector))
+	for key := range g.Selector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if labels == nil || labels[key] != g.Selector[key] {
+			return key + "=" + g.Selector[key]
+		}
+	}
+	for _, expr := range g.MatchExpressions {
+		if !expr.Satisfied(labels) {
+			return expr.Expression()
+		}
+	}
+	return ""
+}
+
 // Normalize normalizes Group selectors according to reserved selector rules
 // which require "mac" addresses to be valid, normalized MAC addresses.
+// Selector expressions are validated and normalized likewise.
 func (g *Group) Normalize() error {
+	for _, expr := range g.MatchExpressions {
+		if err := expr.AssertValid(); err != nil {
+			return err
+		}
+		if err := expr.Normalize(); err != nil {
+			return err
+		}
+	}
 	for key, val := range g.Selector {
 		switch strings.ToLower(key) {
 		case "mac":
@@ -80,11 +141,19 @@ func (g *Group) AssertValid() error {
 	if g.Profile == "" {
 		return ErrProfileRequired
 	}
+	if g.Priority < 0 {
+		return ErrInvalidPriority
+	}
+	for _, expr := range g.MatchExpressions {
+		if err := expr.AssertValid(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // selectorString returns Group selectors as a string of sorted key value
-// pairs for comparisons.
+// pairs, followed by sorted selector expressions, for comparisons.
 func (g *Group) selectorString() string {
 	reqs := make([]string, 0, len(g.Selector))
 	for key, value := range g.Selector {
@@ -92,7 +161,31 @@ func (g *Group) selectorString() string {
 	}
 	// sort by "key=value" pairs for a deterministic ordering
 	sort.StringSlice(reqs).Sort()
-	return strings.Join(reqs, ",")
+	exprs := make([]string, 0, len(g.MatchExpressions))
+	for _, expr := range g.MatchExpressions {
+		exprs = append(exprs, expr.Expression())
+	}
+	sort.StringSlice(exprs).Sort()
+	return strings.Join(append(reqs, exprs...), ",")
+}
+
+// numReqs returns the total number of selectors and selector expressions.
+func (g *Group) numReqs() int {
+	return len(g.Selector) + len(g.MatchExpressions)
+}
+
+// operatorCounts returns the number of selectors (exact matches) followed by
+// the number of selector expressions with each operator, from most to least
+// specific.
+func (g *Group) operatorCounts() []int {
+	counts := make([]int, len(operatorRanks)+1)
+	counts[0] = len(g.Selector)
+	for _, expr := range g.MatchExpressions {
+		if rank, ok := operatorRanks[expr.Operator]; ok {
+			counts[rank+1]++
+		}
+	}
+	return counts
 }
 
 // ToRichGroup converts a Group into a RichGroup suitable for writing and
@@ -106,18 +199,26 @@ func (g *Group) ToRichGroup() (*RichGroup, error) {
 		}
 	}
 	return &RichGroup{
-		Id:       g.Id,
-		Name:     g.Name,
-		Profile:  g.Profile,
-		Selector: g.Selector,
-		Metadata: metadata,
+		Id:               g.Id,
+		Name:             g.Name,
+		Profile:          g.Profile,
+		Selector:         g.Selector,
+		MatchExpressions: g.MatchExpressions,
+		Priority:         g.Priority,
+		Metadata:         metadata,
 	}, nil
 }
 
 // ByReqs defines a collection of Group structs which have a deterministic
-// sorted order by increasing number of Requirements, then by sorted key/value
-// strings. For example, a Group with Requirements {a:b, c:d} should be ordered
-// after one with {a:b} and before one with {a:d, c:d}.
+// sorted order by increasing Priority, then by increasing number of
+// Requirements, then by sorted key/value strings. For example, a Group with
+// Requirements {a:b, c:d} should be ordered after one with {a:b} and before one
+// with {a:d, c:d}, unless their Priority differs.
+//
+// Selectors and selector expressions each count as a Requirement. Among Groups
+// with the same number of Requirements, those with more exact selectors are
+// ordered later, followed by those with more In, Matches, NotIn, Exists, and
+// DoesNotExist expressions, in that order of precedence.
 type ByReqs []*Group
 
 func (groups ByReqs) Len() int {
@@ -129,10 +230,19 @@ func (groups ByReqs) Swap(i, j int) {
 }
 
 func (groups ByReqs) Less(i, j int) bool {
-	if len(groups[i].Selector) == len(groups[j].Selector) {
-		return groups[i].selectorString() < groups[j].selectorString()
+	if groups[i].Priority != groups[j].Priority {
+		return groups[i].Priority < groups[j].Priority
+	}
+	if groups[i].numReqs() != groups[j].numReqs() {
+		return groups[i].numReqs() < groups[j].numReqs()
+	}
+	ci, cj := groups[i].operatorCounts(), groups[j].operatorCounts()
+	for k := range ci {
+		if ci[k] != cj[k] {
+			return ci[k] < cj[k]
+		}
 	}
-	return len(groups[i].Selector) < len(groups[j].Selector)
+	return groups[i].selectorString() < groups[j].selectorString()
 }
 
 // RichGroup is a user provided Group definition.
@@ -145,6 +255,10 @@ type RichGroup struct {
 	Profile string `json:"profile,omitempty"`
 	// Selectors to match machines
 	Selector map[string]string `json:"selector,omitempty"`
+	// Selector expressions to match machines
+	MatchExpressions []*SelectorRequirement `json:"match_expressions,omitempty"`
+	// Priority of the Group during selection (higher first)
+	Priority int32 `json:"priority,omitempty"`
 	// Metadata
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -161,10 +275,12 @@ func (rg *RichGroup) ToGroup() (*Group, error) {
 		}
 	}
 	return &Group{
-		Id:       rg.Id,
-		Name:     rg.Name,
-		Profile:  rg.Profile,
-		Selector: rg.Selector,
-		Metadata: metadata,
+		Id:               rg.Id,
+		Name:             rg.Name,
+		Profile:          rg.Profile,
+		Selector:         rg.Selector,
+		MatchExpressions: rg.MatchExpressions,
+		Priority:         rg.Priority,
+		Metadata:         metadata,
 	}, nil
 }
@@ -32,6 +32,12 @@ func TestGroupParse(t *testing.T) {
 		group *Group
 	}{
 		{`{"id":"node1","name":"test group","profile":"g1h2i3j4","selector":{"uuid":"a1b2c3d4","mac":"52:da:00:89:d8:10"},"metadata":{"some-key":"some-val"}}`, testGroup},
+		{`{"id":"node1","profile":"g1h2i3j4","priority":5}`, &Group{Id: "node1", Profile: "g1h2i3j4", Priority: 5}},
+		{`{"id":"node1","profile":"g1h2i3j4","match_expressions":[{"key":"mac","operator":"In","values":["52-DA-00-89-D8-10"]}]}`, &Group{
+			Id:               "node1",
+			Profile:          "g1h2i3j4",
+			MatchExpressions: []*SelectorRequirement{{Key: "mac", Operator: OpIn, Values: []string{"52:da:00:89:d8:10"}}},
+		}},
 	}
 	for _, c := range cases {
 		group, _ := ParseGroup([]byte(c.json))
@@ -49,6 +55,7 @@ func TestGroupCopy(t *testing.T) {
 	assert.Equal(t, testGroup.Profile, copy.Profile)
 	assert.Equal(t, testGroup.Selector, copy.Selector)
 	assert.Equal(t, testGroup.Metadata, copy.Metadata)
+	assert.Equal(t, testGroup.Priority, copy.Priority)
 
 	copy.Id = "a-copy"
 	copy.Selector["region"] = "us-west"
@@ -76,6 +83,58 @@ func TestGroupMatches(t *testing.T) {
 	}
 }
 
+func TestGroupMatches_Expressions(t *testing.T) {
+	group := &Group{
+		Selector: map[string]string{"region": "a"},
+		MatchExpressions: []*SelectorRequirement{
+			{Key: "hostname", Operator: OpMatches, Values: []string{"^worker-[0-9]+$"}},
+			{Key: "serial", Operator: OpNotIn, Values: []string{"X"}},
+		},
+	}
+	cases := []struct {
+		labels   map[string]string
+		expected bool
+	}{
+		{map[string]string{"region": "a", "hostname": "worker-1"}, true},
+		{map[string]string{"region": "a", "hostname": "worker-1", "serial": "Y"}, true},
+		{map[string]string{"region": "a", "hostname": "worker-1", "serial": "X"}, false},
+		{map[string]string{"region": "b", "hostname": "worker-1"}, false},
+		{map[string]string{"region": "a", "hostname": "controller-1"}, false},
+		{nil, false},
+	}
+	// assert that Group selectors and all selector expressions must be
+	// satisfied for a match
+	for _, c := range cases {
+		assert.Equal(t, c.expected, group.Matches(c.labels))
+	}
+}
+
+func TestGroupFailedRequirement(t *testing.T) {
+	group := &Group{
+		Selector: map[string]string{"region": "a", "zone": "z"},
+		MatchExpressions: []*SelectorRequirement{
+			{Key: "serial", Operator: OpExists},
+		},
+	}
+	cases := []struct {
+		labels   map[string]string
+		expected string
+	}{
+		{map[string]string{"region": "a", "zone": "z", "serial": "X"}, ""},
+		{map[string]string{"region": "b", "zone": "y", "serial": "X"}, "region=a"},
+		{map[string]string{"region": "a", "serial": "X"}, "zone=z"},
+		{map[string]string{"region": "a", "zone": "z"}, "serial Exists"},
+		{nil, "region=a"},
+	}
+	// assert that:
+	// - the first unsatisfied selector or expression is reported
+	// - a requirement is reported if and only if the Group doesn't match
+	for _, c := range cases {
+		assert.Equal(t, c.expected, group.FailedRequirement(c.labels))
+		assert.Equal(t, group.Matches(c.labels), group.FailedRequirement(c.labels) == "")
+	}
+}
+
 func TestNormalize(t *testing.T) {
 	expectedInvalidMAC := &net.AddrError{Err: "invalid MAC address", Addr: "not-a-mac"}
 	cases := []struct {
@@ -103,6 +162,23 @@ func TestNormalize(t *testing.T) {
 	}
 }
 
+func TestNormalize_Expressions(t *testing.T) {
+	group := &Group{
+		Id: "id",
+		MatchExpressions: []*SelectorRequirement{
+			{Key: "mac", Operator: OpIn, Values: []string{"52-DA-00-89-D8-10", "52:da:00:89:d8:11"}},
+		},
+	}
+	// assert that:
+	// - MAC addresses in selector expressions are normalized
+	// - invalid selector expressions cause a normalization error
+	assert.Nil(t, group.Normalize())
+	assert.Equal(t, []string{"52:da:00:89:d8:10", "52:da:00:89:d8:11"}, group.MatchExpressions[0].Values)
+
+	group.MatchExpressions = []*SelectorRequirement{{Key: "hostname", Operator: OpMatches, Values: []string{"("}}}
+	assert.Error(t, group.Normalize())
+}
+
 func TestGroupValidate(t *testing.T) {
 	cases := []struct {
 		group *Group
@@ -112,6 +188,10 @@ func TestGroupValidate(t *testing.T) {
 		{testGroupWithoutProfile, false},
 		{&Group{Id: "node1"}, false},
 		{&Group{}, false},
+		{&Group{Id: "node1", Profile: "k8s-controller", MatchExpressions: []*SelectorRequirement{{Key: "serial", Operator: OpExists}}}, true},
+		{&Group{Id: "node1", Profile: "k8s-controller", MatchExpressions: []*SelectorRequirement{{Key: "serial", Operator: "Equals"}}}, false},
+		{&Group{Id: "node1", Profile: "k8s-controller", Priority: 10}, true},
+		{&Group{Id: "node1", Profile: "k8s-controller", Priority: -1}, false},
 	}
 	for _, c := range cases {
 		valid := c.group.AssertValid() == nil
@@ -128,6 +208,13 @@ func TestSelectorString(t *testing.T) {
 	}
 	expected := "a=b,c=d"
 	assert.Equal(t, expected, group.selectorString())
+
+	group.MatchExpressions = []*SelectorRequirement{
+		{Key: "serial", Operator: OpExists},
+		{Key: "mac", Operator: OpIn, Values: []string{"x", "y"}},
+	}
+	expected = "a=b,c=d,mac In (x,y),serial Exists"
+	assert.Equal(t, expected, group.selectorString())
 }
 
 func TestGroupSort(t *testing.T) {
@@ -168,3 +255,78 @@ func TestGroupSort(t *testing.T) {
 		assert.Equal(t, c.expected, c.input)
 	}
 }
+
+func TestGroupSort_Expressions(t *testing.T) {
+	region := &Group{
+		Name:     "group with one selector",
+		Selector: map[string]string{"region": "a"},
+	}
+	macIn := &Group{
+		Name:             "group with an In expression",
+		MatchExpressions: []*SelectorRequirement{{Key: "mac", Operator: OpIn, Values: []string{"a", "b"}}},
+	}
+	hostnameMatches := &Group{
+		Name:             "group with a Matches expression",
+		MatchExpressions: []*SelectorRequirement{{Key: "hostname", Operator: OpMatches, Values: []string{"^worker"}}},
+	}
+	serialExists := &Group{
+		Name:             "group with an Exists expression",
+		MatchExpressions: []*SelectorRequirement{{Key: "serial", Operator: OpExists}},
+	}
+	regionMACIn := &Group{
+		Name:             "group with a selector and an In expression",
+		Selector:         map[string]string{"region": "a"},
+		MatchExpressions: []*SelectorRequirement{{Key: "mac", Operator: OpIn, Values: []string{"a", "b"}}},
+	}
+	twoSelectors := &Group{
+		Name:     "group with two selectors",
+		Selector: map[string]string{"region": "a", "zone": "z"},
+	}
+	cases := []struct {
+		input    []*Group
+		expected []*Group
+	}{
+		{[]*Group{twoSelectors, regionMACIn, region, serialExists, hostnameMatches, macIn}, []*Group{serialExists, hostnameMatches, macIn, region, regionMACIn, twoSelectors}},
+		{[]*Group{macIn, hostnameMatches, serialExists, region, regionMACIn, twoSelectors}, []*Group{serialExists, hostnameMatches, macIn, region, regionMACIn, twoSelectors}},
+	}
+	// assert that
+	// - Groups are sorted by increasing number of selectors and expressions
+	// - when equal in number, exact selectors sort after expressions
+	// - expressions sort by operator specificity (In, Matches, NotIn, Exists,
+	// DoesNotExist)
+	for _, c := range cases {
+		sort.Sort(ByReqs(c.input))
+		assert.Equal(t, c.expected, c.input)
+	}
+}
+
+func TestGroupSort_Priority(t *testing.T) {
+	catchAll := &Group{
+		Name:     "catch-all group with priority",
+		Selector: map[string]string{"region": "a"},
+		Priority: 10,
+	}
+	stale := &Group{
+		Name:     "group with two selectors",
+		Selector: map[string]string{"region": "a", "zone": "z"},
+	}
+	lower := &Group{
+		Name:     "group with lower priority",
+		Selector: map[string]string{"region": "a", "zone": "z", "os": "installed"},
+		Priority: 5,
+	}
+	cases := []struct {
+		input    []*Group
+		expected []*Group
+	}{
+		{[]*Group{catchAll, lower, stale}, []*Group{stale, lower, catchAll}},
+		{[]*Group{stale, catchAll, lower}, []*Group{stale, lower, catchAll}},
+	}
+	// assert that
+	// - Groups are sorted by increasing Priority before number of selectors
+	// - Groups without a Priority sort first (are selected last)
+	for _, c := range cases {
+		sort.Sort(ByReqs(c.input))
+		assert.Equal(t, c.expected, c.input)
+	}
+}
@@ -0,0 +1,91 @@
+package storagepb
+
+import (
+	"sort"
+)
+
+// indexedSelectors are selector keys which identify individual machines and
+// are indexed for fast lookups, in order of preference.
+var indexedSelectors = []string{"uuid", "mac"}
+
+// GroupIndex is an immutable collection of Groups in selection order, indexed
+// by machine identifying selectors (uuid, mac).
+type GroupIndex struct {
+	// Groups in order of evaluation
+	groups []*Group
+	// positions of Groups by indexed selector key and value
+	byLabel map[string]map[string][]int
+	// positions of Groups without an indexed selector
+	unindexed []int
+}
+
+// NewGroupIndex returns a GroupIndex of the given Groups. Groups are evaluated
+// in sorted order from most selectors to least (reverse ByReqs order).
+func NewGroupIndex(groups []*Group) *GroupIndex {
+	sorted := make([]*Group, len(groups))
+	copy(sorted, groups)
+	sort.Sort(sort.Reverse(ByReqs(sorted)))
+
+	index := &GroupIndex{
+		groups:  sorted,
+		byLabel: make(map[string]map[string][]int),
+	}
+	for i, group := range sorted {
+		key, value, ok := group.indexedSelector()
+		if !ok {
+			index.unindexed = append(index.unindexed, i)
+			continue
+		}
+		if index.byLabel[key] == nil {
+			index.byLabel[key] = make(map[string][]int)
+		}
+		index.byLabel[key][value] = append(index.byLabel[key][value], i)
+	}
+	return index
+}
+
+// Groups returns the indexed Groups in order of evaluation.
+func (idx *GroupIndex) Groups() []*Group {
+	return idx.groups
+}
+
+// Select returns the first Group, in order of evaluation, whose selectors
+// match the given labels or nil if no Group matches.
+func (idx *GroupIndex) Select(labels map[string]string) *Group {
+	// candidate position lists are each in ascending order
+	candidates := [][]int{idx.unindexed}
+	for _, key := range indexedSelectors {
+		if value, ok := labels[key]; ok {
+			candidates = append(candidates, idx.byLabel[key][value])
+		}
+	}
+	// merge candidates in order of evaluation
+	next := make([]int, len(candidates))
+	for {
+		best := -1
+		for c, positions := range candidates {
+			if next[c] < len(positions) && (best == -1 || positions[next[c]] < candidates[best][next[best]]) {
+				best = c
+			}
+		}
+		if best == -1 {
+			return nil
+		}
+		group := idx.groups[candidates[best][next[best]]]
+		next[best]++
+		if group.Matches(labels) {
+			return group
+		}
+	}
+}
+
+// indexedSelector returns the preferred indexed selector of the Group, if it
+// has one. Groups may only match machines with the same selector label value.
+func (g *Group) indexedSelector() (key, value string, ok bool) {
+	for _, key := range indexedSelectors {
+		if value, ok := g.Selector[key]; ok {
+			return key, value, true
+		}
+	}
+	return "", "", false
+}
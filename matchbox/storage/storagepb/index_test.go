@@ -0,0 +1,60 @@
+package storagepb
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupIndexSelect(t *testing.T) {
+	catchAll := &Group{Id: "catch-all"}
+	region := &Group{Id: "region", Selector: map[string]string{"region": "a"}}
+	byMAC := &Group{Id: "mac", Selector: map[string]string{"mac": "52:da:00:89:d8:10"}}
+	byUUID := &Group{Id: "uuid", Selector: map[string]string{"uuid": "a1b2c3d4"}}
+	byUUIDRegion := &Group{Id: "uuid-region", Selector: map[string]string{"uuid": "a1b2c3d4", "region": "b"}}
+	byUUIDMAC := &Group{Id: "uuid-mac", Selector: map[string]string{"uuid": "e5f6g7h8", "mac": "52:da:00:89:d8:10"}}
+	groups := []*Group{catchAll, region, byMAC, byUUID, byUUIDRegion, byUUIDMAC}
+
+	cases := []struct {
+		labels   map[string]string
+		expected *Group
+	}{
+		{map[string]string{"uuid": "a1b2c3d4", "region": "b"}, byUUIDRegion},
+		{map[string]string{"uuid": "a1b2c3d4", "region": "a"}, byUUID},
+		{map[string]string{"uuid": "e5f6g7h8", "mac": "52:da:00:89:d8:10"}, byUUIDMAC},
+		{map[string]string{"uuid": "e5f6g7h8", "mac": "52:da:00:89:d8:10", "region": "a"}, byUUIDMAC},
+		{map[string]string{"uuid": "ffffffff", "mac": "52:da:00:89:d8:10"}, byMAC},
+		{map[string]string{"mac": "52:da:00:89:d8:11", "region": "a"}, region},
+		{map[string]string{"uuid": "ffffffff"}, catchAll},
+		{nil, catchAll},
+	}
+	// assert that:
+	// - indexed selection matches a linear scan in reverse ByReqs order
+	// - Groups with and without indexed selectors are both considered
+	index := NewGroupIndex(groups)
+	sorted := make([]*Group, len(groups))
+	copy(sorted, groups)
+	sort.Sort(sort.Reverse(ByReqs(sorted)))
+	for _, c := range cases {
+		assert.Equal(t, c.expected, index.Select(c.labels))
+		assert.Equal(t, linearSelect(sorted, c.labels), index.Select(c.labels))
+	}
+	assert.Equal(t, sorted, index.Groups())
+}
+
+func TestGroupIndexSelect_NoMatch(t *testing.T) {
+	index := NewGroupIndex([]*Group{testGroup})
+	assert.Nil(t, index.Select(map[string]string{"uuid": "a1b2c3d4"}))
+	assert.Nil(t, NewGroupIndex(nil).Select(map[string]string{"uuid": "a1b2c3d4"}))
+}
+
+// linearSelect returns the first Group matching the labels.
+func linearSelect(groups []*Group, labels map[string]string) *Group {
+	for _, group := range groups {
+		if group.Matches(labels) {
+			return group
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package storagepb
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInstanceIdRequired = errors.New("Instance requires a uuid, mac, serial, or hostname label")
+)
+
+// instanceIdLabels are labels which identify a machine, in order of
+// preference.
+var instanceIdLabels = []string{"uuid", "mac", "serial", "hostname"}
+
+// InstanceId returns the machine readable Id for the machine with the given
+// labels. The uuid, mac, serial, or hostname label is used, in that order.
+// Returns an empty string if the labels don't identify a machine.
+func InstanceId(labels map[string]string) string {
+	for _, name := range instanceIdLabels {
+		for key, val := range labels {
+			if strings.ToLower(key) == name && val != "" {
+				return val
+			}
+		}
+	}
+	return ""
+}
+
+// InstanceLabels returns the labels which identify a machine or which the
+// Group (if any) selects on, dropping other labels (e.g. query params).
+func InstanceLabels(labels map[string]string, group *Group) map[string]string {
+	kept := make(map[string]string)
+	for key, val := range labels {
+		if isInstanceIdLabel(key) || group.selects(key) {
+			kept[key] = val
+		}
+	}
+	return kept
+}
+
+// isInstanceIdLabel returns true if the label key identifies a machine.
+func isInstanceIdLabel(key string) bool {
+	for _, name := range instanceIdLabels {
+		if strings.ToLower(key) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AssertValid validates an Instance. Returns nil if there are no validation
+// errors.
+func (i *Instance) AssertValid() error {
+	if i.Id == "" {
+		return ErrInstanceIdRequired
+	}
+	return nil
+}
+
+func (i *Instance) Copy() *Instance {
+	labels := make(map[string]string)
+	for k, v := range i.Labels {
+		labels[k] = v
+	}
+	return &Instance{
+		Id:        i.Id,
+		Labels:    labels,
+		Group:     i.Group,
+		Profile:   i.Profile,
+		Endpoint:  i.Endpoint,
+		FirstSeen: i.FirstSeen,
+		LastSeen:  i.LastSeen,
+	}
+}
@@ -0,0 +1,103 @@
+package storagepb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testInstance = &Instance{
+		Id: "a1b2c3d4",
+		Labels: map[string]string{
+			"uuid": "a1b2c3d4",
+			"mac":  "52:da:00:89:d8:10",
+		},
+		Group:     "node1",
+		Profile:   "g1h2i3j4",
+		Endpoint:  "/ipxe",
+		FirstSeen: 1500000000,
+		LastSeen:  1500000060,
+	}
+)
+
+func TestInstanceId(t *testing.T) {
+	cases := []struct {
+		labels   map[string]string
+		expected string
+	}{
+		{map[string]string{"uuid": "a1b2c3d4", "mac": "52:da:00:89:d8:10"}, "a1b2c3d4"},
+		{map[string]string{"mac": "52:da:00:89:d8:10", "hostname": "node1"}, "52:da:00:89:d8:10"},
+		{map[string]string{"UUID": "a1b2c3d4"}, "a1b2c3d4"},
+		{map[string]string{"uuid": "", "serial": "XYZ123"}, "XYZ123"},
+		{map[string]string{"hostname": "node1"}, "node1"},
+		{map[string]string{"region": "us-west"}, ""},
+		{nil, ""},
+	}
+	// assert that:
+	// - uuid, mac, serial, and hostname labels identify machines, in that order
+	// - labels without an identifying key yield no Id
+	for _, c := range cases {
+		assert.Equal(t, c.expected, InstanceId(c.labels))
+	}
+}
+
+func TestInstanceLabels(t *testing.T) {
+	labels := map[string]string{
+		"UUID":   "a1b2c3d4",
+		"mac":    "52:da:00:89:d8:10",
+		"region": "us-west",
+		"arch":   "arm64",
+		"token":  "secret",
+	}
+	group := &Group{
+		Selector: map[string]string{"region": "us-west"},
+		MatchExpressions: []*SelectorRequirement{
+			{Key: "arch", Operator: "In", Values: []string{"arm64"}},
+		},
+	}
+	// assert that:
+	// - identifying labels and labels the Group selects on are kept
+	// - other labels are dropped
+	// - without a Group, only identifying labels are kept
+	expected := map[string]string{
+		"UUID":   "a1b2c3d4",
+		"mac":    "52:da:00:89:d8:10",
+		"region": "us-west",
+		"arch":   "arm64",
+	}
+	assert.Equal(t, expected, InstanceLabels(labels, group))
+	expected = map[string]string{
+		"UUID": "a1b2c3d4",
+		"mac":  "52:da:00:89:d8:10",
+	}
+	assert.Equal(t, expected, InstanceLabels(labels, nil))
+}
+
+func TestInstanceValidate(t *testing.T) {
+	cases := []struct {
+		instance *Instance
+		valid    bool
+	}{
+		{testInstance, true},
+		{&Instance{Labels: map[string]string{"region": "us-west"}}, false},
+		{&Instance{}, false},
+	}
+	for _, c := range cases {
+		valid := c.instance.AssertValid() == nil
+		assert.Equal(t, c.valid, valid)
+	}
+}
+
+func TestInstanceCopy(t *testing.T) {
+	copy := testInstance.Copy()
+	// assert that:
+	// - Instance fields are copied
+	// - mutation of the copy does not affect the original
+	assert.Equal(t, testInstance, copy)
+
+	copy.LastSeen = 1500000120
+	copy.Labels["hostname"] = "node1"
+	assert.NotEqual(t, testInstance.LastSeen, copy.LastSeen)
+	assert.NotEqual(t, testInstance.Labels, copy.Labels)
+}
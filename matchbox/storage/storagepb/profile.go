@@ -6,7 +6,8 @@ import (
 )
 
 var (
-	ErrIdRequired = errors.New("Id is required")
+	ErrIdRequired   = errors.New("Id is required")
+	ErrProfileCycle = errors.New("Profile inherits from itself")
 )
 
 // ParseProfile parses bytes into a Profile.
@@ -23,28 +24,95 @@ func (p *Profile) AssertValid() error {
 	if p.Id == "" {
 		return ErrIdRequired
 	}
+	if p.Parent == p.Id {
+		return ErrProfileCycle
+	}
 	return nil
 }
 
 func (p *Profile) Copy() *Profile {
 	return &Profile{
-		Id:         p.Id,
-		Name:       p.Name,
-		IgnitionId: p.IgnitionId,
-		CloudId:    p.CloudId,
-		GenericId:  p.GenericId,
-		Boot:       p.Boot.Copy(),
+		Id:              p.Id,
+		Name:            p.Name,
+		IgnitionId:      p.IgnitionId,
+		CloudId:         p.CloudId,
+		GenericId:       p.GenericId,
+		Boot:            p.Boot.Copy(),
+		Parent:          p.Parent,
+		ResourceVersion: p.ResourceVersion,
 	}
 }
 
+// Inherit returns a copy of the Profile with unset fields inherited from the
+// given parent Profile. Boot args replace the parent's args if set and boot
+// append args are appended. The returned Profile has no parent.
+func (p *Profile) Inherit(parent *Profile) *Profile {
+	resolved := p.Copy()
+	resolved.Parent = ""
+	if resolved.IgnitionId == "" {
+		resolved.IgnitionId = parent.IgnitionId
+	}
+	if resolved.CloudId == "" {
+		resolved.CloudId = parent.CloudId
+	}
+	if resolved.GenericId == "" {
+		resolved.GenericId = parent.GenericId
+	}
+	if p.Boot == nil && parent.Boot == nil {
+		return resolved
+	}
+
+	boot := parent.Boot.Copy()
+	if boot == nil {
+		boot = &NetBoot{}
+	}
+	if p.Boot != nil {
+		if p.Boot.Kernel != "" {
+			boot.Kernel = p.Boot.Kernel
+		}
+		if len(p.Boot.Initrd) > 0 {
+			boot.Initrd = resolved.Boot.Initrd
+		}
+		if len(p.Boot.Args) > 0 {
+			boot.Args = resolved.Boot.Args
+		}
+		boot.Args = append(boot.Args, p.Boot.AppendArgs...)
+	}
+	boot.AppendArgs = nil
+	resolved.Boot = boot
+	return resolved
+}
+
+// Resolve returns the Profile with boot append args appended to its args, for
+// a Profile without a parent. Profiles without append args are returned as is.
+func (p *Profile) Resolve() *Profile {
+	if p.Boot == nil || len(p.Boot.AppendArgs) == 0 {
+		return p
+	}
+	resolved := p.Copy()
+	resolved.Boot.Args = append(resolved.Boot.Args, p.Boot.AppendArgs...)
+	resolved.Boot.AppendArgs = nil
+	return resolved
+}
+
+// Copy returns a copy of the NetBoot.
 func (b *NetBoot) Copy() *NetBoot {
+	if b == nil {
+		return nil
+	}
 	initrd := make([]string, len(b.Initrd))
 	copy(initrd, b.Initrd)
 	args := make([]string, len(b.Args))
 	copy(args, b.Args)
+	var appendArgs []string
+	if len(b.AppendArgs) > 0 {
+		appendArgs = make([]string, len(b.AppendArgs))
+		copy(appendArgs, b.AppendArgs)
+	}
 	return &NetBoot{
-		Kernel: b.Kernel,
-		Initrd: initrd,
-		Args:   args,
+		Kernel:     b.Kernel,
+		Initrd:     initrd,
+		Args:       args,
+		AppendArgs: appendArgs,
 	}
 }
@@ -35,6 +35,8 @@ func TestProfileValidate(t *testing.T) {
 		{testProfile, true},
 		{&Profile{Id: "a1b2c3d4"}, true},
 		{&Profile{}, false},
+		{&Profile{Id: "a1b2c3d4", Parent: "base"}, true},
+		{&Profile{Id: "a1b2c3d4", Parent: "a1b2c3d4"}, false},
 	}
 	for _, c := range cases {
 		valid := c.profile.AssertValid() == nil
@@ -62,6 +64,7 @@ func TestProfileCopy(t *testing.T) {
 	assert.Equal(t, profile.IgnitionId, clone.IgnitionId)
 	assert.Equal(t, profile.CloudId, clone.CloudId)
 	assert.Equal(t, profile.Boot, clone.Boot)
+	assert.Equal(t, profile.Parent, clone.Parent)
 
 	// mutate the NetBoot struct
 	clone.Boot.Initrd = []string{"/image/initrd_b"}
@@ -92,3 +95,106 @@ func TestNetBootCopy(t *testing.T) {
 	assert.NotEqual(t, boot.Initrd, clone.Initrd)
 	assert.NotEqual(t, boot.Args, clone.Args)
 }
+
+func TestProfileResolve(t *testing.T) {
+	profile := &Profile{
+		Id: "base",
+		Boot: &NetBoot{
+			Kernel:     "/image/kernel",
+			Args:       []string{"console=tty0"},
+			AppendArgs: []string{"console=ttyS0"},
+		},
+	}
+	// assert that:
+	// - append args are appended to the args of a Profile without a parent
+	// - Profiles without append args are returned as is
+	resolved := profile.Resolve()
+	assert.Equal(t, []string{"console=tty0", "console=ttyS0"}, resolved.Boot.Args)
+	assert.Empty(t, resolved.Boot.AppendArgs)
+	assert.Equal(t, []string{"console=ttyS0"}, profile.Boot.AppendArgs)
+	plain := &Profile{Id: "plain"}
+	assert.Equal(t, plain, plain.Resolve())
+}
+
+func TestProfileInherit(t *testing.T) {
+	parent := &Profile{
+		Id:         "base",
+		Name:       "base profile",
+		IgnitionId: "base.yaml",
+		GenericId:  "base.tmpl",
+		Boot: &NetBoot{
+			Kernel: "/image/kernel",
+			Initrd: []string{"/image/initrd_a"},
+			Args:   []string{"a=b", "console=tty0"},
+		},
+	}
+	cases := []struct {
+		profile  *Profile
+		expected *Profile
+	}{
+		// inherit everything but the id and name
+		{
+			&Profile{Id: "child", Parent: "base"},
+			&Profile{
+				Id:         "child",
+				IgnitionId: "base.yaml",
+				GenericId:  "base.tmpl",
+				Boot: &NetBoot{
+					Kernel: "/image/kernel",
+					Initrd: []string{"/image/initrd_a"},
+					Args:   []string{"a=b", "console=tty0"},
+				},
+			},
+		},
+		// override the ignition id, kernel, and args
+		{
+			&Profile{
+				Id:         "child",
+				Parent:     "base",
+				IgnitionId: "child.yaml",
+				Boot: &NetBoot{
+					Kernel: "/image/other-kernel",
+					Args:   []string{"c=d"},
+				},
+			},
+			&Profile{
+				Id:         "child",
+				IgnitionId: "child.yaml",
+				GenericId:  "base.tmpl",
+				Boot: &NetBoot{
+					Kernel: "/image/other-kernel",
+					Initrd: []string{"/image/initrd_a"},
+					Args:   []string{"c=d"},
+				},
+			},
+		},
+		// append args
+		{
+			&Profile{
+				Id:     "child",
+				Parent: "base",
+				Boot:   &NetBoot{AppendArgs: []string{"console=ttyS0"}},
+			},
+			&Profile{
+				Id:         "child",
+				IgnitionId: "base.yaml",
+				GenericId:  "base.tmpl",
+				Boot: &NetBoot{
+					Kernel: "/image/kernel",
+					Initrd: []string{"/image/initrd_a"},
+					Args:   []string{"a=b", "console=tty0", "console=ttyS0"},
+				},
+			},
+		},
+	}
+	// assert that:
+	// - unset fields are inherited from the parent
+	// - set fields override the parent's fields
+	// - append args are appended to the inherited args
+	// - the parent is not modified
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.profile.Inherit(parent))
+	}
+	assert.Equal(t, []string{"a=b", "console=tty0"}, parent.Boot.Args)
+	assert.Equal(t, &Profile{Id: "child"}, (&Profile{Id: "child", Parent: "base"}).Inherit(&Profile{Id: "base"}))
+}
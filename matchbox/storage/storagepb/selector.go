@@ -0,0 +1,149 @@
+package storagepb
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Selector expression operators
+const (
+	// OpIn requires the label value to be one of the values.
+	OpIn = "In"
+	// OpNotIn requires the label to be absent or its value to be none of the values.
+	OpNotIn = "NotIn"
+	// OpExists requires the label to be present.
+	OpExists = "Exists"
+	// OpDoesNotExist requires the label to be absent.
+	OpDoesNotExist = "DoesNotExist"
+	// OpMatches requires the label value to match one of the regular expressions.
+	OpMatches = "Matches"
+)
+
+// operatorRanks orders operators from most to least specific.
+var operatorRanks = map[string]int{
+	OpIn:           0,
+	OpMatches:      1,
+	OpNotIn:        2,
+	OpExists:       3,
+	OpDoesNotExist: 4,
+}
+
+// compiled caches compiled Matches expressions by pattern.
+var compiled sync.Map
+
+// Satisfied returns true if the given labels satisfy the requirement.
+func (r *SelectorRequirement) Satisfied(labels map[string]string) bool {
+	value, present := labels[r.Key]
+	switch r.Operator {
+	case OpIn:
+		return present && contains(r.Values, value)
+	case OpNotIn:
+		return !present || !contains(r.Values, value)
+	case OpExists:
+		return present
+	case OpDoesNotExist:
+		return !present
+	case OpMatches:
+		if !present {
+			return false
+		}
+		for _, pattern := range r.Values {
+			if re, err := compile(pattern); err == nil && re.MatchString(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AssertValid validates a SelectorRequirement. Returns nil if there are no
+// validation errors.
+func (r *SelectorRequirement) AssertValid() error {
+	if r.Key == "" {
+		return fmt.Errorf("selector expression requires a key")
+	}
+	switch r.Operator {
+	case OpIn, OpNotIn:
+		if len(r.Values) == 0 {
+			return fmt.Errorf("selector expression %q requires values", r.Expression())
+		}
+	case OpMatches:
+		if len(r.Values) == 0 {
+			return fmt.Errorf("selector expression %q requires values", r.Expression())
+		}
+		for _, pattern := range r.Values {
+			if _, err := compile(pattern); err != nil {
+				return fmt.Errorf("selector expression %q: %v", r.Expression(), err)
+			}
+		}
+	case OpExists, OpDoesNotExist:
+		if len(r.Values) != 0 {
+			return fmt.Errorf("selector expression %q must not have values", r.Expression())
+		}
+	default:
+		return fmt.Errorf("selector expression has unknown operator %q", r.Operator)
+	}
+	return nil
+}
+
+// Normalize normalizes requirement values according to reserved selector
+// rules which require "mac" addresses to be valid, normalized MAC addresses.
+func (r *SelectorRequirement) Normalize() error {
+	if strings.ToLower(r.Key) != "mac" || (r.Operator != OpIn && r.Operator != OpNotIn) {
+		return nil
+	}
+	for i, val := range r.Values {
+		macAddr, err := net.ParseMAC(val)
+		if err != nil {
+			return err
+		}
+		r.Values[i] = macAddr.String()
+	}
+	return nil
+}
+
+// Expression returns the requirement as a human readable expression (e.g.
+// "mac In (52:54:00:a1:9c:ae,52:54:00:b2:2f:86)").
+func (r *SelectorRequirement) Expression() string {
+	switch r.Operator {
+	case OpExists, OpDoesNotExist:
+		return r.Key + " " + r.Operator
+	}
+	return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+}
+
+// Copy returns a copy of the SelectorRequirement.
+func (r *SelectorRequirement) Copy() *SelectorRequirement {
+	values := make([]string, len(r.Values))
+	copy(values, r.Values)
+	return &SelectorRequirement{
+		Key:      r.Key,
+		Operator: r.Operator,
+		Values:   values,
+	}
+}
+
+// compile returns the compiled regular expression for a pattern.
+func compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := compiled.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	compiled.Store(pattern, re)
+	return re, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
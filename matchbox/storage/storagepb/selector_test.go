@@ -0,0 +1,91 @@
+package storagepb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectorRequirementSatisfied(t *testing.T) {
+	labels := map[string]string{
+		"mac":      "52:da:00:89:d8:10",
+		"hostname": "worker-12",
+		"serial":   "X",
+	}
+	cases := []struct {
+		req      *SelectorRequirement
+		expected bool
+	}{
+		{&SelectorRequirement{Key: "mac", Operator: OpIn, Values: []string{"52:da:00:89:d8:10", "52:da:00:89:d8:11"}}, true},
+		{&SelectorRequirement{Key: "mac", Operator: OpIn, Values: []string{"52:da:00:89:d8:11"}}, false},
+		{&SelectorRequirement{Key: "region", Operator: OpIn, Values: []string{"a"}}, false},
+		{&SelectorRequirement{Key: "serial", Operator: OpNotIn, Values: []string{"X"}}, false},
+		{&SelectorRequirement{Key: "serial", Operator: OpNotIn, Values: []string{"Y"}}, true},
+		{&SelectorRequirement{Key: "region", Operator: OpNotIn, Values: []string{"a"}}, true},
+		{&SelectorRequirement{Key: "serial", Operator: OpExists}, true},
+		{&SelectorRequirement{Key: "region", Operator: OpExists}, false},
+		{&SelectorRequirement{Key: "serial", Operator: OpDoesNotExist}, false},
+		{&SelectorRequirement{Key: "region", Operator: OpDoesNotExist}, true},
+		{&SelectorRequirement{Key: "hostname", Operator: OpMatches, Values: []string{"^worker-[0-9]+$"}}, true},
+		{&SelectorRequirement{Key: "hostname", Operator: OpMatches, Values: []string{"^controller-[0-9]+$", "^worker-1"}}, true},
+		{&SelectorRequirement{Key: "hostname", Operator: OpMatches, Values: []string{"^controller-[0-9]+$"}}, false},
+		{&SelectorRequirement{Key: "region", Operator: OpMatches, Values: []string{".*"}}, false},
+		{&SelectorRequirement{Key: "serial", Operator: "Unknown", Values: []string{"X"}}, false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.req.Satisfied(labels), c.req.Expression())
+	}
+}
+
+func TestSelectorRequirementValidate(t *testing.T) {
+	cases := []struct {
+		req   *SelectorRequirement
+		valid bool
+	}{
+		{&SelectorRequirement{Key: "mac", Operator: OpIn, Values: []string{"52:da:00:89:d8:10"}}, true},
+		{&SelectorRequirement{Key: "serial", Operator: OpNotIn, Values: []string{"X"}}, true},
+		{&SelectorRequirement{Key: "serial", Operator: OpExists}, true},
+		{&SelectorRequirement{Key: "serial", Operator: OpDoesNotExist}, true},
+		{&SelectorRequirement{Key: "hostname", Operator: OpMatches, Values: []string{"^worker-[0-9]+$"}}, true},
+		{&SelectorRequirement{Operator: OpExists}, false},
+		{&SelectorRequirement{Key: "mac", Operator: OpIn}, false},
+		{&SelectorRequirement{Key: "serial", Operator: OpNotIn}, false},
+		{&SelectorRequirement{Key: "serial", Operator: OpExists, Values: []string{"X"}}, false},
+		{&SelectorRequirement{Key: "hostname", Operator: OpMatches}, false},
+		{&SelectorRequirement{Key: "hostname", Operator: OpMatches, Values: []string{"worker-[0-9"}}, false},
+		{&SelectorRequirement{Key: "serial", Operator: "Equals", Values: []string{"X"}}, false},
+	}
+	for _, c := range cases {
+		valid := c.req.AssertValid() == nil
+		assert.Equal(t, c.valid, valid, c.req.Expression())
+	}
+}
+
+func TestSelectorRequirementNormalize(t *testing.T) {
+	req := &SelectorRequirement{Key: "mac", Operator: OpIn, Values: []string{"52-DA-00-89-D8-10"}}
+	// assert that:
+	// - MAC address values are normalized
+	// - invalid MAC address values cause a normalization error
+	// - values of Matches expressions are left as is
+	assert.Nil(t, req.Normalize())
+	assert.Equal(t, []string{"52:da:00:89:d8:10"}, req.Values)
+	req = &SelectorRequirement{Key: "mac", Operator: OpNotIn, Values: []string{"not-a-mac"}}
+	assert.Error(t, req.Normalize())
+	req = &SelectorRequirement{Key: "mac", Operator: OpMatches, Values: []string{"^52:da:.*"}}
+	assert.Nil(t, req.Normalize())
+	assert.Equal(t, []string{"^52:da:.*"}, req.Values)
+}
+
+func TestSelectorRequirementExpression(t *testing.T) {
+	cases := []struct {
+		req      *SelectorRequirement
+		expected string
+	}{
+		{&SelectorRequirement{Key: "mac", Operator: OpIn, Values: []string{"a", "b"}}, "mac In (a,b)"},
+		{&SelectorRequirement{Key: "hostname", Operator: OpMatches, Values: []string{"^worker-[0-9]+$"}}, "hostname Matches (^worker-[0-9]+$)"},
+		{&SelectorRequirement{Key: "serial", Operator: OpExists}, "serial Exists"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.req.Expression())
+	}
+}
@@ -31,7 +31,16 @@ type Group struct {
 	// Selectors to match machines
 	Selector map[string]string `protobuf:"bytes,4,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// JSON encoded metadata
-	Metadata             []byte   `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Metadata []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Selector expressions to match machines
+	MatchExpressions []*SelectorRequirement `protobuf:"bytes,6,rep,name=match_expressions,json=matchExpressions,proto3" json:"match_expressions,omitempty"`
+	// Priority of the Group during selection (higher first)
+	Priority int32 `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	// version of the stored resource, a hash of its stored contents. It changes
+	// whenever the contents change, but not when identical contents are
+	// written, and recurs if earlier contents are restored. Puts may set the
+	// version they expect, or "none" if the resource must not exist.
+	ResourceVersion      string   `protobuf:"bytes,8,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -97,6 +106,87 @@ func (m *Group) GetMetadata() []byte {
 	return nil
 }
 
+func (m *Group) GetMatchExpressions() []*SelectorRequirement {
+	if m != nil {
+		return m.MatchExpressions
+	}
+	return nil
+}
+
+func (m *Group) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Group) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+// SelectorRequirement is a selector expression which relates a label key to
+// a set of values with an operator.
+type SelectorRequirement struct {
+	// label key
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// In, NotIn, Exists, DoesNotExist, or Matches
+	Operator string `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	// values (In, NotIn) or regular expressions (Matches)
+	Values               []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SelectorRequirement) Reset()         { *m = SelectorRequirement{} }
+func (m *SelectorRequirement) String() string { return proto.CompactTextString(m) }
+func (*SelectorRequirement) ProtoMessage()    {}
+func (*SelectorRequirement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ed97bf224c67cd0, []int{1}
+}
+
+func (m *SelectorRequirement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelectorRequirement.Unmarshal(m, b)
+}
+func (m *SelectorRequirement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelectorRequirement.Marshal(b, m, deterministic)
+}
+func (m *SelectorRequirement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelectorRequirement.Merge(m, src)
+}
+func (m *SelectorRequirement) XXX_Size() int {
+	return xxx_messageInfo_SelectorRequirement.Size(m)
+}
+func (m *SelectorRequirement) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelectorRequirement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelectorRequirement proto.InternalMessageInfo
+
+func (m *SelectorRequirement) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SelectorRequirement) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *SelectorRequirement) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 // Profile defines the boot and provisioning behavior of a group of machines.
 type Profile struct {
 	// profile id
@@ -110,7 +200,14 @@ type Profile struct {
 	// support network boot / PXE
 	Boot *NetBoot `protobuf:"bytes,5,opt,name=boot,proto3" json:"boot,omitempty"`
 	// generic config id
-	GenericId            string   `protobuf:"bytes,6,opt,name=generic_id,json=genericId,proto3" json:"generic_id,omitempty"`
+	GenericId string `protobuf:"bytes,6,opt,name=generic_id,json=genericId,proto3" json:"generic_id,omitempty"`
+	// parent profile id to inherit from
+	Parent string `protobuf:"bytes,7,opt,name=parent,proto3" json:"parent,omitempty"`
+	// version of the stored resource, a hash of its stored contents. It changes
+	// whenever the contents change, but not when identical contents are
+	// written, and recurs if earlier contents are restored. Puts may set the
+	// version they expect, or "none" if the resource must not exist.
+	ResourceVersion      string   `protobuf:"bytes,8,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -120,7 +217,7 @@ func (m *Profile) Reset()         { *m = Profile{} }
 func (m *Profile) String() string { return proto.CompactTextString(m) }
 func (*Profile) ProtoMessage()    {}
 func (*Profile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ed97bf224c67cd0, []int{1}
+	return fileDescriptor_5ed97bf224c67cd0, []int{2}
 }
 
 func (m *Profile) XXX_Unmarshal(b []byte) error {
@@ -183,6 +280,20 @@ func (m *Profile) GetGenericId() string {
 	return ""
 }
 
+func (m *Profile) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *Profile) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
 // NetBoot describes network or PXE boot settings for a machine.
 type NetBoot struct {
 	// the URL of the kernel image
@@ -190,7 +301,9 @@ type NetBoot struct {
 	// the init RAM filesystem URLs
 	Initrd []string `protobuf:"bytes,2,rep,name=initrd,proto3" json:"initrd,omitempty"`
 	// kernel args
-	Args                 []string `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
+	Args []string `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
+	// kernel args appended to the (inherited) args
+	AppendArgs           []string `protobuf:"bytes,5,rep,name=append_args,json=appendArgs,proto3" json:"append_args,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -200,7 +313,7 @@ func (m *NetBoot) Reset()         { *m = NetBoot{} }
 func (m *NetBoot) String() string { return proto.CompactTextString(m) }
 func (*NetBoot) ProtoMessage()    {}
 func (*NetBoot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ed97bf224c67cd0, []int{2}
+	return fileDescriptor_5ed97bf224c67cd0, []int{3}
 }
 
 func (m *NetBoot) XXX_Unmarshal(b []byte) error {
@@ -242,11 +355,690 @@ func (m *NetBoot) GetArgs() []string {
 	return nil
 }
 
+func (m *NetBoot) GetAppendArgs() []string {
+	if m != nil {
+		return m.AppendArgs
+	}
+	return nil
+}
+
+// TemplateInfo describes a stored template.
+type TemplateInfo struct {
+	// template name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// size in bytes
+	Size int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// modification time (Unix seconds), zero if unknown
+	Modified             int64    `protobuf:"varint,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TemplateInfo) Reset()         { *m = TemplateInfo{} }
+func (m *TemplateInfo) String() string { return proto.CompactTextString(m) }
+func (*TemplateInfo) ProtoMessage()    {}
+func (*TemplateInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ed97bf224c67cd0, []int{4}
+}
+
+func (m *TemplateInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TemplateInfo.Unmarshal(m, b)
+}
+func (m *TemplateInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TemplateInfo.Marshal(b, m, deterministic)
+}
+func (m *TemplateInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TemplateInfo.Merge(m, src)
+}
+func (m *TemplateInfo) XXX_Size() int {
+	return xxx_messageInfo_TemplateInfo.Size(m)
+}
+func (m *TemplateInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TemplateInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TemplateInfo proto.InternalMessageInfo
+
+func (m *TemplateInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TemplateInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *TemplateInfo) GetModified() int64 {
+	if m != nil {
+		return m.Modified
+	}
+	return 0
+}
+
+// InvalidResource is a stored resource which can't be parsed.
+type InvalidResource struct {
+	// resource kind ("Group" or "Profile")
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// resource id
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// why the resource can't be parsed
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvalidResource) Reset()         { *m = InvalidResource{} }
+func (m *InvalidResource) String() string { return proto.CompactTextString(m) }
+func (*InvalidResource) ProtoMessage()    {}
+func (*InvalidResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ed97bf224c67cd0, []int{5}
+}
+
+func (m *InvalidResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvalidResource.Unmarshal(m, b)
+}
+func (m *InvalidResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvalidResource.Marshal(b, m, deterministic)
+}
+func (m *InvalidResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvalidResource.Merge(m, src)
+}
+func (m *InvalidResource) XXX_Size() int {
+	return xxx_messageInfo_InvalidResource.Size(m)
+}
+func (m *InvalidResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvalidResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvalidResource proto.InternalMessageInfo
+
+func (m *InvalidResource) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *InvalidResource) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *InvalidResource) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// Instance is a machine observed requesting boot or provisioning configs.
+type Instance struct {
+	// machine readable Id (uuid, mac, serial, or hostname)
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// labels sent by the machine
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// matched Group id
+	Group string `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	// matched Profile id
+	Profile string `protobuf:"bytes,4,opt,name=profile,proto3" json:"profile,omitempty"`
+	// last requested endpoint
+	Endpoint string `protobuf:"bytes,5,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// first seen time (Unix seconds)
+	FirstSeen int64 `protobuf:"varint,6,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	// last seen time (Unix seconds)
+	LastSeen             int64    `protobuf:"varint,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Instance) Reset()         { *m = Instance{} }
+func (m *Instance) String() string { return proto.CompactTextString(m) }
+func (*Instance) ProtoMessage()    {}
+func (*Instance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ed97bf224c67cd0, []int{6}
+}
+
+func (m *Instance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Instance.Unmarshal(m, b)
+}
+func (m *Instance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Instance.Marshal(b, m, deterministic)
+}
+func (m *Instance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Instance.Merge(m, src)
+}
+func (m *Instance) XXX_Size() int {
+	return xxx_messageInfo_Instance.Size(m)
+}
+func (m *Instance) XXX_DiscardUnknown() {
+	xxx_messageInfo_Instance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Instance proto.InternalMessageInfo
+
+func (m *Instance) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Instance) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Instance) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *Instance) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *Instance) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *Instance) GetFirstSeen() int64 {
+	if m != nil {
+		return m.FirstSeen
+	}
+	return 0
+}
+
+func (m *Instance) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+// Template is the contents of a named template.
+type Template struct {
+	// template name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// template contents
+	Contents []byte `protobuf:"bytes,2,opt,name=contents,proto3" json:"contents,omitempty"`
+	// expected version of the stored template, if any, or "none" if the
+	// template must not exist
+	ResourceVersion      string   `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Template) Reset()         { *m = Template{} }
+func (m *Template) String() string { return proto.CompactTextString(m) }
+func (*Template) ProtoMessage()    {}
+func (*Template) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ed97bf224c67cd0, []int{7}
+}
+
+func (m *Template) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Template.Unmarshal(m, b)
+}
+func (m *Template) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Template.Marshal(b, m, deterministic)
+}
+func (m *Template) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Template.Merge(m, src)
+}
+func (m *Template) XXX_Size() int {
+	return xxx_messageInfo_Template.Size(m)
+}
+func (m *Template) XXX_DiscardUnknown() {
+	xxx_messageInfo_Template.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Template proto.InternalMessageInfo
+
+func (m *Template) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Template) GetContents() []byte {
+	if m != nil {
+		return m.Contents
+	}
+	return nil
+}
+
+func (m *Template) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+// Deletion identifies a resource to delete.
+type Deletion struct {
+	// resource kind ("Group", "Profile", "Ignition", "Generic", "Cloud", or
+	// "Partial")
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Group or Profile id or template name
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// expected version of the stored resource, if any
+	ResourceVersion      string   `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Deletion) Reset()         { *m = Deletion{} }
+func (m *Deletion) String() string { return proto.CompactTextString(m) }
+func (*Deletion) ProtoMessage()    {}
+func (*Deletion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ed97bf224c67cd0, []int{8}
+}
+
+func (m *Deletion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Deletion.Unmarshal(m, b)
+}
+func (m *Deletion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Deletion.Marshal(b, m, deterministic)
+}
+func (m *Deletion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Deletion.Merge(m, src)
+}
+func (m *Deletion) XXX_Size() int {
+	return xxx_messageInfo_Deletion.Size(m)
+}
+func (m *Deletion) XXX_DiscardUnknown() {
+	xxx_messageInfo_Deletion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Deletion proto.InternalMessageInfo
+
+func (m *Deletion) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Deletion) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Deletion) GetResourceVersion() string {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return ""
+}
+
+// Batch is a set of resources to write and delete together.
+type Batch struct {
+	Groups               []*Group    `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	Profiles             []*Profile  `protobuf:"bytes,2,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	Ignition             []*Template `protobuf:"bytes,3,rep,name=ignition,proto3" json:"ignition,omitempty"`
+	Generic              []*Template `protobuf:"bytes,4,rep,name=generic,proto3" json:"generic,omitempty"`
+	Cloud                []*Template `protobuf:"bytes,5,rep,name=cloud,proto3" json:"cloud,omitempty"`
+	Partials             []*Template `protobuf:"bytes,6,rep,name=partials,proto3" json:"partials,omitempty"`
+	Deletes              []*Deletion `protobuf:"bytes,7,rep,name=deletes,proto3" json:"deletes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Batch) Reset()         { *m = Batch{} }
+func (m *Batch) String() string { return proto.CompactTextString(m) }
+func (*Batch) ProtoMessage()    {}
+func (*Batch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ed97bf224c67cd0, []int{9}
+}
+
+func (m *Batch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Batch.Unmarshal(m, b)
+}
+func (m *Batch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Batch.Marshal(b, m, deterministic)
+}
+func (m *Batch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Batch.Merge(m, src)
+}
+func (m *Batch) XXX_Size() int {
+	return xxx_messageInfo_Batch.Size(m)
+}
+func (m *Batch) XXX_DiscardUnknown() {
+	xxx_messageInfo_Batch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Batch proto.InternalMessageInfo
+
+func (m *Batch) GetGroups() []*Group {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+func (m *Batch) GetProfiles() []*Profile {
+	if m != nil {
+		return m.Profiles
+	}
+	return nil
+}
+
+func (m *Batch) GetIgnition() []*Template {
+	if m != nil {
+		return m.Ignition
+	}
+	return nil
+}
+
+func (m *Batch) GetGeneric() []*Template {
+	if m != nil {
+		return m.Generic
+	}
+	return nil
+}
+
+func (m *Batch) GetCloud() []*Template {
+	if m != nil {
+		return m.Cloud
+	}
+	return nil
+}
+
+func (m *Batch) GetPartials() []*Template {
+	if m != nil {
+		return m.Partials
+	}
+	return nil
+}
+
+func (m *Batch) GetDeletes() []*Deletion {
+	if m != nil {
+		return m.Deletes
+	}
+	return nil
+}
+
+// Revision is a revision of a Group, Profile, or template.
+type Revision struct {
+	// revision number, which increases with each write of the resource
+	Revision int64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	// write time (Unix seconds)
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// identity of the client which wrote the revision (e.g. certificate CN)
+	Author string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	// whether the revision deleted the resource
+	Deleted bool `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// Group, for Group revisions
+	Group *Group `protobuf:"bytes,5,opt,name=group,proto3" json:"group,omitempty"`
+	// Profile, for Profile revisions
+	Profile *Profile `protobuf:"bytes,6,opt,name=profile,proto3" json:"profile,omitempty"`
+	// template contents, for template revisions
+	Contents             []byte   `protobuf:"bytes,7,opt,name=contents,proto3" json:"contents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Revision) Reset()         { *m = Revision{} }
+func (m *Revision) String() string { return proto.CompactTextString(m) }
+func (*Revision) ProtoMessage()    {}
+func (*Revision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ed97bf224c67cd0, []int{10}
+}
+
+func (m *Revision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Revision.Unmarshal(m, b)
+}
+func (m *Revision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Revision.Marshal(b, m, deterministic)
+}
+func (m *Revision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Revision.Merge(m, src)
+}
+func (m *Revision) XXX_Size() int {
+	return xxx_messageInfo_Revision.Size(m)
+}
+func (m *Revision) XXX_DiscardUnknown() {
+	xxx_messageInfo_Revision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Revision proto.InternalMessageInfo
+
+func (m *Revision) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *Revision) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Revision) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Revision) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+func (m *Revision) GetGroup() *Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+func (m *Revision) GetProfile() *Profile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+func (m *Revision) GetContents() []byte {
+	if m != nil {
+		return m.Contents
+	}
+	return nil
+}
+
+// AuditEntry records a gRPC call which writes or deletes resources.
+type AuditEntry struct {
+	// call time (Unix seconds)
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// subject of the client certificate (e.g. CN=ci,O=example)
+	Subject string `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	// full gRPC method name (e.g. /rpcpb.Ignition/IgnitionPut)
+	Method string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	// gRPC status code of the call (e.g. OK)
+	Code string `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	// error message, if the call failed
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// resources the call wrote or deleted
+	Changes              []*AuditChange `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AuditEntry) Reset()         { *m = AuditEntry{} }
+func (m *AuditEntry) String() string { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()    {}
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ed97bf224c67cd0, []int{11}
+}
+
+func (m *AuditEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEntry.Unmarshal(m, b)
+}
+func (m *AuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEntry.Marshal(b, m, deterministic)
+}
+func (m *AuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEntry.Merge(m, src)
+}
+func (m *AuditEntry) XXX_Size() int {
+	return xxx_messageInfo_AuditEntry.Size(m)
+}
+func (m *AuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEntry proto.InternalMessageInfo
+
+func (m *AuditEntry) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *AuditEntry) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetChanges() []*AuditChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+// AuditChange is a change to a resource recorded by an AuditEntry.
+type AuditChange struct {
+	// resource kind (Group, Profile, Ignition, Generic, Cloud, or Partial)
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Group or Profile id or template name
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// unified diff of the resource, empty if the call failed
+	Diff                 string   `protobuf:"bytes,3,opt,name=diff,proto3" json:"diff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditChange) Reset()         { *m = AuditChange{} }
+func (m *AuditChange) String() string { return proto.CompactTextString(m) }
+func (*AuditChange) ProtoMessage()    {}
+func (*AuditChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ed97bf224c67cd0, []int{12}
+}
+
+func (m *AuditChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditChange.Unmarshal(m, b)
+}
+func (m *AuditChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditChange.Marshal(b, m, deterministic)
+}
+func (m *AuditChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditChange.Merge(m, src)
+}
+func (m *AuditChange) XXX_Size() int {
+	return xxx_messageInfo_AuditChange.Size(m)
+}
+func (m *AuditChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditChange proto.InternalMessageInfo
+
+func (m *AuditChange) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *AuditChange) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AuditChange) GetDiff() string {
+	if m != nil {
+		return m.Diff
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Group)(nil), "storagepb.Group")
 	proto.RegisterMapType((map[string]string)(nil), "storagepb.Group.SelectorEntry")
+	proto.RegisterType((*SelectorRequirement)(nil), "storagepb.SelectorRequirement")
 	proto.RegisterType((*Profile)(nil), "storagepb.Profile")
 	proto.RegisterType((*NetBoot)(nil), "storagepb.NetBoot")
+	proto.RegisterType((*TemplateInfo)(nil), "storagepb.TemplateInfo")
+	proto.RegisterType((*InvalidResource)(nil), "storagepb.InvalidResource")
+	proto.RegisterType((*Instance)(nil), "storagepb.Instance")
+	proto.RegisterMapType((map[string]string)(nil), "storagepb.Instance.LabelsEntry")
+	proto.RegisterType((*Template)(nil), "storagepb.Template")
+	proto.RegisterType((*Deletion)(nil), "storagepb.Deletion")
+	proto.RegisterType((*Batch)(nil), "storagepb.Batch")
+	proto.RegisterType((*Revision)(nil), "storagepb.Revision")
+	proto.RegisterType((*AuditEntry)(nil), "storagepb.AuditEntry")
+	proto.RegisterType((*AuditChange)(nil), "storagepb.AuditChange")
 }
 
 func init() {
@@ -254,29 +1046,68 @@ func init() {
 }
 
 var fileDescriptor_5ed97bf224c67cd0 = []byte{
-	// 374 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x4d, 0x8b, 0xdb, 0x30,
-	0x10, 0xc5, 0x1f, 0x89, 0xed, 0x49, 0x5b, 0x82, 0x28, 0xc5, 0x0d, 0xb4, 0x35, 0x39, 0x14, 0x9f,
-	0x1c, 0x48, 0x0f, 0x6d, 0xd3, 0x5b, 0xa0, 0x94, 0xf4, 0x50, 0x8a, 0x7b, 0x2b, 0x85, 0x22, 0x5b,
-	0x5a, 0x47, 0xc4, 0xd6, 0x18, 0x59, 0x5e, 0x36, 0xff, 0x6a, 0x7f, 0xcf, 0xfe, 0x9a, 0xc5, 0x8a,
-	0x6c, 0x76, 0x0f, 0x0b, 0x7b, 0xf2, 0x7b, 0x6f, 0xc6, 0x9a, 0xf7, 0x86, 0x81, 0xb4, 0xa1, 0xba,
-	0x3c, 0x16, 0x78, 0xb3, 0xe9, 0x34, 0x2a, 0x5a, 0xf1, 0xf1, 0xdb, 0x16, 0x23, 0xca, 0x5a, 0x85,
-	0x1a, 0x49, 0x34, 0x15, 0xd6, 0x77, 0x0e, 0xcc, 0x7e, 0x28, 0xec, 0x5b, 0xf2, 0x0a, 0x5c, 0xc1,
-	0x62, 0x27, 0x71, 0xd2, 0x28, 0x77, 0x05, 0x23, 0x04, 0x7c, 0x49, 0x1b, 0x1e, 0xbb, 0x46, 0x31,
-	0x98, 0xc4, 0x10, 0xb4, 0x0a, 0xaf, 0x44, 0xcd, 0x63, 0xcf, 0xc8, 0x23, 0x25, 0x3b, 0x08, 0x3b,
-	0x5e, 0xf3, 0x52, 0xa3, 0x8a, 0xfd, 0xc4, 0x4b, 0x17, 0xdb, 0xf7, 0xd9, 0x34, 0x25, 0x33, 0x13,
-	0xb2, 0x3f, 0xb6, 0xe1, 0xbb, 0xd4, 0xea, 0x9c, 0x4f, 0xfd, 0x64, 0x05, 0x61, 0xc3, 0x35, 0x65,
-	0x54, 0xd3, 0x78, 0x96, 0x38, 0xe9, 0x8b, 0x7c, 0xe2, 0xab, 0x6f, 0xf0, 0xf2, 0xd1, 0x6f, 0x64,
-	0x09, 0xde, 0x89, 0x9f, 0xad, 0xcf, 0x01, 0x92, 0xd7, 0x30, 0xbb, 0xa6, 0x75, 0x3f, 0x3a, 0xbd,
-	0x90, 0x9d, 0xfb, 0xc5, 0x59, 0xdf, 0x3a, 0x10, 0xfc, 0xb6, 0x06, 0x9f, 0x13, 0xef, 0x03, 0x2c,
-	0x44, 0x25, 0x85, 0x16, 0x28, 0xff, 0x0b, 0x66, 0x23, 0xc2, 0x28, 0x1d, 0x18, 0x79, 0x0b, 0x61,
-	0x59, 0x63, 0xcf, 0x86, 0xaa, 0x7f, 0x59, 0x80, 0xe1, 0x07, 0x46, 0x3e, 0x82, 0x5f, 0x20, 0x6a,
-	0x13, 0x60, 0xb1, 0x25, 0x0f, 0xc2, 0xff, 0xe2, 0x7a, 0x8f, 0xa8, 0x73, 0x53, 0x27, 0xef, 0x00,
-	0x2a, 0x2e, 0xb9, 0x12, 0xe5, 0xf0, 0xc8, 0xdc, 0x3c, 0x12, 0x59, 0xe5, 0xc0, 0xd6, 0xff, 0x20,
-	0xb0, 0xfd, 0xe4, 0x0d, 0xcc, 0x4f, 0x5c, 0x49, 0x5e, 0x5b, 0xd7, 0x96, 0x0d, 0xba, 0x90, 0x42,
-	0x2b, 0x16, 0xbb, 0x89, 0x37, 0xe8, 0x17, 0x36, 0x24, 0xa2, 0xaa, 0xea, 0xcc, 0xfa, 0xa3, 0xdc,
-	0xe0, 0x9f, 0x7e, 0xe8, 0x2d, 0xfd, 0x3c, 0x28, 0x1b, 0x56, 0x0b, 0xc9, 0xf7, 0x5f, 0xff, 0x7e,
-	0xae, 0x84, 0x3e, 0xf6, 0x45, 0x56, 0x62, 0xb3, 0x69, 0xb1, 0xe3, 0x82, 0xa1, 0xdc, 0x4c, 0x87,
-	0xf3, 0xf4, 0x05, 0x15, 0x73, 0x73, 0x3a, 0x9f, 0xee, 0x03, 0x00, 0x00, 0xff, 0xff, 0x37, 0x8a,
-	0x89, 0x62, 0x66, 0x02, 0x00, 0x00,
+	// 996 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcd, 0x8e, 0x1b, 0x45,
+	0x10, 0xd6, 0x78, 0x6c, 0xcf, 0xb8, 0x1c, 0xc8, 0xd2, 0x89, 0xa2, 0xc1, 0xfc, 0xc4, 0xf2, 0x01,
+	0x39, 0x12, 0x78, 0xd1, 0x72, 0x08, 0x09, 0xa7, 0x2c, 0x44, 0xc8, 0x04, 0x21, 0xd4, 0x41, 0x1c,
+	0x40, 0x68, 0xd5, 0x9e, 0x29, 0x7b, 0x9b, 0x1d, 0x77, 0x0f, 0xdd, 0xed, 0x55, 0x16, 0x89, 0xd7,
+	0xe0, 0x59, 0x78, 0x08, 0x1e, 0x84, 0x13, 0x17, 0x5e, 0x00, 0xf5, 0xdf, 0xec, 0x6c, 0xd6, 0x2b,
+	0x65, 0x4f, 0xee, 0xaf, 0xaa, 0xba, 0xaa, 0xab, 0xbe, 0xaa, 0xf2, 0xc0, 0x7c, 0xcb, 0x4c, 0x79,
+	0xba, 0x92, 0xaf, 0x0e, 0xb5, 0x91, 0x8a, 0x6d, 0x30, 0xfe, 0x36, 0xab, 0x78, 0x5a, 0x34, 0x4a,
+	0x1a, 0x49, 0x46, 0xad, 0x62, 0xf6, 0x6f, 0x0f, 0x06, 0x5f, 0x2b, 0xb9, 0x6b, 0xc8, 0xdb, 0xd0,
+	0xe3, 0x55, 0x91, 0x4c, 0x93, 0xf9, 0x88, 0xf6, 0x78, 0x45, 0x08, 0xf4, 0x05, 0xdb, 0x62, 0xd1,
+	0x73, 0x12, 0x77, 0x26, 0x05, 0x64, 0x8d, 0x92, 0x6b, 0x5e, 0x63, 0x91, 0x3a, 0x71, 0x84, 0xe4,
+	0x29, 0xe4, 0x1a, 0x6b, 0x2c, 0x8d, 0x54, 0x45, 0x7f, 0x9a, 0xce, 0xc7, 0x47, 0x1f, 0x2e, 0xda,
+	0x28, 0x0b, 0x17, 0x61, 0xf1, 0x32, 0x18, 0x3c, 0x17, 0x46, 0x5d, 0xd0, 0xd6, 0x9e, 0x4c, 0x20,
+	0xdf, 0xa2, 0x61, 0x15, 0x33, 0xac, 0x18, 0x4c, 0x93, 0xf9, 0x1d, 0xda, 0x62, 0xf2, 0x02, 0xde,
+	0x71, 0x69, 0x9d, 0xe0, 0xab, 0x46, 0xa1, 0xd6, 0x5c, 0x0a, 0x5d, 0x0c, 0xaf, 0x05, 0x88, 0xae,
+	0x29, 0xfe, 0xb6, 0xe3, 0x0a, 0xb7, 0x28, 0x0c, 0x3d, 0x70, 0x17, 0x9f, 0x5f, 0xde, 0xb3, 0x81,
+	0x1a, 0xc5, 0xa5, 0xe2, 0xe6, 0xa2, 0xc8, 0xa6, 0xc9, 0x7c, 0x40, 0x5b, 0x4c, 0x1e, 0xc1, 0x81,
+	0x42, 0x2d, 0x77, 0xaa, 0xc4, 0x93, 0x73, 0x54, 0xf6, 0x42, 0x91, 0xbb, 0x1c, 0xef, 0x46, 0xf9,
+	0x8f, 0x5e, 0x3c, 0xf9, 0x02, 0xde, 0xba, 0x92, 0x0a, 0x39, 0x80, 0xf4, 0x0c, 0x2f, 0x42, 0xed,
+	0xec, 0x91, 0xdc, 0x87, 0xc1, 0x39, 0xab, 0x77, 0xb1, 0x7a, 0x1e, 0x3c, 0xed, 0x7d, 0x9e, 0xcc,
+	0x7e, 0x86, 0x7b, 0x7b, 0x1e, 0xbb, 0xc7, 0xc5, 0x04, 0x72, 0xd9, 0xa0, 0x62, 0xb6, 0xa2, 0xde,
+	0x4b, 0x8b, 0xc9, 0x03, 0x18, 0x3a, 0x8f, 0xba, 0x48, 0xa7, 0xe9, 0x7c, 0x44, 0x03, 0x9a, 0xfd,
+	0x97, 0x40, 0xf6, 0x7d, 0x60, 0xe4, 0x4d, 0xf8, 0x7c, 0x08, 0x63, 0xbe, 0x11, 0xdc, 0x70, 0x29,
+	0x4e, 0x78, 0x15, 0x38, 0x85, 0x28, 0x5a, 0x56, 0xe4, 0x5d, 0xc8, 0xcb, 0x5a, 0xee, 0x2a, 0xab,
+	0xed, 0x7b, 0xc6, 0x1d, 0x5e, 0x56, 0xe4, 0x23, 0xe8, 0xaf, 0xa4, 0x34, 0x8e, 0xb1, 0xf1, 0x11,
+	0xe9, 0x90, 0xf1, 0x1d, 0x9a, 0x63, 0x29, 0x0d, 0x75, 0x7a, 0xf2, 0x01, 0xc0, 0x06, 0x05, 0x2a,
+	0x5e, 0x5a, 0x27, 0x43, 0xe7, 0x64, 0x14, 0x24, 0xcb, 0xca, 0xa6, 0xd2, 0x30, 0x85, 0xc2, 0x38,
+	0x46, 0x46, 0x34, 0xa0, 0x5b, 0xf0, 0x31, 0xfb, 0x03, 0xb2, 0x10, 0xd2, 0x7a, 0x3b, 0x43, 0x25,
+	0xb0, 0x0e, 0x89, 0x07, 0x64, 0xe5, 0x5c, 0x70, 0xa3, 0xaa, 0xa2, 0xe7, 0x0b, 0xe6, 0x91, 0x2d,
+	0x0a, 0x53, 0x1b, 0xed, 0x5a, 0x76, 0x44, 0xdd, 0xd9, 0x16, 0x85, 0x35, 0x0d, 0x8a, 0xea, 0xc4,
+	0xa9, 0x06, 0x4e, 0x05, 0x5e, 0xf4, 0x4c, 0x6d, 0xf4, 0x37, 0xfd, 0x3c, 0x3d, 0xe8, 0xd3, 0xac,
+	0xdc, 0x56, 0x35, 0x17, 0x38, 0xa3, 0x70, 0xe7, 0x07, 0xdc, 0x36, 0x35, 0x33, 0xb8, 0x14, 0x6b,
+	0xd9, 0x16, 0x3a, 0xe9, 0x14, 0x9a, 0x40, 0x5f, 0xf3, 0xdf, 0x7d, 0xf1, 0x53, 0xea, 0xce, 0xae,
+	0xed, 0x65, 0xc5, 0xd7, 0x1c, 0x7d, 0xe5, 0x53, 0xda, 0xe2, 0xd9, 0x0b, 0xb8, 0xbb, 0x14, 0xe7,
+	0xac, 0xe6, 0x15, 0x0d, 0xc9, 0x5a, 0x17, 0x67, 0x5c, 0x44, 0x46, 0xdd, 0x39, 0x70, 0xdc, 0x6b,
+	0x39, 0xbe, 0x0f, 0x03, 0x54, 0x4a, 0xaa, 0xc0, 0xa4, 0x07, 0xb3, 0x3f, 0x7b, 0x90, 0x2f, 0x85,
+	0x36, 0x4c, 0x94, 0xd7, 0xdb, 0xe2, 0x31, 0x0c, 0x6b, 0xb6, 0xc2, 0x5a, 0xbb, 0xca, 0x8c, 0x8f,
+	0x1e, 0x76, 0x88, 0x8c, 0x97, 0x16, 0xdf, 0x3a, 0x0b, 0x3f, 0xb7, 0xc1, 0xdc, 0xc6, 0xda, 0xd8,
+	0xb1, 0x8e, 0xb1, 0x1c, 0xe8, 0x6e, 0x88, 0xfe, 0xd5, 0x0d, 0x31, 0x81, 0x1c, 0x45, 0xd5, 0x48,
+	0x2e, 0x7c, 0xcf, 0x8c, 0x68, 0x8b, 0x6d, 0x8f, 0xac, 0xb9, 0xd2, 0xe6, 0x44, 0x23, 0x0a, 0xd7,
+	0x23, 0x29, 0x1d, 0x39, 0xc9, 0x4b, 0x44, 0x41, 0xde, 0x83, 0x51, 0xcd, 0xa2, 0x36, 0xf3, 0xa5,
+	0xaa, 0x99, 0x57, 0x4e, 0x9e, 0xc0, 0xb8, 0xf3, 0xbc, 0x5b, 0xcd, 0x22, 0x42, 0x1e, 0x99, 0xdb,
+	0xcb, 0xda, 0x04, 0xf2, 0x52, 0x0a, 0x83, 0xc2, 0x68, 0x77, 0xf9, 0x0e, 0x6d, 0xf1, 0xde, 0xfe,
+	0x4c, 0xf7, 0xf7, 0xe7, 0x2f, 0x90, 0x7f, 0x85, 0x35, 0xda, 0x91, 0xda, 0xcb, 0xe2, 0xbe, 0xc9,
+	0xbc, 0x85, 0xfb, 0xbf, 0x7b, 0x30, 0x38, 0xb6, 0xab, 0x8e, 0xcc, 0x61, 0xe8, 0x58, 0xd0, 0x45,
+	0xe2, 0xb8, 0x3c, 0x78, 0x7d, 0x05, 0xd3, 0xa0, 0x27, 0x0b, 0xc8, 0x03, 0x2f, 0x91, 0xf7, 0xee,
+	0x00, 0x87, 0x15, 0x42, 0x5b, 0x1b, 0x72, 0x08, 0x79, 0xdc, 0x0a, 0x6e, 0xe5, 0x8c, 0x8f, 0xee,
+	0x75, 0xec, 0x63, 0x11, 0x69, 0x6b, 0x44, 0x3e, 0x81, 0x2c, 0xcc, 0x78, 0xd1, 0xbf, 0xd9, 0x3e,
+	0xda, 0x90, 0x47, 0x30, 0x70, 0x7b, 0xa5, 0x18, 0xdc, 0x6c, 0xec, 0x2d, 0xec, 0x53, 0x1a, 0xa6,
+	0x0c, 0x67, 0x75, 0xfc, 0x23, 0xd8, 0xff, 0x94, 0x68, 0x64, 0x9f, 0x52, 0xd9, 0xf2, 0xa3, 0x2e,
+	0xb2, 0x6b, 0xf6, 0x91, 0x18, 0x1a, 0x6d, 0x66, 0xff, 0x24, 0x90, 0x53, 0x3c, 0xe7, 0xb6, 0xb6,
+	0xb6, 0x03, 0x54, 0x38, 0x3b, 0xca, 0x52, 0xda, 0x62, 0xf2, 0x3e, 0x8c, 0x0c, 0xdf, 0xa2, 0x36,
+	0x6c, 0xdb, 0x84, 0xc1, 0xbe, 0x14, 0xd8, 0x8d, 0xc3, 0x76, 0xe6, 0xb4, 0x9d, 0xc5, 0x80, 0xec,
+	0x80, 0xf8, 0x48, 0x7e, 0xa1, 0xe6, 0x31, 0xb0, 0x5d, 0xa8, 0x61, 0xa0, 0xfc, 0x46, 0xbd, 0x4e,
+	0x9e, 0x57, 0x93, 0x8f, 0x2f, 0x47, 0x6c, 0x38, 0x4d, 0x6e, 0xa0, 0xae, 0x3b, 0x76, 0x6d, 0x0f,
+	0x67, 0x57, 0x7b, 0x78, 0xf6, 0x57, 0x02, 0xf0, 0x6c, 0x57, 0x71, 0xe3, 0x47, 0xe7, 0x4a, 0x42,
+	0xc9, 0xeb, 0x09, 0x15, 0x90, 0xe9, 0xdd, 0xea, 0x57, 0x2c, 0x4d, 0x68, 0xd4, 0x08, 0x6d, 0xaa,
+	0x5b, 0x34, 0xa7, 0x32, 0xfe, 0x81, 0x04, 0x64, 0xfb, 0xba, 0x94, 0x55, 0x5c, 0x04, 0xee, 0x7c,
+	0xb9, 0xa1, 0x06, 0x9d, 0x0d, 0x45, 0x3e, 0x85, 0xac, 0x3c, 0x65, 0x62, 0x83, 0x91, 0xd2, 0x07,
+	0x9d, 0x94, 0xdc, 0x0b, 0xbf, 0x74, 0x6a, 0x1a, 0xcd, 0x66, 0x4b, 0x18, 0x77, 0xe4, 0x6f, 0x3c,
+	0x56, 0x04, 0xfa, 0x15, 0x5f, 0xaf, 0xc3, 0x43, 0xdd, 0xf9, 0xf8, 0xc9, 0x4f, 0x8f, 0x37, 0xdc,
+	0x9c, 0xee, 0x56, 0x8b, 0x52, 0x6e, 0x0f, 0x1b, 0xa9, 0x91, 0x57, 0x52, 0x1c, 0xb6, 0x5f, 0x53,
+	0x37, 0x7f, 0x56, 0xad, 0x86, 0xee, 0x7b, 0xea, 0xb3, 0xff, 0x07, 0x00, 0xb2, 0x26, 0x3e, 0x98,
+	0x7b, 0x09, 0x00, 0x00,
 }
@@ -24,7 +24,7 @@ func (s *BrokenStore) GroupGet(id string) (*storagepb.Group, error) {
 }
 
 // GroupDelete returns an error.
-func (s *BrokenStore) GroupDelete(id string) error {
+func (s *BrokenStore) GroupDelete(id, version string) error {
 	return errIntentional
 }
 
@@ -44,7 +44,7 @@ func (s *BrokenStore) ProfileGet(id string) (*storagepb.Profile, error) {
 }
 
 // ProfileDelete returns an error.
-func (s *BrokenStore) ProfileDelete(id string) error {
+func (s *BrokenStore) ProfileDelete(id, version string) error {
 	return errIntentional
 }
 
@@ -54,7 +54,7 @@ func (s *BrokenStore) ProfileList() (profiles []*storagepb.Profile, err error) {
 }
 
 // IgnitionPut returns an error.
-func (s *BrokenStore) IgnitionPut(name string, config []byte) error {
+func (s *BrokenStore) IgnitionPut(name string, config []byte, version string) error {
 	return errIntentional
 }
 
@@ -64,12 +64,17 @@ func (s *BrokenStore) IgnitionGet(name string) (string, error) {
 }
 
 // IgnitionDelete returns an error.
-func (s *BrokenStore) IgnitionDelete(name string) error {
+func (s *BrokenStore) IgnitionDelete(name, version string) error {
 	return errIntentional
 }
 
+// IgnitionList returns an error.
+func (s *BrokenStore) IgnitionList() ([]*storagepb.TemplateInfo, error) {
+	return nil, errIntentional
+}
+
 // GenericPut returns an error.
-func (s *BrokenStore) GenericPut(name string, config []byte) error {
+func (s *BrokenStore) GenericPut(name string, config []byte, version string) error {
 	return errIntentional
 }
 
@@ -79,7 +84,17 @@ func (s *BrokenStore) GenericGet(name string) (string, error) {
 }
 
 // GenericDelete returns an error.
-func (s *BrokenStore) GenericDelete(name string) error {
+func (s *BrokenStore) GenericDelete(name, version string) error {
+	return errIntentional
+}
+
+// GenericList returns an error.
+func (s *BrokenStore) GenericList() ([]*storagepb.TemplateInfo, error) {
+	return nil, errIntentional
+}
+
+// CloudPut returns an error.
+func (s *BrokenStore) CloudPut(name string, config []byte, version string) error {
 	return errIntentional
 }
 
@@ -87,3 +102,38 @@ func (s *BrokenStore) GenericDelete(name string) error {
 func (s *BrokenStore) CloudGet(name string) (string, error) {
 	return "", errIntentional
 }
+
+// CloudDelete returns an error.
+func (s *BrokenStore) CloudDelete(name, version string) error {
+	return errIntentional
+}
+
+// CloudList returns an error.
+func (s *BrokenStore) CloudList() ([]*storagepb.TemplateInfo, error) {
+	return nil, errIntentional
+}
+
+// PartialPut returns an error.
+func (s *BrokenStore) PartialPut(name string, config []byte, version string) error {
+	return errIntentional
+}
+
+// PartialGet returns an error.
+func (s *BrokenStore) PartialGet(name string) (string, error) {
+	return "", errIntentional
+}
+
+// PartialDelete returns an error.
+func (s *BrokenStore) PartialDelete(name, version string) error {
+	return errIntentional
+}
+
+// PartialList returns an error.
+func (s *BrokenStore) PartialList() ([]string, error) {
+	return nil, errIntentional
+}
+
+// Apply returns an error.
+func (s *BrokenStore) Apply(batch *storagepb.Batch) error {
+	return errIntentional
+}
@@ -16,11 +16,11 @@ func (s *EmptyStore) GroupPut(group *storagepb.Group) error {
 
 // GroupGet returns a group not found error.
 func (s *EmptyStore) GroupGet(id string) (*storagepb.Group, error) {
-	return nil, fmt.Errorf("Group not found")
+	return nil, notFound("Group not found")
 }
 
 // GroupDelete returns a nil error (successful deletion).
-func (s *EmptyStore) GroupDelete(id string) error {
+func (s *EmptyStore) GroupDelete(id, version string) error {
 	return nil
 }
 
@@ -36,11 +36,11 @@ func (s *EmptyStore) ProfilePut(profile *storagepb.Profile) error {
 
 // ProfileGet returns a profile not found error.
 func (s *EmptyStore) ProfileGet(id string) (*storagepb.Profile, error) {
-	return nil, fmt.Errorf("Profile not found")
+	return nil, notFound("Profile not found")
 }
 
 // ProfileDelete returns a nil error (successful deletion).
-func (s *EmptyStore) ProfileDelete(id string) error {
+func (s *EmptyStore) ProfileDelete(id, version string) error {
 	return nil
 }
 
@@ -50,36 +50,86 @@ func (s *EmptyStore) ProfileList() (profiles []*storagepb.Profile, err error) {
 }
 
 // IgnitionPut returns an error writing any Ignition template.
-func (s *EmptyStore) IgnitionPut(name string, config []byte) error {
+func (s *EmptyStore) IgnitionPut(name string, config []byte, version string) error {
 	return fmt.Errorf("emptyStore does not accept Ignition templates")
 }
 
 // IgnitionGet get returns an Ignition template not found error.
 func (s *EmptyStore) IgnitionGet(name string) (string, error) {
-	return "", fmt.Errorf("no Ignition template %s", name)
+	return "", notFound("no Ignition template %s", name)
 }
 
 // IgnitionDelete returns a nil error (successful deletion).
-func (s *EmptyStore) IgnitionDelete(name string) error {
+func (s *EmptyStore) IgnitionDelete(name, version string) error {
 	return nil
 }
 
+// IgnitionList returns an empty list of Ignition templates.
+func (s *EmptyStore) IgnitionList() ([]*storagepb.TemplateInfo, error) {
+	return []*storagepb.TemplateInfo{}, nil
+}
+
 // GenericPut returns an error writing any Generic template.
-func (s *EmptyStore) GenericPut(name string, config []byte) error {
+func (s *EmptyStore) GenericPut(name string, config []byte, version string) error {
 	return fmt.Errorf("emptyStore does not accept Generic templates")
 }
 
 // GenericGet get returns an Generic template not found error.
 func (s *EmptyStore) GenericGet(name string) (string, error) {
-	return "", fmt.Errorf("no Generic template %s", name)
+	return "", notFound("no Generic template %s", name)
 }
 
 // GenericDelete returns a nil error (successful deletion).
-func (s *EmptyStore) GenericDelete(name string) error {
+func (s *EmptyStore) GenericDelete(name, version string) error {
 	return nil
 }
 
+// GenericList returns an empty list of Generic templates.
+func (s *EmptyStore) GenericList() ([]*storagepb.TemplateInfo, error) {
+	return []*storagepb.TemplateInfo{}, nil
+}
+
+// CloudPut returns an error writing any Cloud-Config template.
+func (s *EmptyStore) CloudPut(name string, config []byte, version string) error {
+	return fmt.Errorf("emptyStore does not accept Cloud-Config templates")
+}
+
 // CloudGet returns a Cloud-config template not found error.
 func (s *EmptyStore) CloudGet(name string) (string, error) {
-	return "", fmt.Errorf("no Cloud-Config template %s", name)
+	return "", notFound("no Cloud-Config template %s", name)
+}
+
+// CloudDelete returns a nil error (successful deletion).
+func (s *EmptyStore) CloudDelete(name, version string) error {
+	return nil
+}
+
+// CloudList returns an empty list of Cloud-Config templates.
+func (s *EmptyStore) CloudList() ([]*storagepb.TemplateInfo, error) {
+	return []*storagepb.TemplateInfo{}, nil
+}
+
+// PartialPut returns an error writing any template partial.
+func (s *EmptyStore) PartialPut(name string, config []byte, version string) error {
+	return fmt.Errorf("emptyStore does not accept template partials")
+}
+
+// PartialGet returns a template partial not found error.
+func (s *EmptyStore) PartialGet(name string) (string, error) {
+	return "", notFound("no template partial %s", name)
+}
+
+// PartialDelete returns a nil error (successful deletion).
+func (s *EmptyStore) PartialDelete(name, version string) error {
+	return nil
+}
+
+// PartialList returns an empty list of template partials.
+func (s *EmptyStore) PartialList() (names []string, err error) {
+	return names, nil
+}
+
+// Apply returns an error applying any Batch.
+func (s *EmptyStore) Apply(batch *storagepb.Batch) error {
+	return fmt.Errorf("emptyStore does not accept Batches")
 }
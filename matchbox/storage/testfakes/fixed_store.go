@@ -2,17 +2,22 @@ package testfakes
 
 import (
 	"fmt"
+	"os"
+	"sort"
 
 	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
 )
 
-// FixedStore is used for testing purposes.
+// FixedStore is used for testing purposes. Resource versions are not checked.
 type FixedStore struct {
 	Groups          map[string]*storagepb.Group
 	Profiles        map[string]*storagepb.Profile
 	IgnitionConfigs map[string]string
 	CloudConfigs    map[string]string
 	GenericConfigs  map[string]string
+	Partials        map[string]string
+	// resources reported as unparsable
+	InvalidResources []*storagepb.InvalidResource
 }
 
 // NewFixedStore returns a new FixedStore.
@@ -23,6 +28,7 @@ func NewFixedStore() *FixedStore {
 		IgnitionConfigs: make(map[string]string),
 		CloudConfigs:    make(map[string]string),
 		GenericConfigs:  make(map[string]string),
+		Partials:        make(map[string]string),
 	}
 }
 
@@ -37,11 +43,11 @@ func (s *FixedStore) GroupGet(id string) (*storagepb.Group, error) {
 	if group, present := s.Groups[id]; present {
 		return group, nil
 	}
-	return nil, fmt.Errorf("Group not found")
+	return nil, notFound("Group not found")
 }
 
 // GroupDelete deletes the Group from the Groups map with the given id.
-func (s *FixedStore) GroupDelete(id string) error {
+func (s *FixedStore) GroupDelete(id, version string) error {
 	delete(s.Groups, id)
 	return nil
 }
@@ -68,11 +74,11 @@ func (s *FixedStore) ProfileGet(id string) (*storagepb.Profile, error) {
 	if profile, present := s.Profiles[id]; present {
 		return profile, nil
 	}
-	return nil, fmt.Errorf("Profile not found")
+	return nil, notFound("Profile not found")
 }
 
 // ProfileDelete deletes the Profile from the Profiles map with the given id.
-func (s *FixedStore) ProfileDelete(id string) error {
+func (s *FixedStore) ProfileDelete(id, version string) error {
 	delete(s.Profiles, id)
 	return nil
 }
@@ -89,7 +95,7 @@ func (s *FixedStore) ProfileList() ([]*storagepb.Profile, error) {
 }
 
 // IgnitionPut create or updates an Ignition template.
-func (s *FixedStore) IgnitionPut(name string, config []byte) error {
+func (s *FixedStore) IgnitionPut(name string, config []byte, version string) error {
 	s.IgnitionConfigs[name] = string(config)
 	return nil
 }
@@ -99,17 +105,22 @@ func (s *FixedStore) IgnitionGet(name string) (string, error) {
 	if config, present := s.IgnitionConfigs[name]; present {
 		return config, nil
 	}
-	return "", fmt.Errorf("no Ignition template %s", name)
+	return "", notFound("no Ignition template %s", name)
 }
 
 // IgnitionDelete deletes an Ignition template by name.
-func (s *FixedStore) IgnitionDelete(name string) error {
+func (s *FixedStore) IgnitionDelete(name, version string) error {
 	delete(s.IgnitionConfigs, name)
 	return nil
 }
 
+// IgnitionList returns the IgnitionConfigs map as sorted TemplateInfos.
+func (s *FixedStore) IgnitionList() ([]*storagepb.TemplateInfo, error) {
+	return templateList(s.IgnitionConfigs), nil
+}
+
 // GenericPut create or updates an Generic template.
-func (s *FixedStore) GenericPut(name string, config []byte) error {
+func (s *FixedStore) GenericPut(name string, config []byte, version string) error {
 	s.GenericConfigs[name] = string(config)
 	return nil
 }
@@ -119,19 +130,136 @@ func (s *FixedStore) GenericGet(name string) (string, error) {
 	if config, present := s.GenericConfigs[name]; present {
 		return config, nil
 	}
-	return "", fmt.Errorf("no Generic template %s", name)
+	return "", notFound("no Generic template %s", name)
 }
 
 // GenericDelete deletes an Generic template by name.
-func (s *FixedStore) GenericDelete(name string) error {
+func (s *FixedStore) GenericDelete(name, version string) error {
 	delete(s.GenericConfigs, name)
 	return nil
 }
 
+// GenericList returns the GenericConfigs map as sorted TemplateInfos.
+func (s *FixedStore) GenericList() ([]*storagepb.TemplateInfo, error) {
+	return templateList(s.GenericConfigs), nil
+}
+
+// CloudPut create or updates a Cloud-Config template.
+func (s *FixedStore) CloudPut(name string, config []byte, version string) error {
+	s.CloudConfigs[name] = string(config)
+	return nil
+}
+
 // CloudGet returns a Cloud-config template by name.
 func (s *FixedStore) CloudGet(name string) (string, error) {
 	if config, present := s.CloudConfigs[name]; present {
 		return config, nil
 	}
-	return "", fmt.Errorf("no Cloud-Config template %s", name)
+	return "", notFound("no Cloud-Config template %s", name)
+}
+
+// CloudDelete deletes a Cloud-Config template by name.
+func (s *FixedStore) CloudDelete(name, version string) error {
+	delete(s.CloudConfigs, name)
+	return nil
+}
+
+// CloudList returns the CloudConfigs map as sorted TemplateInfos.
+func (s *FixedStore) CloudList() ([]*storagepb.TemplateInfo, error) {
+	return templateList(s.CloudConfigs), nil
+}
+
+// PartialPut create or updates a template partial.
+func (s *FixedStore) PartialPut(name string, config []byte, version string) error {
+	s.Partials[name] = string(config)
+	return nil
+}
+
+// PartialGet returns a template partial by name.
+func (s *FixedStore) PartialGet(name string) (string, error) {
+	if config, present := s.Partials[name]; present {
+		return config, nil
+	}
+	return "", notFound("no template partial %s", name)
+}
+
+// PartialDelete deletes a template partial by name.
+func (s *FixedStore) PartialDelete(name, version string) error {
+	delete(s.Partials, name)
+	return nil
+}
+
+// PartialList returns the sorted names of the Partials map.
+func (s *FixedStore) PartialList() ([]string, error) {
+	names := make([]string, 0, len(s.Partials))
+	for name := range s.Partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Apply writes and deletes the resources of a Batch in the maps.
+func (s *FixedStore) Apply(batch *storagepb.Batch) error {
+	for _, group := range batch.Groups {
+		s.Groups[group.Id] = group
+	}
+	for _, profile := range batch.Profiles {
+		s.Profiles[profile.Id] = profile
+	}
+	templates := map[string]map[string]string{
+		"Ignition": s.IgnitionConfigs,
+		"Generic":  s.GenericConfigs,
+		"Cloud":    s.CloudConfigs,
+		"Partial":  s.Partials,
+	}
+	for kind, list := range map[string][]*storagepb.Template{
+		"Ignition": batch.Ignition,
+		"Generic":  batch.Generic,
+		"Cloud":    batch.Cloud,
+		"Partial":  batch.Partials,
+	} {
+		for _, template := range list {
+			templates[kind][template.Name] = string(template.Contents)
+		}
+	}
+	for _, deletion := range batch.Deletes {
+		switch deletion.Kind {
+		case "Group":
+			delete(s.Groups, deletion.Name)
+		case "Profile":
+			delete(s.Profiles, deletion.Name)
+		default:
+			if m, ok := templates[deletion.Kind]; ok {
+				delete(m, deletion.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Invalid returns the InvalidResources.
+func (s *FixedStore) Invalid() ([]*storagepb.InvalidResource, error) {
+	if s.InvalidResources == nil {
+		return []*storagepb.InvalidResource{}, nil
+	}
+	return s.InvalidResources, nil
+}
+
+// templateList returns TemplateInfos for the templates, sorted by name.
+func templateList(templates map[string]string) []*storagepb.TemplateInfo {
+	infos := make([]*storagepb.TemplateInfo, 0, len(templates))
+	for name, template := range templates {
+		infos = append(infos, &storagepb.TemplateInfo{Name: name, Size: int64(len(template))})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// notFound returns an error for a missing resource, which wraps
+// os.ErrNotExist like the errors of a missing file.
+func notFound(format string, a ...interface{}) error {
+	return fmt.Errorf(format+": %w", append(a, os.ErrNotExist)...)
 }
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/poseidon/matchbox/matchbox/storage/storagepb"
+)
+
+// ErrVersionConflict is returned when a write expects a resource version
+// which doesn't match the stored resource.
+var ErrVersionConflict = errors.New("storage: resource version conflict")
+
+// NoVersion is the resource version expected of resources which must not
+// exist. Puts which expect it create a resource, but fail with
+// ErrVersionConflict rather than overwrite an existing one.
+const NoVersion = "none"
+
+// Version returns the resource version of stored resource data. Versions are
+// hashes of the stored contents, not generation numbers: every modification
+// changes them, but writing identical contents doesn't, and restoring earlier
+// contents restores the earlier version. A write which expects a version
+// succeeds whenever the stored contents are those the version was read from,
+// even if they were changed and changed back in the meantime.
+func Version(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// GroupVersion returns the resource version the Group has once stored.
+func GroupVersion(group *storagepb.Group) (string, error) {
+	data, err := encodeGroup(group)
+	if err != nil {
+		return "", err
+	}
+	return Version(data), nil
+}
+
+// ProfileVersion returns the resource version the Profile has once stored.
+func ProfileVersion(profile *storagepb.Profile) (string, error) {
+	data, err := encodeProfile(profile)
+	if err != nil {
+		return "", err
+	}
+	return Version(data), nil
+}
+
+// checkVersion returns ErrVersionConflict if an expected resource version is
+// given and doesn't match the version of the stored data. Resources which
+// don't exist only match NoVersion.
+func checkVersion(data []byte, exists bool, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	if expected == NoVersion {
+		if exists {
+			return ErrVersionConflict
+		}
+		return nil
+	}
+	if !exists || Version(data) != expected {
+		return ErrVersionConflict
+	}
+	return nil
+}
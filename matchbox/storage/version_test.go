@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	fake "github.com/poseidon/matchbox/matchbox/storage/testfakes"
+)
+
+func TestVersion(t *testing.T) {
+	// assert that:
+	// - versions are stable for the same data
+	// - versions differ for different data
+	assert.Equal(t, Version([]byte("a")), Version([]byte("a")))
+	assert.NotEqual(t, Version([]byte("a")), Version([]byte("b")))
+	assert.Len(t, Version(nil), 16)
+}
+
+func TestFileStore_ResourceVersions(t *testing.T) {
+	dir, err := setup(&fake.FixedStore{})
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	testResourceVersions(t, NewFileStore(&Config{Root: dir}))
+}
+
+// testResourceVersions tests a Store's handling of resource versions.
+func testResourceVersions(t *testing.T, store Store) {
+	// assert that:
+	// - stored Groups and Profiles have resource versions
+	// - writes which expect the current version succeed and change it
+	// - writes and deletes which expect a stale version fail
+	// - writes which expect a version of a missing resource fail
+	// - writes which expect NoVersion create resources, but don't overwrite
+	// - GroupVersion and ProfileVersion match the stored versions
+	// - versions are content hashes, which recur when contents are restored
+	group := fake.Group.Copy()
+	group.ResourceVersion = NoVersion
+	assert.Nil(t, store.GroupPut(group))
+	assert.Equal(t, ErrVersionConflict, store.GroupPut(group))
+	group, err := store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	stale := group.ResourceVersion
+	assert.NotEmpty(t, stale)
+	version, err := GroupVersion(group)
+	assert.Nil(t, err)
+	assert.Equal(t, stale, version)
+	group.Name = "updated"
+	assert.Nil(t, store.GroupPut(group))
+	group, err = store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.NotEqual(t, stale, group.ResourceVersion)
+	group.ResourceVersion = stale
+	assert.Equal(t, ErrVersionConflict, store.GroupPut(group))
+	assert.Equal(t, ErrVersionConflict, store.GroupDelete(fake.Group.Id, stale))
+	group, err = store.GroupGet(fake.Group.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, "updated", group.Name)
+	assert.Nil(t, store.GroupDelete(fake.Group.Id, group.ResourceVersion))
+
+	profile := fake.Profile.Copy()
+	profile.ResourceVersion = "0123456789abcdef"
+	assert.Equal(t, ErrVersionConflict, store.ProfilePut(profile))
+	profile.ResourceVersion = ""
+	assert.Nil(t, store.ProfilePut(profile))
+	profile, err = store.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, profile.ResourceVersion)
+	version, err = ProfileVersion(profile)
+	assert.Nil(t, err)
+	assert.Equal(t, profile.ResourceVersion, version)
+	assert.Nil(t, store.ProfilePut(profile))
+	// versions are derived from content, not stored
+	stored, err := store.ProfileGet(fake.Profile.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, profile.ResourceVersion, stored.ResourceVersion)
+	assert.Nil(t, store.ProfileDelete(fake.Profile.Id, stored.ResourceVersion))
+
+	assert.Nil(t, store.IgnitionPut("ignition.yaml", []byte("a"), ""))
+	assert.Equal(t, ErrVersionConflict, store.IgnitionPut("ignition.yaml", []byte("c"), Version([]byte("b"))))
+	assert.Nil(t, store.IgnitionPut("ignition.yaml", []byte("b"), Version([]byte("a"))))
+	assert.Nil(t, store.IgnitionPut("ignition.yaml", []byte("a"), Version([]byte("b"))))
+	assert.Nil(t, store.IgnitionPut("ignition.yaml", []byte("b"), Version([]byte("a"))))
+	assert.Equal(t, ErrVersionConflict, store.IgnitionDelete("ignition.yaml", Version([]byte("a"))))
+	assert.Nil(t, store.IgnitionDelete("ignition.yaml", Version([]byte("b"))))
+	assert.Equal(t, ErrVersionConflict, store.IgnitionPut("ignition.yaml", []byte("c"), Version([]byte("b"))))
+	assert.Nil(t, store.IgnitionPut("ignition.yaml", []byte("c"), NoVersion))
+	assert.Equal(t, ErrVersionConflict, store.IgnitionPut("ignition.yaml", []byte("d"), NoVersion))
+	assert.Nil(t, store.IgnitionDelete("ignition.yaml", Version([]byte("c"))))
+}